@@ -0,0 +1,773 @@
+package btrees
+
+import "sort"
+
+type (
+	K = int // NOTE: generic type placeholder
+	V = int // NOTE: generic type placeholder
+)
+
+// DefaultDegree is the degree of a Map created with Make or of the
+// zero-value Map.
+const DefaultDegree = 32
+
+// Map is an ordered map of keys to values, backed by an in-memory B-tree.
+// Its keys are kept in ascending order as defined by the standard <, <=, >,
+// >= operators, so that they can be iterated in order and queried by range.
+// Compared to a binary search tree, each node stores many keys in
+// contiguous memory, which uses less memory per key and is more cache
+// friendly. Its zero-value is ready to use, with the DefaultDegree.
+//
+// A B-tree of degree t has nodes with at most 2t-1 keys and 2t children,
+// and all nodes except the root have at least t-1 keys. All leaves are at
+// the same depth, so its height is O(log_t n).
+//
+// Maps support cheap snapshots with Clone: the nodes are shared between the
+// clones and copied lazily when they are modified (copy-on-write).
+type Map /*[K algo.Ordered, V algo.Any]*/ struct {
+	root   *node /*[K, V]*/
+	len    int
+	degree int
+	cow    *cowToken
+}
+
+type node /*[K algo.Ordered, V algo.Any]*/ struct {
+	keys     []K
+	vals     []V
+	children []*node   /*[K, V]*/ // nil for a leaf
+	cow      *cowToken // the map that owns the node, it is shared otherwise
+}
+
+// cowToken identifies the map that owns a node and can modify it in place.
+// It must not be a zero-size type, as pointers to distinct zero-size values
+// may be equal.
+type cowToken struct{ _ byte }
+
+// Make returns an ordered map of some key and value types, with the
+// DefaultDegree.
+func Make /*[K algo.Ordered, V algo.Any]*/ () *Map /*[K, V]*/ {
+	return MakeDegree /*[K, V]*/ (DefaultDegree)
+}
+
+// MakeDegree returns an ordered map of some key and value types, with the
+// specified degree. It panics if degree is smaller than 2.
+func MakeDegree /*[K algo.Ordered, V algo.Any]*/ (degree int) *Map /*[K, V]*/ {
+	if degree < 2 {
+		panic("btrees: invalid degree")
+	}
+	return &Map /*[K, V]*/ {degree: degree}
+}
+
+// MakeFrom returns an ordered map of some key and value types with the
+// specified degree, bulk-loaded from the sorted keys and their associated
+// values, e.g. as returned by sort.Merge. If a key is repeated, the last
+// value associated with it is kept. If vals is nil, all keys are associated
+// with the zero value of V. It panics if degree is smaller than 2, if keys
+// are not sorted in ascending order or if vals is not nil and does not have
+// the same length as keys.
+//
+// It runs in O(n) time complexity, instead of O(n log n) for inserting the
+// keys one by one.
+func MakeFrom /*[K algo.Ordered, V algo.Any]*/ (degree int, keys []K, vals []V) *Map /*[K, V]*/ {
+	m := MakeDegree /*[K, V]*/ (degree)
+	if vals != nil && len(vals) != len(keys) {
+		panic("btrees: keys and values of different lengths")
+	}
+
+	// remove duplicates, keeping the last value
+	ks := make([]K, 0, len(keys))
+	vs := make([]V, 0, len(keys))
+	for i, k := range keys {
+		var v V
+		if vals != nil {
+			v = vals[i]
+		}
+		if l := len(ks); l > 0 && k <= ks[l-1] {
+			if k < ks[l-1] {
+				panic("btrees: keys not sorted")
+			}
+			vs[l-1] = v
+			continue
+		}
+		ks = append(ks, k)
+		vs = append(vs, v)
+	}
+	if len(ks) == 0 {
+		return m
+	}
+
+	// find the smallest height that can hold all keys, a tree of height h
+	// (0 for a single leaf) holds at most (2t)^(h+1)-1 keys.
+	var h int
+	for capacity(m.degree, h) < len(ks) {
+		h++
+	}
+	m.root = m.build(ks, vs, h)
+	m.len = len(ks)
+	return m
+}
+
+// returns the maximum number of keys of a subtree of height h, which is
+// (2t)^(h+1)-1.
+func capacity(degree, h int) int {
+	n := 1
+	for i := 0; i <= h; i++ {
+		n *= 2 * degree
+	}
+	return n - 1
+}
+
+// builds the subtree of height h holding the sorted keys. Each child holds
+// about the same number of keys, and there are as few children as possible,
+// so that each one is at least half full.
+func (m *Map /*[K, V]*/) build(keys []K, vals []V, h int) *node /*[K, V]*/ {
+	n := m.newNode(len(keys), h > 0)
+	if h == 0 {
+		n.keys = append(n.keys, keys...)
+		n.vals = append(n.vals, vals...)
+		return n
+	}
+
+	sub := capacity(m.degree, h-1)
+	c := (len(keys) + sub + 1) / (sub + 1) // ceil((len+1) / (sub+1))
+	size, extra := (len(keys)-(c-1))/c, (len(keys)-(c-1))%c
+	for i := 0; i < c; i++ {
+		l := size
+		if i < extra {
+			l++
+		}
+		n.children = append(n.children, m.build(keys[:l], vals[:l], h-1))
+		if i < c-1 {
+			n.keys = append(n.keys, keys[l])
+			n.vals = append(n.vals, vals[l])
+			l++
+		}
+		keys, vals = keys[l:], vals[l:]
+	}
+	return n
+}
+
+// returns the degree of the map, using the DefaultDegree for the zero-value.
+func (m *Map /*[K, V]*/) minDegree() int {
+	if m.degree == 0 {
+		return DefaultDegree
+	}
+	return m.degree
+}
+
+// returns the maximum number of keys in a node.
+func (m *Map /*[K, V]*/) maxKeys() int {
+	return 2*m.minDegree() - 1
+}
+
+// returns the minimum number of keys in a non-root node.
+func (m *Map /*[K, V]*/) minKeys() int {
+	return m.minDegree() - 1
+}
+
+// returns a new node owned by m, with room for size keys.
+func (m *Map /*[K, V]*/) newNode(size int, internal bool) *node /*[K, V]*/ {
+	if size < m.maxKeys() {
+		size = m.maxKeys()
+	}
+	n := &node /*[K, V]*/ {
+		keys: make([]K, 0, size),
+		vals: make([]V, 0, size),
+		cow:  m.cow,
+	}
+	if internal {
+		n.children = make([]*node /*[K, V]*/, 0, size+1)
+	}
+	return n
+}
+
+// returns n if it is owned by m, or a copy of n owned by m otherwise.
+func (m *Map /*[K, V]*/) mutable(n *node /*[K, V]*/) *node /*[K, V]*/ {
+	if n.cow == m.cow {
+		return n
+	}
+	c := m.newNode(len(n.keys), n.children != nil)
+	c.keys = append(c.keys, n.keys...)
+	c.vals = append(c.vals, n.vals...)
+	if n.children != nil {
+		c.children = append(c.children, n.children...)
+	}
+	return c
+}
+
+// returns the child i of n, after making it mutable. n must be mutable.
+func (m *Map /*[K, V]*/) mutableChild(n *node /*[K, V]*/, i int) *node /*[K, V]*/ {
+	c := m.mutable(n.children[i])
+	n.children[i] = c
+	return c
+}
+
+// Degree returns the degree of the map m.
+func (m *Map /*[K, V]*/) Degree() int {
+	return m.minDegree()
+}
+
+// Len returns the number of keys in the map m.
+func (m *Map /*[K, V]*/) Len() int {
+	return m.len
+}
+
+// Clone returns a copy of the map m. The nodes of the tree are shared
+// between m and its copy until they are modified, at which point the
+// modified nodes are copied, so that changes to one map are not visible in
+// the other.
+//
+// It runs in O(1) time complexity, but subsequent changes to either map are
+// slower until the shared nodes on their path have been copied.
+func (m *Map /*[K, V]*/) Clone() *Map /*[K, V]*/ {
+	// both maps get a new token, so that neither owns the shared nodes.
+	c := *m
+	m.cow, c.cow = new(cowToken), new(cowToken)
+	return &c
+}
+
+// Get returns the value associated with k and true, or the zero value of V
+// and false if k is not in the map.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Get(k K) (V, bool) {
+	for n := m.root; n != nil; {
+		i, found := n.search(k)
+		if found {
+			return n.vals[i], true
+		}
+		if n.children == nil {
+			break
+		}
+		n = n.children[i]
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether k is in the map m.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Contains(k K) bool {
+	_, ok := m.Get(k)
+	return ok
+}
+
+// Put associates the value v with the key k in the map m, replacing the
+// previous value if k is already in m.
+//
+// It runs in O(log n) time complexity.
+func (m *Map /*[K, V]*/) Put(k K, v V) {
+	if m.root == nil {
+		m.root = m.newNode(0, false)
+	}
+	m.root = m.mutable(m.root)
+
+	// the tree grows at the root, by splitting it when it is full.
+	if len(m.root.keys) == m.maxKeys() {
+		old := m.root
+		m.root = m.newNode(0, true)
+		m.root.children = append(m.root.children, old)
+		m.splitChild(m.root, 0)
+	}
+	if m.insert(m.root, k, v) {
+		m.len++
+	}
+}
+
+// inserts k and v in the subtree rooted at n, which must be mutable and not
+// full, and returns true if k was added, false if its value was replaced.
+func (m *Map /*[K, V]*/) insert(n *node /*[K, V]*/, k K, v V) bool {
+	for {
+		i, found := n.search(k)
+		if found {
+			n.vals[i] = v
+			return false
+		}
+		if n.children == nil {
+			n.keys = insertAt(n.keys, i, k)
+			n.vals = insertValAt(n.vals, i, v)
+			return true
+		}
+
+		// split a full child before descending, so that there is always room
+		// for a key moving up from a split.
+		if len(n.children[i].keys) == m.maxKeys() {
+			m.splitChild(n, i)
+			switch mid := n.keys[i]; {
+			case k == mid:
+				n.vals[i] = v
+				return false
+			case k > mid:
+				i++
+			}
+		}
+		n = m.mutableChild(n, i)
+	}
+}
+
+// splits the full child i of n in two, moving its middle key up in n. n
+// must be mutable.
+func (m *Map /*[K, V]*/) splitChild(n *node /*[K, V]*/, i int) {
+	c := m.mutableChild(n, i)
+	mid := m.minDegree() - 1
+
+	r := m.newNode(0, c.children != nil)
+	r.keys = append(r.keys, c.keys[mid+1:]...)
+	r.vals = append(r.vals, c.vals[mid+1:]...)
+	if c.children != nil {
+		r.children = append(r.children, c.children[mid+1:]...)
+		clearNodes(c.children[mid+1:])
+		c.children = c.children[:mid+1]
+	}
+
+	n.keys = insertAt(n.keys, i, c.keys[mid])
+	n.vals = insertValAt(n.vals, i, c.vals[mid])
+	n.children = insertNodeAt(n.children, i+1, r)
+
+	clearVals(c.vals[mid:])
+	c.keys, c.vals = c.keys[:mid], c.vals[:mid]
+}
+
+// Delete removes the key k and its associated value from the map m and
+// returns true, or false if k is not in m.
+//
+// It runs in O(log n) time complexity.
+func (m *Map /*[K, V]*/) Delete(k K) bool {
+	if m.root == nil || !m.Contains(k) {
+		return false
+	}
+	m.root = m.mutable(m.root)
+	m.remove(m.root, k)
+	m.len--
+
+	// the tree shrinks at the root, when it has no key left.
+	if len(m.root.keys) == 0 {
+		if m.root.children == nil {
+			m.root = nil
+		} else {
+			m.root = m.root.children[0]
+		}
+	}
+	return true
+}
+
+// removes k, which must be in the subtree rooted at n, from that subtree. n
+// must be mutable, and it must have more than the minimum number of keys
+// unless it is the root.
+func (m *Map /*[K, V]*/) remove(n *node /*[K, V]*/, k K) {
+	for {
+		i, found := n.search(k)
+		if n.children == nil {
+			n.keys = removeAt(n.keys, i)
+			n.vals = removeValAt(n.vals, i)
+			return
+		}
+
+		// ensure the child to descend into has more than the minimum number of
+		// keys, so that a key can be removed from it, then search again as the
+		// keys of n may have moved.
+		if len(n.children[i].keys) <= m.minKeys() {
+			m.growChild(n, i)
+			continue
+		}
+
+		c := m.mutableChild(n, i)
+		if found {
+			// replace the key with its predecessor, the largest key of the left
+			// child, and remove that predecessor.
+			n.keys[i], n.vals[i] = m.removeMax(c)
+			return
+		}
+		n = c
+	}
+}
+
+// removes the largest key of the subtree rooted at n and returns it with
+// its value. n must be mutable and have more than the minimum number of
+// keys.
+func (m *Map /*[K, V]*/) removeMax(n *node /*[K, V]*/) (K, V) {
+	for n.children != nil {
+		i := len(n.children) - 1
+		if len(n.children[i].keys) <= m.minKeys() {
+			m.growChild(n, i)
+			continue
+		}
+		n = m.mutableChild(n, i)
+	}
+	l := len(n.keys) - 1
+	k, v := n.keys[l], n.vals[l]
+	n.keys = removeAt(n.keys, l)
+	n.vals = removeValAt(n.vals, l)
+	return k, v
+}
+
+// grows the child i of n, which has the minimum number of keys, by taking a
+// key from a sibling that has more than the minimum, or by merging it with
+// a sibling otherwise. n must be mutable.
+func (m *Map /*[K, V]*/) growChild(n *node /*[K, V]*/, i int) {
+	switch {
+	case i > 0 && len(n.children[i-1].keys) > m.minKeys():
+		// rotate a key from the left sibling through n
+		c, l := m.mutableChild(n, i), m.mutableChild(n, i-1)
+		last := len(l.keys) - 1
+		c.keys = insertAt(c.keys, 0, n.keys[i-1])
+		c.vals = insertValAt(c.vals, 0, n.vals[i-1])
+		n.keys[i-1], n.vals[i-1] = l.keys[last], l.vals[last]
+		l.keys = removeAt(l.keys, last)
+		l.vals = removeValAt(l.vals, last)
+		if l.children != nil {
+			c.children = insertNodeAt(c.children, 0, l.children[last+1])
+			l.children = removeNodeAt(l.children, last+1)
+		}
+
+	case i < len(n.children)-1 && len(n.children[i+1].keys) > m.minKeys():
+		// rotate a key from the right sibling through n
+		c, r := m.mutableChild(n, i), m.mutableChild(n, i+1)
+		c.keys = append(c.keys, n.keys[i])
+		c.vals = append(c.vals, n.vals[i])
+		n.keys[i], n.vals[i] = r.keys[0], r.vals[0]
+		r.keys = removeAt(r.keys, 0)
+		r.vals = removeValAt(r.vals, 0)
+		if r.children != nil {
+			c.children = append(c.children, r.children[0])
+			r.children = removeNodeAt(r.children, 0)
+		}
+
+	default:
+		// merge the child with its right sibling (or its left sibling if it is
+		// the last child) and the key that separates them in n.
+		if i == len(n.children)-1 {
+			i--
+		}
+		c, r := m.mutableChild(n, i), n.children[i+1]
+		c.keys = append(c.keys, n.keys[i])
+		c.vals = append(c.vals, n.vals[i])
+		c.keys = append(c.keys, r.keys...)
+		c.vals = append(c.vals, r.vals...)
+		if r.children != nil {
+			c.children = append(c.children, r.children...)
+		}
+		n.keys = removeAt(n.keys, i)
+		n.vals = removeValAt(n.vals, i)
+		n.children = removeNodeAt(n.children, i+1)
+	}
+}
+
+// Min returns the smallest key of the map and its value, and true, or the
+// zero values and false if the map is empty.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Min() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	for n.children != nil {
+		n = n.children[0]
+	}
+	return n.keys[0], n.vals[0], true
+}
+
+// Max returns the largest key of the map and its value, and true, or the
+// zero values and false if the map is empty.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Max() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	for n.children != nil {
+		n = n.children[len(n.children)-1]
+	}
+	l := len(n.keys) - 1
+	return n.keys[l], n.vals[l], true
+}
+
+// Floor returns the largest key of the map that is smaller than or equal to
+// k and its value, and true, or the zero values and false if there is no
+// such key.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Floor(k K) (K, V, bool) {
+	var floor *node /*[K, V]*/
+	var fi int
+	for n := m.root; n != nil; {
+		i, found := n.search(k)
+		if found {
+			return n.keys[i], n.vals[i], true
+		}
+		// the key before i is a candidate, but there may be a larger one in
+		// the child i.
+		if i > 0 {
+			floor, fi = n, i-1
+		}
+		if n.children == nil {
+			break
+		}
+		n = n.children[i]
+	}
+	if floor == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return floor.keys[fi], floor.vals[fi], true
+}
+
+// Ceiling returns the smallest key of the map that is larger than or equal
+// to k and its value, and true, or the zero values and false if there is no
+// such key.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Ceiling(k K) (K, V, bool) {
+	var ceil *node /*[K, V]*/
+	var ci int
+	for n := m.root; n != nil; {
+		i, found := n.search(k)
+		if found {
+			return n.keys[i], n.vals[i], true
+		}
+		// the key at i is a candidate, but there may be a smaller one in the
+		// child i.
+		if i < len(n.keys) {
+			ceil, ci = n, i
+		}
+		if n.children == nil {
+			break
+		}
+		n = n.children[i]
+	}
+	if ceil == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return ceil.keys[ci], ceil.vals[ci], true
+}
+
+// Keys returns a slice of all keys of the map, in ascending order.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Keys() []K {
+	var keys []K
+	if m.len > 0 {
+		keys = make([]K, 0, m.len)
+		m.Ascend(func(k K, _ V) bool {
+			keys = append(keys, k)
+			return true
+		})
+	}
+	return keys
+}
+
+// Values returns a slice of all values of the map, in ascending order of
+// their keys.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Values() []V {
+	var vals []V
+	if m.len > 0 {
+		vals = make([]V, 0, m.len)
+		m.Ascend(func(_ K, v V) bool {
+			vals = append(vals, v)
+			return true
+		})
+	}
+	return vals
+}
+
+// Ascend calls fn for each key and value of the map, in ascending order of
+// keys, until all keys have been visited or fn returns false. The map must
+// not be modified during the iteration.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Ascend(fn func(K, V) bool) {
+	m.root.ascend(fn)
+}
+
+// Descend calls fn for each key and value of the map, in descending order of
+// keys, until all keys have been visited or fn returns false. The map must
+// not be modified during the iteration.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Descend(fn func(K, V) bool) {
+	m.root.descend(fn)
+}
+
+// AscendRange calls fn for each key and value of the map in the half-open
+// range [lo, hi), in ascending order of keys, until all keys in the range
+// have been visited or fn returns false. The map must not be modified during
+// the iteration.
+//
+// It runs in O(log n + m) time complexity where m is the number of keys
+// visited.
+func (m *Map /*[K, V]*/) AscendRange(lo, hi K, fn func(K, V) bool) {
+	if lo < hi {
+		m.root.ascendRange(lo, hi, fn)
+	}
+}
+
+// DescendRange calls fn for each key and value of the map in the half-open
+// range [lo, hi), in descending order of keys, until all keys in the range
+// have been visited or fn returns false. The map must not be modified during
+// the iteration.
+//
+// It runs in O(log n + m) time complexity where m is the number of keys
+// visited.
+func (m *Map /*[K, V]*/) DescendRange(lo, hi K, fn func(K, V) bool) {
+	if lo < hi {
+		m.root.descendRange(lo, hi, fn)
+	}
+}
+
+// returns the index of the first key of n that is greater than or equal to
+// k, and whether that key is k.
+func (n *node /*[K, V]*/) search(k K) (int, bool) {
+	i := sort.Search(len(n.keys), func(i int) bool { return n.keys[i] >= k })
+	return i, i < len(n.keys) && n.keys[i] == k
+}
+
+func (n *node /*[K, V]*/) ascend(fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	for i := range n.keys {
+		if n.children != nil && !n.children[i].ascend(fn) {
+			return false
+		}
+		if !fn(n.keys[i], n.vals[i]) {
+			return false
+		}
+	}
+	if n.children != nil {
+		return n.children[len(n.keys)].ascend(fn)
+	}
+	return true
+}
+
+func (n *node /*[K, V]*/) descend(fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	for i := len(n.keys); i > 0; i-- {
+		if n.children != nil && !n.children[i].descend(fn) {
+			return false
+		}
+		if !fn(n.keys[i-1], n.vals[i-1]) {
+			return false
+		}
+	}
+	if n.children != nil {
+		return n.children[0].descend(fn)
+	}
+	return true
+}
+
+// returns false when the iteration must stop, either because fn returned
+// false or because a key past the range was reached.
+func (n *node /*[K, V]*/) ascendRange(lo, hi K, fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	// the child i holds the keys between the keys i-1 and i, so the
+	// iteration starts with the child of the first key that is >= lo.
+	i, _ := n.search(lo)
+	for ; i <= len(n.keys); i++ {
+		if n.children != nil && !n.children[i].ascendRange(lo, hi, fn) {
+			return false
+		}
+		if i == len(n.keys) {
+			break
+		}
+		if n.keys[i] >= hi || !fn(n.keys[i], n.vals[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// returns false when the iteration must stop, either because fn returned
+// false or because a key past the range was reached.
+func (n *node /*[K, V]*/) descendRange(lo, hi K, fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	i, _ := n.search(hi)
+	for ; i >= 0; i-- {
+		if n.children != nil && !n.children[i].descendRange(lo, hi, fn) {
+			return false
+		}
+		if i == 0 {
+			break
+		}
+		if n.keys[i-1] < lo || !fn(n.keys[i-1], n.vals[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func insertAt /*[K algo.Any]*/ (ks []K, i int, k K) []K {
+	var zero K
+	ks = append(ks, zero)
+	copy(ks[i+1:], ks[i:])
+	ks[i] = k
+	return ks
+}
+
+func insertValAt /*[V algo.Any]*/ (vs []V, i int, v V) []V {
+	var zero V
+	vs = append(vs, zero)
+	copy(vs[i+1:], vs[i:])
+	vs[i] = v
+	return vs
+}
+
+func insertNodeAt /*[K, V algo.Any]*/ (ns []*node /*[K, V]*/, i int, n *node /*[K, V]*/) []*node /*[K, V]*/ {
+	ns = append(ns, nil)
+	copy(ns[i+1:], ns[i:])
+	ns[i] = n
+	return ns
+}
+
+func removeAt /*[K algo.Any]*/ (ks []K, i int) []K {
+	copy(ks[i:], ks[i+1:])
+	var zero K
+	ks[len(ks)-1] = zero
+	return ks[:len(ks)-1]
+}
+
+func removeValAt /*[V algo.Any]*/ (vs []V, i int) []V {
+	copy(vs[i:], vs[i+1:])
+	var zero V
+	vs[len(vs)-1] = zero
+	return vs[:len(vs)-1]
+}
+
+// the removed node is cleared so that it can be garbage-collected.
+func removeNodeAt /*[K, V algo.Any]*/ (ns []*node /*[K, V]*/, i int) []*node /*[K, V]*/ {
+	copy(ns[i:], ns[i+1:])
+	ns[len(ns)-1] = nil
+	return ns[:len(ns)-1]
+}
+
+// clears the values so that they can be garbage-collected.
+func clearVals /*[V algo.Any]*/ (vs []V) {
+	var zero V
+	for i := range vs {
+		vs[i] = zero
+	}
+}
+
+// clears the nodes so that they can be garbage-collected.
+func clearNodes /*[K, V algo.Any]*/ (ns []*node /*[K, V]*/) {
+	for i := range ns {
+		ns[i] = nil
+	}
+}
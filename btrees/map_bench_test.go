@@ -0,0 +1,217 @@
+package btrees
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/mna/algo/rbtrees"
+	"github.com/mna/algo/sets"
+)
+
+// returns a map with the keys 0, 2, 4, ..., 2*(n-1), inserted in random
+// order.
+func benchMap(n int) *Map {
+	m := Make()
+	for _, k := range rand.New(rand.NewSource(1)).Perm(n) {
+		m.Put(2*k, k)
+	}
+	return m
+}
+
+// returns a red-black tree map with the same keys as benchMap, for
+// comparison.
+func benchRBMap(n int) *rbtrees.Map {
+	m := rbtrees.Make()
+	for _, k := range rand.New(rand.NewSource(1)).Perm(n) {
+		m.Put(2*k, k)
+	}
+	return m
+}
+
+func BenchmarkMap_Put(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// replace existing keys, so that the size of the map is stable
+				m.Put(2*(i%n), i)
+			}
+		})
+	}
+}
+
+func BenchmarkMap_PutDelete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("btree;n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				k := 2*(i%n) + 1
+				m.Put(k, i)
+				m.Delete(k)
+			}
+		})
+		b.Run(fmt.Sprintf("rbtree;n=%d", n), func(b *testing.B) {
+			m := benchRBMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				k := 2*(i%n) + 1
+				m.Put(k, i)
+				m.Delete(k)
+			}
+		})
+	}
+}
+
+func BenchmarkMap_PutDeleteDegree(b *testing.B) {
+	const n = 100000
+	for _, degree := range []int{2, 4, 8, 16, 32, 64, 128} {
+		b.Run(fmt.Sprintf("degree=%d", degree), func(b *testing.B) {
+			m := MakeDegree(degree)
+			for _, k := range rand.New(rand.NewSource(1)).Perm(n) {
+				m.Put(2*k, k)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				k := 2*(i%n) + 1
+				m.Put(k, i)
+				m.Delete(k)
+			}
+		})
+	}
+}
+
+func BenchmarkMap_Contains(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("btree;n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !m.Contains(2 * (i % n)) {
+					b.Fatal("Contains returned false")
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("rbtree;n=%d", n), func(b *testing.B) {
+			m := benchRBMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !m.Contains(2 * (i % n)) {
+					b.Fatal("Contains returned false")
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("set;n=%d", n), func(b *testing.B) {
+			s := sets.Make()
+			for i := 0; i < n; i++ {
+				s.Add(2 * i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s.Contains(2 * (i % n)) {
+					b.Fatal("Contains returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMap_Floor(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, _, ok := m.Floor(2*(i%n) + 1); !ok {
+					b.Fatal("Floor returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMap_AscendRange(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("btree;n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// visit 10 keys
+				lo := 2 * (i % n)
+				m.AscendRange(lo, lo+20, func(K, V) bool { return true })
+			}
+		})
+		b.Run(fmt.Sprintf("rbtree;n=%d", n), func(b *testing.B) {
+			m := benchRBMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				lo := 2 * (i % n)
+				m.AscendRange(lo, lo+20, func(K, V) bool { return true })
+			}
+		})
+	}
+}
+
+func BenchmarkMap_Ascend(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("btree;n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				m.Ascend(func(K, V) bool { return true })
+			}
+		})
+		b.Run(fmt.Sprintf("rbtree;n=%d", n), func(b *testing.B) {
+			m := benchRBMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				m.Ascend(func(K, V) bool { return true })
+			}
+		})
+	}
+}
+
+func BenchmarkMakeFrom(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			keys := make([]K, n)
+			for i := range keys {
+				keys[i] = i
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = MakeFrom(DefaultDegree, keys, nil)
+			}
+		})
+	}
+}
+
+func BenchmarkMap_ClonePut(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// each snapshot copies the nodes on the path of the change
+				c := m.Clone()
+				c.Put(2*(i%n)+1, i)
+			}
+		})
+	}
+}
@@ -0,0 +1,445 @@
+package btrees
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	algosort "github.com/mna/algo/sort"
+)
+
+func TestMap(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var m Map
+		if m.Len() != 0 || m.Keys() != nil || m.Values() != nil || m.Degree() != DefaultDegree {
+			t.Fatal("want empty map")
+		}
+		if _, ok := m.Get(1); ok {
+			t.Fatal("want no value")
+		}
+		if _, _, ok := m.Min(); ok {
+			t.Fatal("want no min")
+		}
+		if _, _, ok := m.Max(); ok {
+			t.Fatal("want no max")
+		}
+		if _, _, ok := m.Floor(1); ok {
+			t.Fatal("want no floor")
+		}
+		if _, _, ok := m.Ceiling(1); ok {
+			t.Fatal("want no ceiling")
+		}
+		if m.Delete(1) {
+			t.Fatal("want no delete")
+		}
+		m.Ascend(func(K, V) bool {
+			t.Fatal("want no iteration")
+			return false
+		})
+		m.Put(1, 2)
+		if v, ok := m.Get(1); !ok || v != 2 || m.Len() != 1 {
+			t.Fatal("want single key")
+		}
+	})
+
+	t.Run("PutReplace", func(t *testing.T) {
+		m := MakeDegree(2)
+		for i := 0; i < 10; i++ {
+			m.Put(i, i)
+		}
+		for i := 0; i < 10; i++ {
+			m.Put(i, i*10)
+		}
+		if m.Len() != 10 {
+			t.Fatalf("want len 10, got %d", m.Len())
+		}
+		for i := 0; i < 10; i++ {
+			if v, _ := m.Get(i); v != i*10 {
+				t.Fatalf("%d: want replaced value %d, got %d", i, i*10, v)
+			}
+		}
+	})
+
+	for _, degree := range []int{2, 3, 4, 32} {
+		t.Run(fmt.Sprintf("PutDeleteRandom;degree=%d", degree), func(t *testing.T) {
+			seed := time.Now().UnixNano()
+			t.Logf("random seed: %d", seed)
+			r := rand.New(rand.NewSource(seed))
+
+			m := MakeDegree(degree)
+			ref := make(map[K]V)
+			for i := 0; i < 10000; i++ {
+				k := r.Intn(1000)
+				if r.Intn(3) == 0 {
+					_, want := ref[k]
+					if got := m.Delete(k); got != want {
+						t.Fatalf("%d: want deleted %t, got %t", k, want, got)
+					}
+					delete(ref, k)
+				} else {
+					v := r.Int()
+					m.Put(k, v)
+					ref[k] = v
+				}
+				if i%100 == 0 {
+					checkMap(t, m, ref)
+				}
+			}
+			checkMap(t, m, ref)
+
+			// delete all keys, in random order
+			for _, k := range r.Perm(1000) {
+				m.Delete(k)
+				delete(ref, k)
+			}
+			checkMap(t, m, ref)
+			if m.root != nil {
+				t.Fatal("want empty tree")
+			}
+		})
+	}
+
+	t.Run("Sequential", func(t *testing.T) {
+		m := MakeDegree(3)
+		ref := make(map[K]V)
+		for i := 0; i < 1000; i++ {
+			m.Put(i, -i)
+			ref[i] = -i
+		}
+		checkMap(t, m, ref)
+		for i := 999; i >= 500; i-- {
+			m.Delete(i)
+			delete(ref, i)
+		}
+		checkMap(t, m, ref)
+	})
+}
+
+func TestMakeFrom(t *testing.T) {
+	for _, degree := range []int{2, 3, 5, 32} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 100, 1000, 5000} {
+			t.Run(fmt.Sprintf("degree=%d;n=%d", degree, n), func(t *testing.T) {
+				keys := make([]K, n)
+				vals := make([]V, n)
+				ref := make(map[K]V)
+				for i := range keys {
+					keys[i], vals[i] = i*2, i
+					ref[i*2] = i
+				}
+				m := MakeFrom(degree, keys, vals)
+				checkMap(t, m, ref)
+
+				// the bulk-loaded tree supports changes
+				for i := 0; i < n; i++ {
+					m.Put(i*2+1, -i)
+					ref[i*2+1] = -i
+				}
+				checkMap(t, m, ref)
+				for i := 0; i < n; i += 2 {
+					m.Delete(i)
+					delete(ref, i)
+				}
+				checkMap(t, m, ref)
+			})
+		}
+	}
+
+	t.Run("Duplicates", func(t *testing.T) {
+		m := MakeFrom(2, []K{1, 1, 2, 3, 3, 3}, []V{1, 2, 3, 4, 5, 6})
+		checkMap(t, m, map[K]V{1: 2, 2: 3, 3: 6})
+	})
+
+	t.Run("NilValues", func(t *testing.T) {
+		m := MakeFrom(2, algosort.Merge([]K{5, 1, 3, 4, 2}), nil)
+		checkMap(t, m, map[K]V{1: 0, 2: 0, 3: 0, 4: 0, 5: 0})
+	})
+}
+
+func TestMapPanics(t *testing.T) {
+	cases := []struct {
+		desc string
+		fn   func()
+	}{
+		{"degree too small", func() { MakeDegree(1) }},
+		{"bulk-load degree too small", func() { MakeFrom(0, nil, nil) }},
+		{"keys not sorted", func() { MakeFrom(2, []K{1, 3, 2}, nil) }},
+		{"lengths differ", func() { MakeFrom(2, []K{1, 2}, []V{1}) }},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("want panic")
+				}
+			}()
+			c.fn()
+		})
+	}
+}
+
+func TestMapQueries(t *testing.T) {
+	for _, degree := range []int{2, 32} {
+		t.Run(fmt.Sprintf("degree=%d", degree), func(t *testing.T) {
+			m := MakeDegree(degree)
+			for _, k := range rand.Perm(50) {
+				// keys are 10, 20, ..., 500
+				m.Put((k+1)*10, (k+1)*100)
+			}
+			if k, v, ok := m.Min(); !ok || k != 10 || v != 100 {
+				t.Fatalf("want min 10, got %d", k)
+			}
+			if k, v, ok := m.Max(); !ok || k != 500 || v != 5000 {
+				t.Fatalf("want max 500, got %d", k)
+			}
+
+			cases := []struct {
+				k           K
+				floor, ceil K // -1 if none
+			}{
+				{5, -1, 10},
+				{10, 10, 10},
+				{15, 10, 20},
+				{300, 300, 300},
+				{455, 450, 460},
+				{500, 500, 500},
+				{505, 500, -1},
+			}
+			for _, c := range cases {
+				k, v, ok := m.Floor(c.k)
+				if (c.floor == -1 && ok) || (c.floor != -1 && (!ok || k != c.floor || v != c.floor*10)) {
+					t.Fatalf("%d: want floor %d, got %d (%t)", c.k, c.floor, k, ok)
+				}
+				k, v, ok = m.Ceiling(c.k)
+				if (c.ceil == -1 && ok) || (c.ceil != -1 && (!ok || k != c.ceil || v != c.ceil*10)) {
+					t.Fatalf("%d: want ceiling %d, got %d (%t)", c.k, c.ceil, k, ok)
+				}
+			}
+
+			ranges := []struct {
+				lo, hi K
+				want   []K
+			}{
+				{0, 45, []K{10, 20, 30, 40}},
+				{10, 50, []K{10, 20, 30, 40}},
+				{11, 50, []K{20, 30, 40}},
+				{200, 201, []K{200}},
+				{475, 1000, []K{480, 490, 500}},
+				{21, 29, nil},
+				{30, 30, nil},
+				{40, 20, nil},
+				{600, 700, nil},
+			}
+			for _, c := range ranges {
+				var asc, desc []K
+				m.AscendRange(c.lo, c.hi, func(k K, v V) bool {
+					asc = append(asc, k)
+					return true
+				})
+				m.DescendRange(c.lo, c.hi, func(k K, v V) bool {
+					desc = append(desc, k)
+					return true
+				})
+				if diff := cmp.Diff(c.want, asc); diff != "" {
+					t.Fatalf("[%d, %d): %s", c.lo, c.hi, diff)
+				}
+				algosort.Reverse(desc)
+				if diff := cmp.Diff(c.want, desc); diff != "" {
+					t.Fatalf("[%d, %d) descending: %s", c.lo, c.hi, diff)
+				}
+			}
+
+			// full ranges match the keys
+			var all []K
+			m.AscendRange(0, 1000, func(k K, v V) bool {
+				all = append(all, k)
+				return true
+			})
+			if diff := cmp.Diff(m.Keys(), all); diff != "" {
+				t.Fatal(diff)
+			}
+
+			// stop early
+			var got []K
+			m.AscendRange(0, 1000, func(k K, v V) bool {
+				got = append(got, k)
+				return k < 30
+			})
+			if diff := cmp.Diff([]K{10, 20, 30}, got); diff != "" {
+				t.Fatal(diff)
+			}
+			got = nil
+			m.DescendRange(0, 1000, func(k K, v V) bool {
+				got = append(got, k)
+				return k > 480
+			})
+			if diff := cmp.Diff([]K{500, 490, 480}, got); diff != "" {
+				t.Fatal(diff)
+			}
+			got = nil
+			m.Ascend(func(k K, v V) bool {
+				got = append(got, k)
+				return len(got) < 2
+			})
+			if diff := cmp.Diff([]K{10, 20}, got); diff != "" {
+				t.Fatal(diff)
+			}
+			got = nil
+			m.Descend(func(k K, v V) bool {
+				got = append(got, k)
+				return len(got) < 2
+			})
+			if diff := cmp.Diff([]K{500, 490}, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	m := MakeDegree(3)
+	ref := make(map[K]V)
+	for i := 0; i < 1000; i++ {
+		k := r.Intn(2000)
+		m.Put(k, i)
+		ref[k] = i
+	}
+
+	// take a few generations of snapshots, each one modified independently
+	type snapshot struct {
+		m   *Map
+		ref map[K]V
+	}
+	snaps := []snapshot{{m, ref}}
+	for gen := 0; gen < 5; gen++ {
+		s := snaps[r.Intn(len(snaps))]
+		c := snapshot{s.m.Clone(), make(map[K]V, len(s.ref))}
+		for k, v := range s.ref {
+			c.ref[k] = v
+		}
+		snaps = append(snaps, c)
+
+		for _, s := range snaps {
+			for i := 0; i < 200; i++ {
+				k := r.Intn(2000)
+				if r.Intn(2) == 0 {
+					s.m.Delete(k)
+					delete(s.ref, k)
+				} else {
+					s.m.Put(k, -i)
+					s.ref[k] = -i
+				}
+			}
+		}
+		for _, s := range snaps {
+			checkMap(t, s.m, s.ref)
+		}
+	}
+
+	// a clone of an empty map
+	var e Map
+	c := e.Clone()
+	c.Put(1, 1)
+	if e.Len() != 0 || e.root != nil {
+		t.Fatal("want original map unchanged")
+	}
+}
+
+// checkMap checks that m contains the same keys and values as ref, and that
+// its tree is a valid B-tree.
+func checkMap(t *testing.T, m *Map, ref map[K]V) {
+	t.Helper()
+
+	keys := make([]K, 0, len(ref))
+	vals := make([]V, 0, len(ref))
+	for k := range ref {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	for _, k := range keys {
+		vals = append(vals, ref[k])
+	}
+
+	if m.Len() != len(ref) {
+		t.Fatalf("want len %d, got %d", len(ref), m.Len())
+	}
+	if diff := cmp.Diff(keys, m.Keys(), cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("keys: %s", diff)
+	}
+	if diff := cmp.Diff(vals, m.Values(), cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("values: %s", diff)
+	}
+	desc := make([]K, 0, len(keys))
+	m.Descend(func(k K, _ V) bool {
+		desc = append(desc, k)
+		return true
+	})
+	algosort.Reverse(desc)
+	if diff := cmp.Diff(keys, desc, cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("descending keys: %s", diff)
+	}
+	for _, k := range keys {
+		if v, ok := m.Get(k); !ok || v != ref[k] {
+			t.Fatalf("%d: want value %d, got %d", k, ref[k], v)
+		}
+	}
+
+	if m.root == nil {
+		if len(ref) != 0 {
+			t.Fatal("want non-empty tree")
+		}
+		return
+	}
+	if len(m.root.keys) == 0 {
+		t.Fatal("want non-empty root")
+	}
+	checkNode(t, m, m.root, true, nil, nil)
+}
+
+// checkNode checks the B-tree properties of the subtree rooted at n, whose
+// keys must be in the range (lo, hi), and returns its height.
+func checkNode(t *testing.T, m *Map, n *node, root bool, lo, hi *K) int {
+	t.Helper()
+
+	if len(n.keys) > m.maxKeys() || (!root && len(n.keys) < m.minKeys()) {
+		t.Fatalf("want between %d and %d keys, got %d", m.minKeys(), m.maxKeys(), len(n.keys))
+	}
+	if len(n.vals) != len(n.keys) {
+		t.Fatalf("want %d values, got %d", len(n.keys), len(n.vals))
+	}
+	for i, k := range n.keys {
+		if (lo != nil && k <= *lo) || (hi != nil && k >= *hi) || (i > 0 && k <= n.keys[i-1]) {
+			t.Fatalf("%d: key out of order", k)
+		}
+	}
+	if n.children == nil {
+		return 0
+	}
+	if len(n.children) != len(n.keys)+1 {
+		t.Fatalf("want %d children, got %d", len(n.keys)+1, len(n.children))
+	}
+
+	var h int
+	for i, c := range n.children {
+		clo, chi := lo, hi
+		if i > 0 {
+			clo = &n.keys[i-1]
+		}
+		if i < len(n.keys) {
+			chi = &n.keys[i]
+		}
+		ch := checkNode(t, m, c, false, clo, chi)
+		if i > 0 && ch != h {
+			t.Fatalf("leaves at different depths: %d and %d", h, ch)
+		}
+		h = ch
+	}
+	return h + 1
+}
@@ -22,3 +22,21 @@ type OrderedComparable interface {
 	Ordered
 	Comparable
 }
+
+// Bytes allows any type that is a sequence of bytes that can be indexed and
+// sliced.
+type Bytes interface {
+	/*
+		type string, []byte
+	*/
+}
+
+// Number allows any integer or floating-point type, i.e. the types that
+// support the arithmetic operators +, -, * and /.
+type Number interface {
+	/*
+		type int, int8, int16, int32, int64,
+			uint, uint8, uint16, uint32, uint64, uintptr,
+			float32, float64
+	*/
+}
@@ -0,0 +1,285 @@
+package filters
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/mna/algo/hashes"
+	"github.com/mna/algo/internal/binfmt"
+)
+
+// Bloom is a Bloom filter, a probabilistic set that can tell if a value is
+// definitely not in the set or if it may be in the set, with a configurable
+// false positive rate. It uses much less memory than a set, as it does not
+// store the values, only k bits for each value in an array of m bits. Values
+// cannot be removed from the filter.
+//
+// The k bit positions of a value are derived from its hash (as returned by
+// the hash function of the filter) using double hashing, so that the hash
+// function is only called once per value.
+type Bloom /*[T algo.Any]*/ struct {
+	words []uint64
+	m     uint64      // number of bits
+	k     int         // number of hash functions
+	hash  hashes.Func /*[T]*/
+}
+
+// MakeBloom returns a Bloom filter sized to hold n values with a false
+// positive rate of p, using the hash function to hash the values. If n is
+// smaller than 1, it is sized to hold 1 value. It panics if p is not
+// between 0 and 1 (exclusively) or if hash is nil.
+//
+// The number of bits m is -n*ln(p)/ln(2)^2 and the number of hash functions
+// k is m/n*ln(2), e.g. about 9.6 bits and 7 hash functions per value for a
+// 1% false positive rate.
+func MakeBloom /*[T algo.Any]*/ (n int, p float64, hash hashes.Func /*[T]*/) *Bloom /*[T]*/ {
+	if !(p > 0 && p < 1) {
+		panic("filters: invalid Bloom false positive rate")
+	}
+	if n < 1 {
+		n = 1
+	}
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	return MakeBloomSize /*[T]*/ (int(m), int(k), hash)
+}
+
+// MakeBloomSize returns a Bloom filter with m bits and k hash functions,
+// using the hash function to hash the values. If m or k is smaller than 1,
+// 1 is used instead. It panics if hash is nil.
+func MakeBloomSize /*[T algo.Any]*/ (m, k int, hash hashes.Func /*[T]*/) *Bloom /*[T]*/ {
+	if hash == nil {
+		panic("filters: nil hash function")
+	}
+	if m < 1 {
+		m = 1
+	}
+	if k < 1 {
+		k = 1
+	}
+	return &Bloom /*[T]*/ {
+		words: make([]uint64, (m+63)/64),
+		m:     uint64(m),
+		k:     k,
+		hash:  hash,
+	}
+}
+
+// Add adds the values to the filter f.
+//
+// It runs in O(k) time complexity for each value, where k is the number of
+// hash functions. It does not allocate.
+func (f *Bloom /*[T]*/) Add(vs ...T) {
+	for _, v := range vs {
+		h1, h2 := bloomHashes(f.hash(v))
+		for i := 0; i < f.k; i++ {
+			bit := h1 % f.m
+			f.words[bit/64] |= 1 << (bit % 64)
+			h1 += h2
+		}
+	}
+}
+
+// MayContain returns false if v is definitely not in the filter f, and true
+// if it may be in f, that is either it was added to f or it is a false
+// positive.
+//
+// It runs in O(k) time complexity where k is the number of hash functions.
+// It does not allocate.
+func (f *Bloom /*[T]*/) MayContain(v T) bool {
+	h1, h2 := bloomHashes(f.hash(v))
+	for i := 0; i < f.k; i++ {
+		bit := h1 % f.m
+		if f.words[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+		h1 += h2
+	}
+	return true
+}
+
+// returns the two hashes used to derive the bit positions of a value with
+// hash h, the second one is odd so that it cannot be 0 (which would result
+// in the same bit for all hash functions).
+func bloomHashes(h uint64) (uint64, uint64) {
+	return h, hashes.Mix(h) | 1
+}
+
+// EstimatedLen returns an estimation of the number of distinct values added
+// to the filter f, based on the number of bits set. If all bits are set, the
+// filter is saturated and the estimation is that of a filter with all but
+// one bit set, the largest estimation it can make.
+//
+// It runs in O(m) time complexity where m is the number of bits. It does not
+// allocate.
+func (f *Bloom /*[T]*/) EstimatedLen() int {
+	var x int
+	for _, w := range f.words {
+		x += bits.OnesCount64(w)
+	}
+	m := float64(f.m)
+	if x >= int(f.m) {
+		x = int(f.m) - 1
+	}
+	return int(math.Round(-m / float64(f.k) * math.Log(1-float64(x)/m)))
+}
+
+// BitLen returns the number of bits m of the filter f.
+func (f *Bloom /*[T]*/) BitLen() int {
+	return int(f.m)
+}
+
+// HashCount returns the number of hash functions k of the filter f.
+func (f *Bloom /*[T]*/) HashCount() int {
+	return f.k
+}
+
+// MarshalBinary returns the binary representation of the filter f. The
+// hash function is not part of the binary representation, the same hash
+// function must be used when unmarshaling.
+func (f *Bloom /*[T]*/) MarshalBinary() ([]byte, error) {
+	e := binfmt.NewEncoder(binfmt.KindBloom)
+	e.Uvarint(f.m)
+	e.Uvarint(uint64(f.k))
+	e.Uint64s(f.words)
+	return e.Data(), nil
+}
+
+// UnmarshalBinary sets the filter f to the binary representation in data,
+// as returned by MarshalBinary. The hash function of f is kept, so f must
+// have been created with the same hash function as the one used to create
+// the marshaled filter (see also UnmarshalBloom). It returns ErrInvalidData
+// if data is not a valid representation of a Bloom filter, in which case f
+// is unchanged.
+func (f *Bloom /*[T]*/) UnmarshalBinary(data []byte) error {
+	d := binfmt.NewDecoder(data, binfmt.KindBloom)
+	m := d.Int(maxInt)
+	k := d.Int(math.MaxInt32)
+	words := d.Uint64s()
+	if err := d.Close(); err != nil {
+		return err
+	}
+	if m == 0 || k == 0 || len(words) != (m-1)/64+1 {
+		return ErrInvalidData
+	}
+	// bits after the last one must not be set
+	if rem := m % 64; rem != 0 && words[len(words)-1]>>rem != 0 {
+		return ErrInvalidData
+	}
+
+	f.words, f.m, f.k = words, uint64(m), k
+	return nil
+}
+
+// UnmarshalBloom returns the Bloom filter represented by data, as returned
+// by MarshalBinary, using hash as hash function. It must be the same hash
+// function as the one used to create the marshaled filter. It panics if
+// hash is nil.
+func UnmarshalBloom /*[T algo.Any]*/ (data []byte, hash hashes.Func /*[T]*/) (*Bloom /*[T]*/, error) {
+	f := MakeBloomSize /*[T]*/ (1, 1, hash)
+	if err := f.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// UnionBloom returns a new Bloom filter that is the union of all filters,
+// that is a value added to any of the filters may be in the union. All
+// filters must have the same number of bits and hash functions, and must
+// use the same hash function, otherwise ErrIncompatible is returned (the
+// hash functions cannot be compared, so it is the caller's responsibility
+// to ensure they are the same). If no filter is provided, it returns nil. If
+// a single filter is provided, it returns a copy of that filter (that is, it
+// always creates a new filter if at least one filter is provided).
+//
+// The union has the same false positive rate as a filter where all values
+// would have been added.
+//
+// It runs in O(m*n) time complexity where m is the number of bits and n is
+// the number of filters.
+func UnionBloom /*[T algo.Any]*/ (filters ...*Bloom /*[T]*/) (*Bloom /*[T]*/, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	f := MakeBloomSize /*[T]*/ (int(filters[0].m), filters[0].k, filters[0].hash)
+	if err := UnionBloomInto(f, filters...); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// UnionBloomInto is like UnionBloom, but the union of the filters is stored
+// in dst, which is part of the union. If no filter is provided for the
+// union, then dst is untouched. If the filters are incompatible, it returns
+// ErrIncompatible and dst is untouched.
+//
+// Its time complexity is the same as UnionBloom.
+func UnionBloomInto /*[T algo.Any]*/ (dst *Bloom /*[T]*/, filters ...*Bloom /*[T]*/) error {
+	if !compatibleBloom(dst, filters) {
+		return ErrIncompatible
+	}
+	for _, f := range filters {
+		for i, w := range f.words {
+			dst.words[i] |= w
+		}
+	}
+	return nil
+}
+
+// IntersectBloom returns a new Bloom filter that is the intersection of all
+// filters, that is a value added to all filters may be in the intersection.
+// The filters must be compatible, as for UnionBloom. If no filter is
+// provided, it returns nil. If a single filter is provided, it returns a
+// copy of that filter (that is, it always creates a new filter if at least
+// one filter is provided).
+//
+// Note that the intersection has a false positive rate that may be higher
+// than that of a filter where only the values of the intersection would
+// have been added, as it may have bits set by values that are not in all
+// filters. Its EstimatedLen is also less accurate.
+//
+// It runs in O(m*n) time complexity where m is the number of bits and n is
+// the number of filters.
+func IntersectBloom /*[T algo.Any]*/ (filters ...*Bloom /*[T]*/) (*Bloom /*[T]*/, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	f := MakeBloomSize /*[T]*/ (int(filters[0].m), filters[0].k, filters[0].hash)
+	if err := IntersectBloomInto(f, filters...); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// IntersectBloomInto is like IntersectBloom, but the intersection of the
+// filters is added to dst. The dst filter's values are not used to find the
+// intersection, only as destination storage. If no filter is provided for
+// the intersection, then dst is untouched. If the filters are incompatible,
+// it returns ErrIncompatible and dst is untouched.
+//
+// Its time complexity is the same as IntersectBloom.
+func IntersectBloomInto /*[T algo.Any]*/ (dst *Bloom /*[T]*/, filters ...*Bloom /*[T]*/) error {
+	if !compatibleBloom(dst, filters) {
+		return ErrIncompatible
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+	for i := range dst.words {
+		w := filters[0].words[i]
+		for _, f := range filters[1:] {
+			w &= f.words[i]
+		}
+		dst.words[i] |= w
+	}
+	return nil
+}
+
+func compatibleBloom /*[T algo.Any]*/ (dst *Bloom /*[T]*/, filters []*Bloom /*[T]*/) bool {
+	for _, f := range filters {
+		if f.m != dst.m || f.k != dst.k {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package filters
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mna/algo/hashes"
+)
+
+func BenchmarkBloom_Add(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			f := MakeBloom(n, 0.01, hashes.Int)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				f.Add(i % n)
+			}
+		})
+	}
+}
+
+func BenchmarkBloom_MayContain(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			f := MakeBloom(n, 0.01, hashes.Int)
+			for i := 0; i < n; i++ {
+				f.Add(i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// half the lookups are for values in the filter
+				_ = f.MayContain(i % (2 * n))
+			}
+		})
+	}
+}
+
+func BenchmarkBloom_Union(b *testing.B) {
+	for _, nfilters := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("filters=%d;n=%d", nfilters, n), func(b *testing.B) {
+				fs := make([]*Bloom, nfilters)
+				for i := range fs {
+					fs[i] = MakeBloom(n, 0.01, hashes.Int)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					if _, err := UnionBloom(fs...); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkBloom_Marshal(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			f := MakeBloom(n, 0.01, hashes.Int)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				data, err := f.MarshalBinary()
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := UnmarshalBloom(data, hashes.Int); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package filters
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mna/algo/hashes"
+)
+
+func TestBloomSize(t *testing.T) {
+	cases := []struct {
+		n    int
+		p    float64
+		m, k int
+	}{
+		{0, 0.01, 10, 7},
+		{1, 0.01, 10, 7},
+		{1000, 0.01, 9586, 7},
+		{1000, 0.001, 14378, 10},
+		{1000, 0.5, 1443, 1},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("n=%d;p=%f", c.n, c.p), func(t *testing.T) {
+			f := MakeBloom(c.n, c.p, hashes.Int)
+			if f.BitLen() != c.m || f.HashCount() != c.k {
+				t.Fatalf("want m=%d, k=%d, got m=%d, k=%d", c.m, c.k, f.BitLen(), f.HashCount())
+			}
+		})
+	}
+}
+
+func TestBloomPanics(t *testing.T) {
+	cases := []struct {
+		desc string
+		fn   func()
+	}{
+		{"zero rate", func() { MakeBloom(1, 0, hashes.Int) }},
+		{"one rate", func() { MakeBloom(1, 1, hashes.Int) }},
+		{"NaN rate", func() { MakeBloom(1, math.NaN(), hashes.Int) }},
+		{"nil hash", func() { MakeBloom(1, 0.1, nil) }},
+		{"nil hash size", func() { MakeBloomSize(1, 1, nil) }},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("want panic")
+				}
+			}()
+			c.fn()
+		})
+	}
+}
+
+func TestBloomFalsePositiveRate(t *testing.T) {
+	const n, tests = 10000, 100000
+	for _, p := range []float64{0.1, 0.01, 0.001} {
+		t.Run(fmt.Sprintf("p=%f", p), func(t *testing.T) {
+			f := MakeBloom(n, p, hashes.Int)
+			for i := 0; i < n; i++ {
+				f.Add(i)
+			}
+
+			// no false negative
+			for i := 0; i < n; i++ {
+				if !f.MayContain(i) {
+					t.Fatalf("%d: want may contain", i)
+				}
+			}
+
+			var fp int
+			for i := n; i < n+tests; i++ {
+				if f.MayContain(i) {
+					fp++
+				}
+			}
+			rate := float64(fp) / tests
+			t.Logf("false positive rate: %f", rate)
+			if rate > p*1.25 {
+				t.Fatalf("want false positive rate of at most %f, got %f", p*1.25, rate)
+			}
+		})
+	}
+}
+
+func TestBloomEstimatedLen(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 100, 1000, 10000} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			f := MakeBloom(n, 0.01, hashes.Int)
+			for i := 0; i < n; i++ {
+				// duplicates are not counted
+				f.Add(i, i)
+			}
+			got := f.EstimatedLen()
+			if diff := math.Abs(float64(got - n)); diff > 0.05*float64(n)+1 {
+				t.Fatalf("want ~%d, got %d", n, got)
+			}
+		})
+	}
+
+	t.Run("saturated", func(t *testing.T) {
+		f := MakeBloomSize(64, 1, hashes.Int)
+		for i := 0; i < 10000; i++ {
+			f.Add(i)
+		}
+		if got := f.EstimatedLen(); got <= 64 {
+			t.Fatalf("want large estimation, got %d", got)
+		}
+	})
+}
+
+func TestBloomOps(t *testing.T) {
+	const n = 1000
+	all := MakeBloom(3*n, 0.01, hashes.Int)
+	fs := make([]*Bloom, 3)
+	for i := range fs {
+		fs[i] = MakeBloom(3*n, 0.01, hashes.Int)
+		// each filter has its own values and the shared values [-n, 0)
+		for j := 0; j < n; j++ {
+			fs[i].Add(i*n+j, -j-1)
+			all.Add(i*n+j, -j-1)
+		}
+	}
+
+	t.Run("Union", func(t *testing.T) {
+		if f, err := UnionBloom(); f != nil || err != nil {
+			t.Fatalf("want nil, got %v, %v", f, err)
+		}
+
+		f, err := UnionBloom(fs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cmp.Equal(all.words, f.words) {
+			t.Fatal("want same bits as filter with all values")
+		}
+
+		// single filter is a copy
+		f, err = UnionBloom(fs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cmp.Equal(fs[0].words, f.words) {
+			t.Fatal("want copy")
+		}
+		f.Add(-n - 1)
+		if cmp.Equal(fs[0].words, f.words) {
+			t.Fatal("want distinct copy")
+		}
+	})
+
+	t.Run("UnionInto", func(t *testing.T) {
+		dst := MakeBloom(3*n, 0.01, hashes.Int)
+		dst.Add(-2 * n)
+		if err := UnionBloomInto(dst, fs...); err != nil {
+			t.Fatal(err)
+		}
+		for i := -n; i < 3*n; i++ {
+			if !dst.MayContain(i) {
+				t.Fatalf("%d: want may contain", i)
+			}
+		}
+		if !dst.MayContain(-2 * n) {
+			t.Fatal("want dst values in union")
+		}
+	})
+
+	t.Run("Intersect", func(t *testing.T) {
+		if f, err := IntersectBloom(); f != nil || err != nil {
+			t.Fatalf("want nil, got %v, %v", f, err)
+		}
+
+		f, err := IntersectBloom(fs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := -n; i < 0; i++ {
+			if !f.MayContain(i) {
+				t.Fatalf("%d: want may contain", i)
+			}
+		}
+		var fp int
+		for i := 0; i < 3*n; i++ {
+			if f.MayContain(i) {
+				fp++
+			}
+		}
+		if rate := float64(fp) / (3 * n); rate > 0.05 {
+			t.Fatalf("want few false positives, got rate %f", rate)
+		}
+		for i, w := range f.words {
+			if w != fs[0].words[i]&fs[1].words[i]&fs[2].words[i] {
+				t.Fatalf("%d: want intersection of bits", i)
+			}
+		}
+	})
+
+	t.Run("Incompatible", func(t *testing.T) {
+		other := MakeBloom(n, 0.01, hashes.Int)
+		otherK := MakeBloomSize(all.BitLen(), all.HashCount()+1, hashes.Int)
+		for _, o := range []*Bloom{other, otherK} {
+			if _, err := UnionBloom(all, o); err != ErrIncompatible {
+				t.Fatalf("want ErrIncompatible, got %v", err)
+			}
+			if _, err := IntersectBloom(all, o); err != ErrIncompatible {
+				t.Fatalf("want ErrIncompatible, got %v", err)
+			}
+
+			dst := MakeBloom(3*n, 0.01, hashes.Int)
+			if err := UnionBloomInto(dst, all, o); err != ErrIncompatible {
+				t.Fatalf("want ErrIncompatible, got %v", err)
+			}
+			if err := IntersectBloomInto(dst, all, o); err != ErrIncompatible {
+				t.Fatalf("want ErrIncompatible, got %v", err)
+			}
+			if dst.EstimatedLen() != 0 {
+				t.Fatal("want dst untouched")
+			}
+		}
+	})
+}
+
+func TestBloomMarshal(t *testing.T) {
+	for _, n := range []int{1, 63, 64, 65, 1000} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			f := MakeBloom(n, 0.01, hashes.Int)
+			for i := 0; i < n; i++ {
+				f.Add(i)
+			}
+			data, err := f.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := UnmarshalBloom(data, hashes.Int)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.m != f.m || got.k != f.k || !cmp.Equal(f.words, got.words) {
+				t.Fatal("want same filter")
+			}
+			for i := 0; i < n; i++ {
+				if !got.MayContain(i) {
+					t.Fatalf("%d: want may contain", i)
+				}
+			}
+
+			// the receiver's hash function is kept
+			other := MakeBloom(1, 0.5, hashes.Int)
+			if err := other.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if !other.MayContain(0) || other.BitLen() != f.BitLen() {
+				t.Fatal("want unmarshaled filter")
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		f := MakeBloomSize(100, 3, hashes.Int)
+		data, _ := f.MarshalBinary()
+
+		// bits past m must not be set
+		f.words[1] |= 1 << 63
+		badBits, _ := f.MarshalBinary()
+		// number of words does not match m
+		f.m = 1000
+		badLen, _ := f.MarshalBinary()
+		// zero hash functions
+		f.words[1] &^= 1 << 63
+		f.m, f.k = 100, 0
+		badK, _ := f.MarshalBinary()
+		// huge number of bits and no words
+		f.m, f.k, f.words = math.MaxUint64, 3, nil
+		badM, _ := f.MarshalBinary()
+		f.m = uint64(maxInt)
+		badMaxM, _ := f.MarshalBinary()
+
+		cases := []struct {
+			desc string
+			data []byte
+		}{
+			{"empty", nil},
+			{"truncated", data[:len(data)-1]},
+			{"trailing", append(append([]byte(nil), data...), 0)},
+			{"bits", badBits},
+			{"len", badLen},
+			{"k", badK},
+			{"huge m", badM},
+			{"max m", badMaxM},
+		}
+		for _, c := range cases {
+			t.Run(c.desc, func(t *testing.T) {
+				g := MakeBloomSize(10, 1, hashes.Int)
+				if err := g.UnmarshalBinary(c.data); err != ErrInvalidData {
+					t.Fatalf("want ErrInvalidData, got %v", err)
+				}
+				if g.BitLen() != 10 {
+					t.Fatal("want filter unchanged")
+				}
+				if _, err := UnmarshalBloom(c.data, hashes.Int); err != ErrInvalidData {
+					t.Fatalf("want ErrInvalidData, got %v", err)
+				}
+			})
+		}
+	})
+}
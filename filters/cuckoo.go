@@ -0,0 +1,332 @@
+package filters
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/mna/algo/hashes"
+	"github.com/mna/algo/internal/binfmt"
+)
+
+// Cuckoo is a cuckoo filter, a probabilistic set that can tell if a value is
+// definitely not in the set or if it may be in the set. Unlike a Bloom
+// filter, values can be deleted from a cuckoo filter, but it has a maximum
+// capacity after which values cannot be added anymore.
+//
+// It stores a fingerprint of fpBits bits for each value in one of two
+// buckets of bucketLen entries, and its false positive rate is about
+// 2*bucketLen/2^fpBits, e.g. about 0.01% with 16-bit fingerprints and 4
+// entries per bucket, or 3% with 8-bit fingerprints. When both buckets of a
+// value are full, the fingerprint of another value is evicted to its
+// alternate bucket, repeatedly, until an empty entry is found.
+//
+// See "Cuckoo Filter: Practically Better Than Bloom", by Bin Fan, David G.
+// Andersen, Michael Kaminsky and Michael D. Mitzenmacher.
+type Cuckoo /*[T algo.Any]*/ struct {
+	words     []uint64 // packed fingerprints, 0 is an empty entry
+	fpBits    uint64
+	fpMask    uint64
+	bucketLen uint64
+	buckets   uint64 // always a power of 2
+	n         int
+	rng       uint64 // state of the random choice of the entry to evict
+	kicks     []cuckooKick
+	hash      hashes.Func /*[T]*/
+}
+
+// an eviction made while adding a value, recorded so that it can be
+// reverted if the value cannot be added.
+type cuckooKick struct {
+	bucket uint64
+	entry  uint64
+}
+
+// maximum number of evictions when adding a value.
+const maxCuckooKicks = 500
+
+// MakeCuckoo returns a cuckoo filter with room for at least n values, using
+// fingerprints of fpBits bits in buckets of bucketLen entries, and the hash
+// function to hash the values. It panics if fpBits is not between 4 and 16,
+// if bucketLen is not between 1 and 8 or if hash is nil. If n is smaller
+// than 1, it has room for at least 1 value.
+//
+// The number of buckets is a power of 2 that accounts for the maximum load
+// factor that can be reached with bucketLen entries per bucket (e.g. about
+// 95% with 4 entries). Note that the alternate bucket of a value is derived
+// from its fingerprint, so with small fingerprints (and small buckets) there
+// are few candidate alternate buckets and that load factor may not be
+// reached.
+func MakeCuckoo /*[T algo.Any]*/ (n, fpBits, bucketLen int, hash hashes.Func /*[T]*/) *Cuckoo /*[T]*/ {
+	if fpBits < 4 || fpBits > 16 {
+		panic("filters: invalid Cuckoo fingerprint size")
+	}
+	if bucketLen < 1 || bucketLen > 8 {
+		panic("filters: invalid Cuckoo bucket size")
+	}
+	if hash == nil {
+		panic("filters: nil hash function")
+	}
+	if n < 1 {
+		n = 1
+	}
+
+	load := cuckooMaxLoad[bucketLen]
+	buckets := uint64(math.Ceil(float64(n) / (float64(bucketLen) * load)))
+	if buckets&(buckets-1) != 0 {
+		buckets = 1 << uint(bits.Len64(buckets))
+	}
+	return makeCuckoo /*[T]*/ (uint64(fpBits), uint64(bucketLen), buckets, hash)
+}
+
+// maximum load factor that can be reached with high probability by number
+// of entries per bucket, from the cuckoo filter paper.
+var cuckooMaxLoad = [...]float64{1: 0.5, 2: 0.84, 3: 0.9, 4: 0.95, 5: 0.96, 6: 0.97, 7: 0.97, 8: 0.98}
+
+func makeCuckoo /*[T algo.Any]*/ (fpBits, bucketLen, buckets uint64, hash hashes.Func /*[T]*/) *Cuckoo /*[T]*/ {
+	return &Cuckoo /*[T]*/ {
+		words:     make([]uint64, (buckets*bucketLen*fpBits+63)/64),
+		fpBits:    fpBits,
+		fpMask:    1<<fpBits - 1,
+		bucketLen: bucketLen,
+		buckets:   buckets,
+		hash:      hash,
+	}
+}
+
+// Add adds v to the filter f. It returns ErrFull if there is no room left
+// for v, in which case f is unchanged. Adding the same value multiple times
+// stores its fingerprint multiple times, so that it can be deleted as many
+// times (but a value can be added at most 2*bucketLen times).
+//
+// It runs in O(1) amortized time complexity, but may require up to 500
+// evictions (and as many to revert them if it fails) when f is close to
+// full. It does not allocate, except the first time it needs to evict
+// values.
+func (f *Cuckoo /*[T]*/) Add(v T) error {
+	fp, i1, i2 := f.locate(v)
+	if f.insert(i1, fp) || f.insert(i2, fp) {
+		f.n++
+		return nil
+	}
+
+	// evict a random entry of one of the buckets, and try to insert the
+	// evicted fingerprint in its alternate bucket.
+	f.kicks = f.kicks[:0]
+	i := i1
+	if f.random()&1 == 1 {
+		i = i2
+	}
+	for k := 0; k < maxCuckooKicks; k++ {
+		e := f.random() % f.bucketLen
+		slot := i*f.bucketLen + e
+		evicted := f.get(slot)
+		f.set(slot, fp)
+		f.kicks = append(f.kicks, cuckooKick{bucket: i, entry: e})
+
+		fp = evicted
+		i = f.altIndex(i, fp)
+		if f.insert(i, fp) {
+			f.n++
+			return nil
+		}
+	}
+
+	// revert the evictions, so that the values already added are still in
+	// the filter.
+	for k := len(f.kicks) - 1; k >= 0; k-- {
+		slot := f.kicks[k].bucket*f.bucketLen + f.kicks[k].entry
+		evicted := f.get(slot)
+		f.set(slot, fp)
+		fp = evicted
+	}
+	return ErrFull
+}
+
+// Delete deletes v from the filter f and returns true, or returns false if
+// v is not in f. Only values that were added to f should be deleted, as
+// deleting another value that is a false positive would delete the
+// fingerprint of an added value.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (f *Cuckoo /*[T]*/) Delete(v T) bool {
+	fp, i1, i2 := f.locate(v)
+	if f.remove(i1, fp) || f.remove(i2, fp) {
+		f.n--
+		return true
+	}
+	return false
+}
+
+// MayContain returns false if v is definitely not in the filter f, and true
+// if it may be in f, that is either it was added to f or it is a false
+// positive.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (f *Cuckoo /*[T]*/) MayContain(v T) bool {
+	fp, i1, i2 := f.locate(v)
+	return f.find(i1, fp) >= 0 || f.find(i2, fp) >= 0
+}
+
+// Len returns the number of values in the filter f.
+func (f *Cuckoo /*[T]*/) Len() int {
+	return f.n
+}
+
+// Cap returns the number of entries of the filter f, which is the maximum
+// number of values it can hold. In practice, it can hold a number of values
+// close to its maximum load factor times its capacity.
+func (f *Cuckoo /*[T]*/) Cap() int {
+	return int(f.buckets * f.bucketLen)
+}
+
+// BitLen returns the number of bits used by the entries of the filter f.
+func (f *Cuckoo /*[T]*/) BitLen() int {
+	return int(f.buckets * f.bucketLen * f.fpBits)
+}
+
+// returns the fingerprint and the two candidate buckets of v.
+func (f *Cuckoo /*[T]*/) locate(v T) (fp, i1, i2 uint64) {
+	h := f.hash(v)
+	fp = (h >> 32) & f.fpMask
+	if fp == 0 {
+		// 0 is an empty entry
+		fp = 1
+	}
+	i1 = h & (f.buckets - 1)
+	return fp, i1, f.altIndex(i1, fp)
+}
+
+// returns the alternate bucket of fingerprint fp stored in bucket i. As the
+// number of buckets is a power of 2, the alternate bucket of the alternate
+// bucket is i.
+func (f *Cuckoo /*[T]*/) altIndex(i, fp uint64) uint64 {
+	return (i ^ hashes.Mix(fp)) & (f.buckets - 1)
+}
+
+// inserts fp in an empty entry of bucket i, and returns true, or returns
+// false if bucket i is full.
+func (f *Cuckoo /*[T]*/) insert(i, fp uint64) bool {
+	if e := f.find(i, 0); e >= 0 {
+		f.set(i*f.bucketLen+uint64(e), fp)
+		return true
+	}
+	return false
+}
+
+// removes fp from bucket i and returns true, or returns false if bucket i
+// does not contain fp.
+func (f *Cuckoo /*[T]*/) remove(i, fp uint64) bool {
+	if e := f.find(i, fp); e >= 0 {
+		f.set(i*f.bucketLen+uint64(e), 0)
+		return true
+	}
+	return false
+}
+
+// returns the entry of bucket i that contains fp, or -1.
+func (f *Cuckoo /*[T]*/) find(i, fp uint64) int {
+	for e := uint64(0); e < f.bucketLen; e++ {
+		if f.get(i*f.bucketLen+e) == fp {
+			return int(e)
+		}
+	}
+	return -1
+}
+
+// returns the fingerprint stored at the specified slot, a slot being the
+// index of an entry in the whole table.
+func (f *Cuckoo /*[T]*/) get(slot uint64) uint64 {
+	bit := slot * f.fpBits
+	w, off := bit/64, bit%64
+	v := f.words[w] >> off
+	if off+f.fpBits > 64 {
+		v |= f.words[w+1] << (64 - off)
+	}
+	return v & f.fpMask
+}
+
+// stores fp at the specified slot.
+func (f *Cuckoo /*[T]*/) set(slot, fp uint64) {
+	bit := slot * f.fpBits
+	w, off := bit/64, bit%64
+	f.words[w] = f.words[w]&^(f.fpMask<<off) | fp<<off
+	if off+f.fpBits > 64 {
+		shift := 64 - off
+		f.words[w+1] = f.words[w+1]&^(f.fpMask>>shift) | fp>>shift
+	}
+}
+
+// returns a pseudo-random number, used to select the entries to evict.
+func (f *Cuckoo /*[T]*/) random() uint64 {
+	f.rng += 0x9e3779b97f4a7c15
+	return hashes.Mix(f.rng)
+}
+
+// MarshalBinary returns the binary representation of the filter f. The
+// hash function is not part of the binary representation, the same hash
+// function must be used when unmarshaling.
+func (f *Cuckoo /*[T]*/) MarshalBinary() ([]byte, error) {
+	e := binfmt.NewEncoder(binfmt.KindCuckoo)
+	e.Uvarint(f.fpBits)
+	e.Uvarint(f.bucketLen)
+	e.Uvarint(f.buckets)
+	e.Uvarint(uint64(f.n))
+	e.Uint64s(f.words)
+	return e.Data(), nil
+}
+
+// UnmarshalBinary sets the filter f to the binary representation in data,
+// as returned by MarshalBinary. The hash function of f is kept, so f must
+// have been created with the same hash function as the one used to create
+// the marshaled filter (see also UnmarshalCuckoo). It returns ErrInvalidData
+// if data is not a valid representation of a cuckoo filter, in which case f
+// is unchanged.
+func (f *Cuckoo /*[T]*/) UnmarshalBinary(data []byte) error {
+	d := binfmt.NewDecoder(data, binfmt.KindCuckoo)
+	fpBits := d.Uvarint()
+	bucketLen := d.Uvarint()
+	buckets := d.Uvarint()
+	n := d.Int(math.MaxInt32)
+	words := d.Uint64s()
+	if err := d.Close(); err != nil {
+		return err
+	}
+	if fpBits < 4 || fpBits > 16 || bucketLen < 1 || bucketLen > 8 ||
+		buckets == 0 || buckets&(buckets-1) != 0 || buckets > math.MaxInt32 ||
+		uint64(len(words)) != (buckets*bucketLen*fpBits+63)/64 {
+		return ErrInvalidData
+	}
+
+	g := makeCuckoo /*[T]*/ (fpBits, bucketLen, 1, f.hash)
+	g.buckets, g.words = buckets, words
+
+	// the number of values must match the number of non-empty entries
+	var count int
+	for slot := uint64(0); slot < buckets*bucketLen; slot++ {
+		if g.get(slot) != 0 {
+			count++
+		}
+	}
+	if count != n {
+		return ErrInvalidData
+	}
+	g.n = n
+
+	*f = *g
+	return nil
+}
+
+// UnmarshalCuckoo returns the cuckoo filter represented by data, as returned
+// by MarshalBinary, using hash as hash function. It must be the same hash
+// function as the one used to create the marshaled filter. It panics if
+// hash is nil.
+func UnmarshalCuckoo /*[T algo.Any]*/ (data []byte, hash hashes.Func /*[T]*/) (*Cuckoo /*[T]*/, error) {
+	if hash == nil {
+		panic("filters: nil hash function")
+	}
+	f := &Cuckoo /*[T]*/ {hash: hash}
+	if err := f.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
@@ -0,0 +1,94 @@
+package filters
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mna/algo/hashes"
+)
+
+func BenchmarkCuckoo_Add(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			f := MakeCuckoo(n, 16, 4, hashes.Int)
+			b.ResetTimer()
+
+			// once n values are added, a value is deleted before each add so
+			// that the filter never gets full.
+			for i := 0; i < b.N; i++ {
+				v := i % n
+				if i >= n {
+					f.Delete(v)
+				}
+				if err := f.Add(v); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCuckoo_AddFull(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			f := MakeCuckoo(n, 16, 4, hashes.Int)
+			var i int
+			for ; ; i++ {
+				if err := f.Add(i); err != nil {
+					break
+				}
+			}
+			b.ResetTimer()
+
+			// the worst case, each failed insert evicts and reverts up to 500
+			// entries.
+			for j := 0; j < b.N; j++ {
+				_ = f.Add(i + j)
+			}
+		})
+	}
+}
+
+func BenchmarkCuckoo_MayContain(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			f := MakeCuckoo(n, 16, 4, hashes.Int)
+			for i := 0; i < n; i++ {
+				if err := f.Add(i); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(f.BitLen())/float64(n), "bits/value")
+			b.ResetTimer()
+
+			// half the lookups are for values in the filter
+			for i := 0; i < b.N; i++ {
+				_ = f.MayContain(i % (2 * n))
+			}
+		})
+	}
+}
+
+func BenchmarkCuckoo_Delete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			f := MakeCuckoo(n, 16, 4, hashes.Int)
+			for i := 0; i < n; i++ {
+				if err := f.Add(i); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				v := i % n
+				if !f.Delete(v) {
+					b.Fatalf("%d: want deleted", v)
+				}
+				if err := f.Add(v); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
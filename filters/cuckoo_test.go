@@ -0,0 +1,307 @@
+package filters
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mna/algo/hashes"
+)
+
+func TestCuckooPanics(t *testing.T) {
+	cases := []struct {
+		desc string
+		fn   func()
+	}{
+		{"fingerprint too small", func() { MakeCuckoo(1, 3, 4, hashes.Int) }},
+		{"fingerprint too big", func() { MakeCuckoo(1, 17, 4, hashes.Int) }},
+		{"bucket too small", func() { MakeCuckoo(1, 8, 0, hashes.Int) }},
+		{"bucket too big", func() { MakeCuckoo(1, 8, 9, hashes.Int) }},
+		{"nil hash", func() { MakeCuckoo(1, 8, 4, nil) }},
+		{"nil hash unmarshal", func() { _, _ = UnmarshalCuckoo(nil, nil) }},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("want panic")
+				}
+			}()
+			c.fn()
+		})
+	}
+}
+
+func TestCuckoo(t *testing.T) {
+	const n = 10000
+	for _, fpBits := range []int{4, 7, 8, 12, 16} {
+		for _, bucketLen := range []int{1, 2, 4, 8} {
+			if bucketLen == 1 && fpBits < 8 {
+				// too few alternate buckets to reach the load factor
+				continue
+			}
+			t.Run(fmt.Sprintf("fp=%d;bucket=%d", fpBits, bucketLen), func(t *testing.T) {
+				f := MakeCuckoo(n, fpBits, bucketLen, hashes.Int)
+				if f.Cap() < n {
+					t.Fatalf("want capacity of at least %d, got %d", n, f.Cap())
+				}
+
+				// adding n values succeeds with high probability
+				for i := 0; i < n; i++ {
+					if err := f.Add(i); err != nil {
+						t.Fatalf("%d: %v", i, err)
+					}
+				}
+				if f.Len() != n {
+					t.Fatalf("want len %d, got %d", n, f.Len())
+				}
+				for i := 0; i < n; i++ {
+					if !f.MayContain(i) {
+						t.Fatalf("%d: want may contain", i)
+					}
+				}
+
+				// delete the even values
+				for i := 0; i < n; i += 2 {
+					if !f.Delete(i) {
+						t.Fatalf("%d: want deleted", i)
+					}
+				}
+				if f.Len() != n/2 {
+					t.Fatalf("want len %d, got %d", n/2, f.Len())
+				}
+				for i := 1; i < n; i += 2 {
+					if !f.MayContain(i) {
+						t.Fatalf("%d: want may contain", i)
+					}
+				}
+
+				// delete the odd values, the filter is empty
+				for i := 1; i < n; i += 2 {
+					if !f.Delete(i) {
+						t.Fatalf("%d: want deleted", i)
+					}
+				}
+				if f.Len() != 0 {
+					t.Fatalf("want len 0, got %d", f.Len())
+				}
+				for i := 0; i < n; i++ {
+					if f.MayContain(i) || f.Delete(i) {
+						t.Fatalf("%d: want empty filter", i)
+					}
+				}
+				for _, w := range f.words {
+					if w != 0 {
+						t.Fatal("want all entries empty")
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestCuckooFalsePositiveRate(t *testing.T) {
+	const n, tests = 100000, 1000000
+	for _, fpBits := range []int{8, 12, 16} {
+		for _, bucketLen := range []int{2, 4} {
+			t.Run(fmt.Sprintf("fp=%d;bucket=%d", fpBits, bucketLen), func(t *testing.T) {
+				f := MakeCuckoo(n, fpBits, bucketLen, hashes.Int)
+				for i := 0; i < n; i++ {
+					if err := f.Add(i); err != nil {
+						t.Fatalf("%d: %v", i, err)
+					}
+				}
+
+				var fp int
+				for i := n; i < n+tests; i++ {
+					if f.MayContain(i) {
+						fp++
+					}
+				}
+				rate := float64(fp) / tests
+				want := float64(2*bucketLen) / float64(uint(1)<<uint(fpBits))
+				t.Logf("false positive rate: %f, bits per value: %.2f", rate, float64(f.BitLen())/n)
+				if rate > want*1.25+10.0/tests {
+					t.Fatalf("want false positive rate of at most %f, got %f", want*1.25, rate)
+				}
+			})
+		}
+	}
+}
+
+func TestCuckooFull(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	for _, bucketLen := range []int{1, 2, 4, 8} {
+		t.Run(fmt.Sprintf("bucket=%d", bucketLen), func(t *testing.T) {
+			f := MakeCuckoo(1000, 16, bucketLen, hashes.Int)
+
+			var added []T
+			var err error
+			for err == nil {
+				v := r.Int()
+				if err = f.Add(v); err == nil {
+					added = append(added, v)
+				}
+			}
+			if err != ErrFull {
+				t.Fatalf("want ErrFull, got %v", err)
+			}
+			if f.Len() != len(added) {
+				t.Fatalf("want len %d, got %d", len(added), f.Len())
+			}
+			load := float64(len(added)) / float64(f.Cap())
+			t.Logf("load factor: %f", load)
+			// with a single entry per bucket, the load factor reached at the
+			// first failure varies too much to be tested reliably.
+			if want := cuckooMaxLoad[bucketLen] * 0.75; bucketLen > 1 && load < want {
+				t.Fatalf("want load factor of at least %f, got %f", want, load)
+			}
+
+			// a failed insert leaves the filter unchanged (an insert may still
+			// succeed if the value's buckets have room).
+			for i := 0; i < 10; i++ {
+				words := append([]uint64(nil), f.words...)
+				v := r.Int()
+				if err := f.Add(v); err == nil {
+					added = append(added, v)
+				} else if !cmp.Equal(words, f.words) {
+					t.Fatal("want filter unchanged")
+				}
+			}
+			for _, v := range added {
+				if !f.MayContain(v) {
+					t.Fatalf("%d: want may contain", v)
+				}
+			}
+
+			// deleting a value makes room for it in its buckets
+			for _, v := range added[:10] {
+				if !f.Delete(v) {
+					t.Fatalf("%d: want deleted", v)
+				}
+				if err := f.Add(v); err != nil {
+					t.Fatalf("%d: want added, got %v", v, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCuckooDuplicates(t *testing.T) {
+	for _, bucketLen := range []int{1, 2, 4, 8} {
+		t.Run(fmt.Sprintf("bucket=%d", bucketLen), func(t *testing.T) {
+			f := MakeCuckoo(1000, 8, bucketLen, hashes.Int)
+			for i := 0; i < 2*bucketLen; i++ {
+				if err := f.Add(42); err != nil {
+					t.Fatalf("%d: %v", i, err)
+				}
+			}
+			if err := f.Add(42); err != ErrFull {
+				t.Fatalf("want ErrFull, got %v", err)
+			}
+			for i := 0; i < 2*bucketLen; i++ {
+				if !f.MayContain(42) || !f.Delete(42) {
+					t.Fatalf("%d: want deleted", i)
+				}
+			}
+			if f.MayContain(42) || f.Delete(42) || f.Len() != 0 {
+				t.Fatal("want empty filter")
+			}
+		})
+	}
+}
+
+func TestCuckooMarshal(t *testing.T) {
+	for _, fpBits := range []int{4, 7, 16} {
+		t.Run(fmt.Sprintf("fp=%d", fpBits), func(t *testing.T) {
+			f := MakeCuckoo(1000, fpBits, 4, hashes.Int)
+			for i := 0; i < 1000; i++ {
+				if err := f.Add(i); err != nil {
+					t.Fatal(err)
+				}
+			}
+			data, err := f.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := UnmarshalCuckoo(data, hashes.Int)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Len() != f.Len() || got.Cap() != f.Cap() || got.BitLen() != f.BitLen() || !cmp.Equal(f.words, got.words) {
+				t.Fatal("want same filter")
+			}
+			for i := 0; i < 1000; i++ {
+				if !got.Delete(i) {
+					t.Fatalf("%d: want deleted", i)
+				}
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		f := MakeCuckoo(100, 8, 4, hashes.Int)
+		for i := 0; i < 10; i++ {
+			if err := f.Add(i); err != nil {
+				t.Fatal(err)
+			}
+		}
+		data, _ := f.MarshalBinary()
+
+		// number of values does not match the entries
+		f.n++
+		badLen, _ := f.MarshalBinary()
+		f.n--
+		// number of buckets is not a power of 2
+		f.buckets--
+		badBuckets, _ := f.MarshalBinary()
+		f.buckets++
+		// fingerprint size out of range
+		f.fpBits = 17
+		badFP, _ := f.MarshalBinary()
+		f.fpBits = 8
+		// bucket size out of range
+		f.bucketLen = 0
+		badBucketLen, _ := f.MarshalBinary()
+		f.bucketLen = 4
+		// number of words does not match the size
+		f.words = f.words[1:]
+		badWords, _ := f.MarshalBinary()
+
+		// the other filters have a distinct binary format
+		bloom, _ := MakeBloom(100, 0.01, hashes.Int).MarshalBinary()
+
+		cases := []struct {
+			desc string
+			data []byte
+		}{
+			{"empty", nil},
+			{"truncated", data[:len(data)-1]},
+			{"trailing", append(append([]byte(nil), data...), 0)},
+			{"len", badLen},
+			{"buckets", badBuckets},
+			{"fingerprint", badFP},
+			{"bucket size", badBucketLen},
+			{"words", badWords},
+			{"bloom", bloom},
+		}
+		for _, c := range cases {
+			t.Run(c.desc, func(t *testing.T) {
+				g := MakeCuckoo(1, 8, 1, hashes.Int)
+				want := g.Cap()
+				if err := g.UnmarshalBinary(c.data); err != ErrInvalidData {
+					t.Fatalf("want ErrInvalidData, got %v", err)
+				}
+				if g.Cap() != want {
+					t.Fatal("want filter unchanged")
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package filters
+
+import (
+	"errors"
+
+	"github.com/mna/algo/internal/binfmt"
+)
+
+type T = int // NOTE: generic type placeholder
+
+// ErrIncompatible is the error returned when combining filters that do not
+// have the same parameters (e.g. size and number of hash functions).
+var ErrIncompatible = errors.New("filters: incompatible filters")
+
+// ErrInvalidData is the error returned when unmarshaling data that is not a
+// valid binary representation of the filter.
+var ErrInvalidData = binfmt.ErrInvalid
+
+// ErrBuild is the error returned when a static filter cannot be built from
+// its values.
+var ErrBuild = errors.New("filters: failed to build filter")
+
+// ErrFull is the error returned when a value cannot be added to a filter
+// because it has no room left for it.
+var ErrFull = errors.New("filters: filter is full")
+
+// maxInt is the largest value of an int.
+const maxInt = int(^uint(0) >> 1)
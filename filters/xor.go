@@ -0,0 +1,368 @@
+package filters
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/mna/algo/hashes"
+	"github.com/mna/algo/internal/binfmt"
+)
+
+// Xor8 is a static xor filter with 8-bit fingerprints, a probabilistic set
+// that can tell if a value is definitely not in the set or if it may be in
+// the set. Unlike a Bloom filter, it is built once from a fixed set of
+// values and no value can be added afterwards, but it uses less memory for
+// the same false positive rate: it uses about 9.84 bits per value for a
+// false positive rate of about 0.39% (1/256), and a query makes exactly
+// three memory lookups.
+//
+// See "Xor Filters: Faster and Smaller Than Bloom and Cuckoo Filters", by
+// Thomas Mueller Graf and Daniel Lemire.
+type Xor8 /*[T algo.Any]*/ struct {
+	seed         uint64
+	blockLen     uint32
+	n            int
+	fingerprints []uint8
+	hash         hashes.Func /*[T]*/
+}
+
+// Xor16 is like Xor8 but with 16-bit fingerprints, it uses about 19.7 bits
+// per value for a false positive rate of about 0.0015% (1/65536).
+type Xor16 /*[T algo.Any]*/ struct {
+	seed         uint64
+	blockLen     uint32
+	n            int
+	fingerprints []uint16
+	hash         hashes.Func /*[T]*/
+}
+
+// MakeXor8 returns an Xor8 filter that contains the values vs, using the
+// hash function to hash the values. Duplicate values are ignored. It panics
+// if hash is nil.
+//
+// The construction may fail for a given seed (used to derive the positions
+// of a value from its hash), in which case it is retried with a new seed,
+// which succeeds with high probability. If it fails after 100 attempts, it
+// returns ErrBuild, which can only realistically happen if the hash function
+// is of very poor quality.
+//
+// It runs in O(n) expected time complexity where n is the number of values
+// (O(n log n) to remove the duplicate hashes).
+func MakeXor8 /*[T algo.Any]*/ (vs []T, hash hashes.Func /*[T]*/) (*Xor8 /*[T]*/, error) {
+	if hash == nil {
+		panic("filters: nil hash function")
+	}
+	b, err := buildXor(hashValues(vs, hash))
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Xor8 /*[T]*/ {
+		seed:         b.seed,
+		blockLen:     b.blockLen,
+		n:            len(b.stack),
+		fingerprints: make([]uint8, 3*b.blockLen),
+		hash:         hash,
+	}
+	for i := len(b.stack) - 1; i >= 0; i-- {
+		ki := b.stack[i]
+		h0, h1, h2 := xorPositions(ki.hash, b.blockLen)
+		f.fingerprints[ki.index] = uint8(xorFingerprint(ki.hash)) ^
+			f.fingerprints[h0] ^ f.fingerprints[h1] ^ f.fingerprints[h2]
+	}
+	return f, nil
+}
+
+// MakeXor16 is like MakeXor8 but returns an Xor16 filter.
+func MakeXor16 /*[T algo.Any]*/ (vs []T, hash hashes.Func /*[T]*/) (*Xor16 /*[T]*/, error) {
+	if hash == nil {
+		panic("filters: nil hash function")
+	}
+	b, err := buildXor(hashValues(vs, hash))
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Xor16 /*[T]*/ {
+		seed:         b.seed,
+		blockLen:     b.blockLen,
+		n:            len(b.stack),
+		fingerprints: make([]uint16, 3*b.blockLen),
+		hash:         hash,
+	}
+	for i := len(b.stack) - 1; i >= 0; i-- {
+		ki := b.stack[i]
+		h0, h1, h2 := xorPositions(ki.hash, b.blockLen)
+		f.fingerprints[ki.index] = uint16(xorFingerprint(ki.hash)) ^
+			f.fingerprints[h0] ^ f.fingerprints[h1] ^ f.fingerprints[h2]
+	}
+	return f, nil
+}
+
+// MayContain returns false if v is definitely not in the filter f, and true
+// if it may be in f, that is either it was used to build f or it is a false
+// positive.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (f *Xor8 /*[T]*/) MayContain(v T) bool {
+	h := hashes.MixSeed(f.hash(v), f.seed)
+	h0, h1, h2 := xorPositions(h, f.blockLen)
+	return uint8(xorFingerprint(h)) == f.fingerprints[h0]^f.fingerprints[h1]^f.fingerprints[h2]
+}
+
+// MayContain returns false if v is definitely not in the filter f, and true
+// if it may be in f, that is either it was used to build f or it is a false
+// positive.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (f *Xor16 /*[T]*/) MayContain(v T) bool {
+	h := hashes.MixSeed(f.hash(v), f.seed)
+	h0, h1, h2 := xorPositions(h, f.blockLen)
+	return uint16(xorFingerprint(h)) == f.fingerprints[h0]^f.fingerprints[h1]^f.fingerprints[h2]
+}
+
+// Len returns the number of distinct values used to build the filter f.
+func (f *Xor8 /*[T]*/) Len() int {
+	return f.n
+}
+
+// Len returns the number of distinct values used to build the filter f.
+func (f *Xor16 /*[T]*/) Len() int {
+	return f.n
+}
+
+// BitLen returns the number of bits used by the fingerprints of the filter
+// f.
+func (f *Xor8 /*[T]*/) BitLen() int {
+	return 8 * len(f.fingerprints)
+}
+
+// BitLen returns the number of bits used by the fingerprints of the filter
+// f.
+func (f *Xor16 /*[T]*/) BitLen() int {
+	return 16 * len(f.fingerprints)
+}
+
+// MarshalBinary returns the binary representation of the filter f. The
+// hash function is not part of the binary representation, the same hash
+// function must be used when unmarshaling.
+func (f *Xor8 /*[T]*/) MarshalBinary() ([]byte, error) {
+	e := binfmt.NewEncoder(binfmt.KindXor8)
+	e.Uint64(f.seed)
+	e.Uvarint(uint64(f.n))
+	e.Bytes(f.fingerprints)
+	return e.Data(), nil
+}
+
+// MarshalBinary returns the binary representation of the filter f. The
+// hash function is not part of the binary representation, the same hash
+// function must be used when unmarshaling.
+func (f *Xor16 /*[T]*/) MarshalBinary() ([]byte, error) {
+	e := binfmt.NewEncoder(binfmt.KindXor16)
+	e.Uint64(f.seed)
+	e.Uvarint(uint64(f.n))
+	e.Uint16s(f.fingerprints)
+	return e.Data(), nil
+}
+
+// UnmarshalBinary sets the filter f to the binary representation in data,
+// as returned by MarshalBinary. The hash function of f is kept, so f must
+// have been created with the same hash function as the one used to create
+// the marshaled filter (see also UnmarshalXor8). It returns ErrInvalidData
+// if data is not a valid representation of an Xor8 filter, in which case f
+// is unchanged.
+func (f *Xor8 /*[T]*/) UnmarshalBinary(data []byte) error {
+	d := binfmt.NewDecoder(data, binfmt.KindXor8)
+	seed := d.Uint64()
+	n := d.Int(math.MaxInt32)
+	fps := d.Bytes()
+	if err := d.Close(); err != nil {
+		return err
+	}
+	if uint64(len(fps)) != 3*uint64(xorBlockLen(n)) {
+		return ErrInvalidData
+	}
+
+	f.seed, f.blockLen, f.n, f.fingerprints = seed, xorBlockLen(n), n, fps
+	return nil
+}
+
+// UnmarshalBinary sets the filter f to the binary representation in data,
+// as returned by MarshalBinary. The hash function of f is kept, so f must
+// have been created with the same hash function as the one used to create
+// the marshaled filter (see also UnmarshalXor16). It returns ErrInvalidData
+// if data is not a valid representation of an Xor16 filter, in which case f
+// is unchanged.
+func (f *Xor16 /*[T]*/) UnmarshalBinary(data []byte) error {
+	d := binfmt.NewDecoder(data, binfmt.KindXor16)
+	seed := d.Uint64()
+	n := d.Int(math.MaxInt32)
+	fps := d.Uint16s()
+	if err := d.Close(); err != nil {
+		return err
+	}
+	if uint64(len(fps)) != 3*uint64(xorBlockLen(n)) {
+		return ErrInvalidData
+	}
+
+	f.seed, f.blockLen, f.n, f.fingerprints = seed, xorBlockLen(n), n, fps
+	return nil
+}
+
+// UnmarshalXor8 returns the Xor8 filter represented by data, as returned by
+// MarshalBinary, using hash as hash function. It must be the same hash
+// function as the one used to create the marshaled filter. It panics if
+// hash is nil.
+func UnmarshalXor8 /*[T algo.Any]*/ (data []byte, hash hashes.Func /*[T]*/) (*Xor8 /*[T]*/, error) {
+	if hash == nil {
+		panic("filters: nil hash function")
+	}
+	f := &Xor8 /*[T]*/ {hash: hash}
+	if err := f.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// UnmarshalXor16 returns the Xor16 filter represented by data, as returned
+// by MarshalBinary, using hash as hash function. It must be the same hash
+// function as the one used to create the marshaled filter. It panics if
+// hash is nil.
+func UnmarshalXor16 /*[T algo.Any]*/ (data []byte, hash hashes.Func /*[T]*/) (*Xor16 /*[T]*/, error) {
+	if hash == nil {
+		panic("filters: nil hash function")
+	}
+	f := &Xor16 /*[T]*/ {hash: hash}
+	if err := f.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// maximum number of seeds to try when building an xor filter.
+const maxXorAttempts = 100
+
+// result of the construction of an xor filter, which is independent of the
+// size of the fingerprints.
+type xorBuild struct {
+	seed     uint64
+	blockLen uint32
+	// order in which the fingerprints must be assigned, in reverse.
+	stack []xorKeyIndex
+}
+
+type xorKeyIndex struct {
+	hash  uint64 // hash of the value, mixed with the seed
+	index uint32 // index of the fingerprint assigned to that value
+}
+
+// returns the sorted, distinct hashes of the values.
+func hashValues /*[T algo.Any]*/ (vs []T, hash hashes.Func /*[T]*/) []uint64 {
+	hs := make([]uint64, len(vs))
+	for i, v := range vs {
+		hs[i] = hash(v)
+	}
+	sort.Slice(hs, func(i, j int) bool { return hs[i] < hs[j] })
+
+	var n int
+	for i, h := range hs {
+		if i == 0 || h != hs[n-1] {
+			hs[n] = h
+			n++
+		}
+	}
+	return hs[:n]
+}
+
+// returns the length of each of the 3 blocks of fingerprints for n values,
+// for a total of about 1.23*n fingerprints.
+func xorBlockLen(n int) uint32 {
+	return uint32((32 + uint64(math.Ceil(1.23*float64(n)))) / 3)
+}
+
+// builds the xor filter of the distinct hashes hs by finding an order in
+// which each value can be assigned a fingerprint that is not used by any
+// value that comes after it (a process called peeling of the hypergraph
+// where each value is an edge between its 3 positions). If peeling fails
+// for a seed, it retries with a new seed.
+func buildXor(hs []uint64) (xorBuild, error) {
+	blockLen := xorBlockLen(len(hs))
+	capacity := 3 * blockLen
+
+	// xor of the hashes of the values at each position and number of values
+	// at each position.
+	masks := make([]uint64, capacity)
+	counts := make([]uint32, capacity)
+	queue := make([]uint32, 0, capacity)
+	stack := make([]xorKeyIndex, 0, len(hs))
+
+	for attempt := uint64(0); attempt < maxXorAttempts; attempt++ {
+		seed := hashes.Mix(attempt)
+		for i := range masks {
+			masks[i], counts[i] = 0, 0
+		}
+		queue, stack = queue[:0], stack[:0]
+
+		for _, h := range hs {
+			h = hashes.MixSeed(h, seed)
+			h0, h1, h2 := xorPositions(h, blockLen)
+			masks[h0] ^= h
+			masks[h1] ^= h
+			masks[h2] ^= h
+			counts[h0]++
+			counts[h1]++
+			counts[h2]++
+		}
+
+		// positions with a single value can be assigned to that value, which
+		// is then removed from its other positions.
+		for i, c := range counts {
+			if c == 1 {
+				queue = append(queue, uint32(i))
+			}
+		}
+		for len(queue) > 0 {
+			i := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			if counts[i] != 1 {
+				continue
+			}
+
+			h := masks[i]
+			stack = append(stack, xorKeyIndex{hash: h, index: i})
+			h0, h1, h2 := xorPositions(h, blockLen)
+			for _, j := range [3]uint32{h0, h1, h2} {
+				masks[j] ^= h
+				counts[j]--
+				if counts[j] == 1 {
+					queue = append(queue, j)
+				}
+			}
+		}
+
+		if len(stack) == len(hs) {
+			return xorBuild{seed: seed, blockLen: blockLen, stack: stack}, nil
+		}
+	}
+	return xorBuild{}, ErrBuild
+}
+
+// returns the 3 positions of the value with hash h, one in each block.
+func xorPositions(h uint64, blockLen uint32) (uint32, uint32, uint32) {
+	h0 := reduce(uint32(h), blockLen)
+	h1 := reduce(uint32(bits.RotateLeft64(h, 21)), blockLen) + blockLen
+	h2 := reduce(uint32(bits.RotateLeft64(h, 42)), blockLen) + 2*blockLen
+	return h0, h1, h2
+}
+
+// returns the fingerprint of the value with hash h, to be truncated to the
+// size of the fingerprints.
+func xorFingerprint(h uint64) uint64 {
+	return h ^ (h >> 32)
+}
+
+// maps x to [0, n) fairly, without the cost of a modulo operation.
+func reduce(x, n uint32) uint32 {
+	return uint32((uint64(x) * uint64(n)) >> 32)
+}
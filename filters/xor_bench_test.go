@@ -0,0 +1,106 @@
+package filters
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mna/algo/hashes"
+	"github.com/mna/algo/sets"
+)
+
+func sequentialValues(n int) []T {
+	vs := make([]T, n)
+	for i := range vs {
+		vs[i] = i
+	}
+	return vs
+}
+
+func BenchmarkXor8_Make(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vs := sequentialValues(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := MakeXor8(vs, hashes.Int); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkXor16_Make(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vs := sequentialValues(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := MakeXor16(vs, hashes.Int); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkMayContain compares the query time and the space used by the
+// static filters with the Bloom filter (with a similar false positive rate
+// as Xor8) and the sets.Set membership test. Half the lookups are for values
+// in the filter.
+func BenchmarkMayContain(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		vs := sequentialValues(n)
+
+		b.Run(fmt.Sprintf("Xor8;n=%d", n), func(b *testing.B) {
+			f, err := MakeXor8(vs, hashes.Int)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportMetric(float64(f.BitLen())/float64(n), "bits/value")
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = f.MayContain(i % (2 * n))
+			}
+		})
+
+		b.Run(fmt.Sprintf("Xor16;n=%d", n), func(b *testing.B) {
+			f, err := MakeXor16(vs, hashes.Int)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportMetric(float64(f.BitLen())/float64(n), "bits/value")
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = f.MayContain(i % (2 * n))
+			}
+		})
+
+		b.Run(fmt.Sprintf("Bloom;n=%d", n), func(b *testing.B) {
+			f := MakeBloom(n, 1.0/256, hashes.Int)
+			f.Add(vs...)
+			b.ReportMetric(float64(f.BitLen())/float64(n), "bits/value")
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = f.MayContain(i % (2 * n))
+			}
+		})
+
+		b.Run(fmt.Sprintf("Set;n=%d", n), func(b *testing.B) {
+			s := sets.MakeCap(n)
+			s.Add(vs...)
+			// the values only, not accounting for the map's overhead
+			b.ReportMetric(64, "bits/value")
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = s.Contains(i % (2 * n))
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package filters
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mna/algo/hashes"
+)
+
+// xorFilter is the common interface of Xor8 and Xor16, for tests.
+type xorFilter interface {
+	MayContain(v T) bool
+	Len() int
+	BitLen() int
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary([]byte) error
+}
+
+var xorFilters = []struct {
+	desc   string
+	fpBits int
+	fpRate float64
+	make   func([]T, hashes.Func) (xorFilter, error)
+	zero   func() xorFilter
+}{
+	{
+		"Xor8", 8, 1.0 / 256,
+		func(vs []T, hash hashes.Func) (xorFilter, error) { return MakeXor8(vs, hash) },
+		func() xorFilter { return &Xor8{hash: hashes.Int} },
+	},
+	{
+		"Xor16", 16, 1.0 / 65536,
+		func(vs []T, hash hashes.Func) (xorFilter, error) { return MakeXor16(vs, hash) },
+		func() xorFilter { return &Xor16{hash: hashes.Int} },
+	},
+}
+
+func TestXor(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	for _, xf := range xorFilters {
+		t.Run(xf.desc, func(t *testing.T) {
+			for _, n := range []int{0, 1, 2, 10, 100, 1000, 100000} {
+				t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+					vs := r.Perm(n)
+					f, err := xf.make(vs, hashes.Int)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if f.Len() != n {
+						t.Fatalf("want len %d, got %d", n, f.Len())
+					}
+					for _, v := range vs {
+						if !f.MayContain(v) {
+							t.Fatalf("%d: want may contain", v)
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestXorDuplicates(t *testing.T) {
+	for _, xf := range xorFilters {
+		t.Run(xf.desc, func(t *testing.T) {
+			vs := []T{1, 2, 3, 2, 1, 1, 4}
+			f, err := xf.make(vs, hashes.Int)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f.Len() != 4 {
+				t.Fatalf("want len 4, got %d", f.Len())
+			}
+			for _, v := range vs {
+				if !f.MayContain(v) {
+					t.Fatalf("%d: want may contain", v)
+				}
+			}
+		})
+	}
+}
+
+func TestXorFalsePositiveRate(t *testing.T) {
+	const n, tests = 100000, 1000000
+	vs := make([]T, n)
+	for i := range vs {
+		vs[i] = i
+	}
+
+	for _, xf := range xorFilters {
+		t.Run(xf.desc, func(t *testing.T) {
+			f, err := xf.make(vs, hashes.Int)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var fp int
+			for i := n; i < n+tests; i++ {
+				if f.MayContain(i) {
+					fp++
+				}
+			}
+			rate := float64(fp) / tests
+			bitsPerValue := float64(f.BitLen()) / n
+			t.Logf("false positive rate: %f, bits per value: %.2f", rate, bitsPerValue)
+			if rate > xf.fpRate*1.25+10.0/tests {
+				t.Fatalf("want false positive rate of at most %f, got %f", xf.fpRate*1.25, rate)
+			}
+			// there are about 1.23 fingerprints per value
+			if want := 1.231 * float64(xf.fpBits); bitsPerValue > want {
+				t.Fatalf("want at most %.2f bits per value, got %.2f", want, bitsPerValue)
+			}
+		})
+	}
+}
+
+func TestXorBuildFailure(t *testing.T) {
+	// duplicate hashes have the same positions for all seeds, so peeling
+	// always fails (MakeXor8 and MakeXor16 remove duplicates).
+	if _, err := buildXor([]uint64{1, 1}); err != ErrBuild {
+		t.Fatalf("want ErrBuild for duplicate hashes, got %v", err)
+	}
+}
+
+func TestXorMarshal(t *testing.T) {
+	for _, xf := range xorFilters {
+		t.Run(xf.desc, func(t *testing.T) {
+			for _, n := range []int{0, 1, 1000} {
+				vs := rand.Perm(n)
+				f, err := xf.make(vs, hashes.Int)
+				if err != nil {
+					t.Fatal(err)
+				}
+				data, err := f.MarshalBinary()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got := xf.zero()
+				if err := got.UnmarshalBinary(data); err != nil {
+					t.Fatal(err)
+				}
+				if !cmp.Equal(f, got, cmp.AllowUnexported(Xor8{}, Xor16{}), cmp.Comparer(func(a, b hashes.Func) bool {
+					return (a == nil) == (b == nil)
+				})) {
+					t.Fatal("want same filter")
+				}
+				for _, v := range vs {
+					if !got.MayContain(v) {
+						t.Fatalf("%d: want may contain", v)
+					}
+				}
+
+				// missing, truncated and trailing data
+				invalid := [][]byte{
+					nil,
+					data[:len(data)-1],
+					append(append([]byte(nil), data...), 0),
+				}
+				g := xf.zero()
+				for _, b := range invalid {
+					if err := g.UnmarshalBinary(b); err != ErrInvalidData {
+						t.Fatalf("want ErrInvalidData, got %v", err)
+					}
+				}
+				if g.Len() != 0 || g.BitLen() != 0 {
+					t.Fatal("want filter unchanged")
+				}
+			}
+
+			// the binary format of each filter type is distinct
+			other := xorFilters[0]
+			if other.desc == xf.desc {
+				other = xorFilters[1]
+			}
+			f, _ := other.make([]T{1, 2, 3}, hashes.Int)
+			data, _ := f.MarshalBinary()
+			if err := xf.zero().UnmarshalBinary(data); err != ErrInvalidData {
+				t.Fatalf("want ErrInvalidData, got %v", err)
+			}
+		})
+	}
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		f8, _ := MakeXor8([]T{1, 2, 3}, hashes.Int)
+		data, _ := f8.MarshalBinary()
+		got8, err := UnmarshalXor8(data, hashes.Int)
+		if err != nil || !got8.MayContain(2) {
+			t.Fatalf("want unmarshaled filter, got %v", err)
+		}
+		if _, err := UnmarshalXor16(data, hashes.Int); err != ErrInvalidData {
+			t.Fatalf("want ErrInvalidData, got %v", err)
+		}
+
+		f16, _ := MakeXor16([]T{1, 2, 3}, hashes.Int)
+		data, _ = f16.MarshalBinary()
+		got16, err := UnmarshalXor16(data, hashes.Int)
+		if err != nil || !got16.MayContain(2) {
+			t.Fatalf("want unmarshaled filter, got %v", err)
+		}
+		if _, err := UnmarshalXor8(data, hashes.Int); err != ErrInvalidData {
+			t.Fatalf("want ErrInvalidData, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package hashes
+
+import "math"
+
+type T = int // NOTE: generic type placeholder
+
+// Func is a hash function that returns a 64-bit hash of a value. It must
+// always return the same hash for the same value. The probabilistic data
+// structures (filters and sketches) derive all the hashes they need from
+// that single 64-bit hash (e.g. by mixing it with a seed), so it should be
+// well distributed over all 64 bits. The functions of this package can be
+// used for the common types, and Mix can be used to improve the distribution
+// of an existing hash function.
+type Func /*[T algo.Any]*/ func(T) uint64
+
+// Mix returns a well-distributed 64-bit hash of h, using the finalizer of
+// the splitmix64 generator. It is a bijection, so distinct values of h
+// always result in distinct hashes.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func Mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+// MixSeed returns a hash of h derived with the provided seed, so that
+// different seeds result in independent hashes of the same h.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func MixSeed(h, seed uint64) uint64 {
+	return Mix(h + (seed+1)*0x9e3779b97f4a7c15)
+}
+
+// Int returns a hash of the int value v.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func Int(v int) uint64 {
+	return Mix(uint64(v))
+}
+
+// Uint64 returns a hash of the uint64 value v.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func Uint64(v uint64) uint64 {
+	return Mix(v)
+}
+
+// Float64 returns a hash of the float64 value v. The positive and negative
+// zero values have the same hash, as they are equal.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func Float64(v float64) uint64 {
+	if v == 0 {
+		v = 0
+	}
+	return Mix(math.Float64bits(v))
+}
+
+// String returns a hash of the string s, using the 64-bit FNV-1a hash
+// function followed by Mix.
+//
+// It runs in O(n) time complexity where n is the length of s. It does not
+// allocate.
+func String(s string) uint64 {
+	h := uint64(fnvOffset)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime
+	}
+	return Mix(h)
+}
+
+// Bytes returns a hash of the byte slice b. It returns the same hash as
+// String for the same sequence of bytes.
+//
+// It runs in O(n) time complexity where n is the length of b. It does not
+// allocate.
+func Bytes(b []byte) uint64 {
+	h := uint64(fnvOffset)
+	for _, c := range b {
+		h ^= uint64(c)
+		h *= fnvPrime
+	}
+	return Mix(h)
+}
+
+const (
+	fnvOffset = 14695981039346656037
+	fnvPrime  = 1099511628211
+)
@@ -0,0 +1,43 @@
+package hashes
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var benchHash uint64
+
+func BenchmarkInt(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchHash = Int(i)
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := strings.Repeat("a", n)
+			b.SetBytes(int64(n))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				benchHash = String(s)
+			}
+		})
+	}
+}
+
+func BenchmarkBytes(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := make([]byte, n)
+			b.SetBytes(int64(n))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				benchHash = Bytes(s)
+			}
+		})
+	}
+}
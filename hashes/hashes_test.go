@@ -0,0 +1,89 @@
+package hashes
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+)
+
+func TestMix(t *testing.T) {
+	// Mix is a bijection, so distinct inputs give distinct hashes
+	seen := make(map[uint64]bool)
+	for i := uint64(0); i < 100000; i++ {
+		h := Mix(i)
+		if seen[h] {
+			t.Fatalf("%d: duplicate hash %x", i, h)
+		}
+		seen[h] = true
+	}
+}
+
+func TestAvalanche(t *testing.T) {
+	// flipping a single bit of the input should flip about half the bits of
+	// the output. Int only uses the low bits.UintSize bits of its input.
+	fns := []struct {
+		desc  string
+		fn    func(uint64) uint64
+		nbits int
+	}{
+		{"Mix", Mix, 64},
+		{"MixSeed", func(h uint64) uint64 { return MixSeed(h, 42) }, 64},
+		{"Int", func(h uint64) uint64 { return Int(int(h)) }, bits.UintSize},
+	}
+	for _, f := range fns {
+		t.Run(f.desc, func(t *testing.T) {
+			var total, count int
+			for i := uint64(0); i < 1000; i++ {
+				v := i * 0x9e3779b97f4a7c15
+				h := f.fn(v)
+				for b := 0; b < f.nbits; b++ {
+					total += bits.OnesCount64(h ^ f.fn(v^(1<<uint(b))))
+					count++
+				}
+			}
+			if avg := float64(total) / float64(count); avg < 30 || avg > 34 {
+				t.Fatalf("want average of ~32 flipped bits, got %.2f", avg)
+			}
+		})
+	}
+}
+
+func TestMixSeed(t *testing.T) {
+	for _, h := range []uint64{0, 1, 42, math.MaxUint64} {
+		seen := make(map[uint64]bool)
+		for seed := uint64(0); seed < 1000; seed++ {
+			v := MixSeed(h, seed)
+			if seen[v] {
+				t.Fatalf("%d: duplicate hash for seed %d", h, seed)
+			}
+			seen[v] = true
+			if v != MixSeed(h, seed) {
+				t.Fatalf("%d: not deterministic for seed %d", h, seed)
+			}
+		}
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	if Float64(0) != Float64(math.Copysign(0, -1)) {
+		t.Fatal("want same hash for positive and negative zero")
+	}
+	if Float64(1) == Float64(-1) {
+		t.Fatal("want different hashes for 1 and -1")
+	}
+}
+
+func TestStringBytes(t *testing.T) {
+	cases := []string{"", "a", "b", "ab", "ba", "hello, world", "\x00", "\x00\x00"}
+	seen := make(map[uint64]string)
+	for _, c := range cases {
+		h := String(c)
+		if h != Bytes([]byte(c)) {
+			t.Errorf("%q: want same hash for String and Bytes", c)
+		}
+		if s, ok := seen[h]; ok {
+			t.Errorf("%q: same hash as %q", c, s)
+		}
+		seen[h] = c
+	}
+}
@@ -0,0 +1,265 @@
+package binfmt
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Kind identifies the type of data structure that is encoded, so that the
+// data of one structure cannot be decoded as another one.
+type Kind byte
+
+// List of kinds of encoded data structures.
+const (
+	KindBloom Kind = iota + 1
+	KindXor8
+	KindXor16
+	KindCuckoo
+	KindHyperLogLog
+	KindCountMin
+	KindTopK
+	KindTDigest
+)
+
+// Version is the current version of the binary format. It is encoded in the
+// header so that the format can evolve while still being able to decode the
+// data encoded by previous versions.
+const Version = 1
+
+// ErrInvalid is the error returned when decoding data that is not a valid
+// encoding of the expected data structure.
+var ErrInvalid = errors.New("invalid binary data")
+
+// the header is made of the magic bytes, the version and the kind.
+var magic = [...]byte{'a', 'l', 'g', 'o'}
+
+// Encoder encodes the binary representation of a data structure. All
+// integers are encoded in little-endian order, variable-length integers are
+// encoded as in the encoding/binary package.
+type Encoder struct {
+	buf []byte
+}
+
+// NewEncoder returns an encoder that starts with the header of the
+// specified kind of data structure.
+func NewEncoder(kind Kind) *Encoder {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, magic[:]...)
+	buf = append(buf, Version, byte(kind))
+	return &Encoder{buf: buf}
+}
+
+// Data returns the encoded data.
+func (e *Encoder) Data() []byte {
+	return e.buf
+}
+
+// Uvarint encodes v as a variable-length unsigned integer.
+func (e *Encoder) Uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	e.buf = append(e.buf, b[:n]...)
+}
+
+// Varint encodes v as a variable-length signed integer.
+func (e *Encoder) Varint(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(b[:], v)
+	e.buf = append(e.buf, b[:n]...)
+}
+
+// Uint64 encodes v as a fixed-size 8 bytes unsigned integer.
+func (e *Encoder) Uint64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	e.buf = append(e.buf, b[:]...)
+}
+
+// Float64 encodes v as its fixed-size 8 bytes IEEE 754 representation.
+func (e *Encoder) Float64(v float64) {
+	e.Uint64(math.Float64bits(v))
+}
+
+// Bytes encodes b prefixed with its length.
+func (e *Encoder) Bytes(b []byte) {
+	e.Uvarint(uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+// Uint16s encodes vs prefixed with its length, each value encoded as a
+// fixed-size 2 bytes unsigned integer.
+func (e *Encoder) Uint16s(vs []uint16) {
+	e.Uvarint(uint64(len(vs)))
+	for _, v := range vs {
+		e.buf = append(e.buf, byte(v), byte(v>>8))
+	}
+}
+
+// Uint64s encodes vs prefixed with its length, each value encoded as a
+// fixed-size 8 bytes unsigned integer.
+func (e *Encoder) Uint64s(vs []uint64) {
+	e.Uvarint(uint64(len(vs)))
+	for _, v := range vs {
+		e.Uint64(v)
+	}
+}
+
+// Decoder decodes the binary representation of a data structure encoded by
+// an Encoder. Once an error is encountered, all subsequent calls return the
+// zero value and the error is reported by Err and Close, so that the caller
+// can check for errors only once all values are decoded.
+type Decoder struct {
+	buf []byte
+	err error
+}
+
+// NewDecoder returns a decoder for data, after checking that its header is
+// valid for the specified kind of data structure.
+func NewDecoder(data []byte, kind Kind) *Decoder {
+	d := &Decoder{buf: data}
+	if len(data) < len(magic)+2 || string(data[:len(magic)]) != string(magic[:]) {
+		d.err = ErrInvalid
+		return d
+	}
+	if v, k := data[len(magic)], Kind(data[len(magic)+1]); v == 0 || v > Version || k != kind {
+		d.err = ErrInvalid
+		return d
+	}
+	d.buf = data[len(magic)+2:]
+	return d
+}
+
+// Err returns the first error encountered while decoding, if any.
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+// Close returns the first error encountered while decoding, if any, or
+// ErrInvalid if not all data was decoded.
+func (d *Decoder) Close() error {
+	if d.err == nil && len(d.buf) > 0 {
+		d.err = ErrInvalid
+	}
+	return d.err
+}
+
+// Fail sets the error of the decoder to ErrInvalid if it does not already
+// have an error. It is used when a decoded value is invalid for the data
+// structure (e.g. an out-of-range value).
+func (d *Decoder) Fail() {
+	if d.err == nil {
+		d.err = ErrInvalid
+	}
+}
+
+// Uvarint decodes a variable-length unsigned integer.
+func (d *Decoder) Uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.Fail()
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+// Varint decodes a variable-length signed integer.
+func (d *Decoder) Varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.Fail()
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+// Int decodes a variable-length unsigned integer that must be no greater
+// than limit.
+func (d *Decoder) Int(limit int) int {
+	v := d.Uvarint()
+	if v > uint64(limit) {
+		d.Fail()
+		return 0
+	}
+	return int(v)
+}
+
+// Uint64 decodes a fixed-size 8 bytes unsigned integer.
+func (d *Decoder) Uint64() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.buf) < 8 {
+		d.Fail()
+		return 0
+	}
+	v := binary.LittleEndian.Uint64(d.buf)
+	d.buf = d.buf[8:]
+	return v
+}
+
+// Float64 decodes a fixed-size 8 bytes IEEE 754 floating-point value.
+func (d *Decoder) Float64() float64 {
+	return math.Float64frombits(d.Uint64())
+}
+
+// Bytes decodes a slice of bytes prefixed with its length. The returned
+// slice is a copy, it does not share memory with the decoded data.
+func (d *Decoder) Bytes() []byte {
+	n := d.length(1)
+	if d.err != nil {
+		return nil
+	}
+	b := make([]byte, n)
+	copy(b, d.buf)
+	d.buf = d.buf[n:]
+	return b
+}
+
+// Uint16s decodes a slice of fixed-size 2 bytes unsigned integers prefixed
+// with its length.
+func (d *Decoder) Uint16s() []uint16 {
+	n := d.length(2)
+	if d.err != nil {
+		return nil
+	}
+	vs := make([]uint16, n)
+	for i := range vs {
+		vs[i] = binary.LittleEndian.Uint16(d.buf[2*i:])
+	}
+	d.buf = d.buf[2*n:]
+	return vs
+}
+
+// Uint64s decodes a slice of fixed-size 8 bytes unsigned integers prefixed
+// with its length.
+func (d *Decoder) Uint64s() []uint64 {
+	n := d.length(8)
+	if d.err != nil {
+		return nil
+	}
+	vs := make([]uint64, n)
+	for i := range vs {
+		vs[i] = d.Uint64()
+	}
+	return vs
+}
+
+// decodes the length of a slice of values of size bytes each, and checks
+// that there is enough data left for that many values.
+func (d *Decoder) length(size int) int {
+	v := d.Uvarint()
+	if v > uint64(len(d.buf)/size) {
+		d.Fail()
+		return 0
+	}
+	return int(v)
+}
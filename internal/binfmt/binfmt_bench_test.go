@@ -0,0 +1,43 @@
+package binfmt
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkEncoder_Uint64s(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vs := make([]uint64, n)
+			b.SetBytes(int64(8 * n))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				e := NewEncoder(KindBloom)
+				e.Uint64s(vs)
+			}
+		})
+	}
+}
+
+func BenchmarkDecoder_Uint64s(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			e := NewEncoder(KindBloom)
+			e.Uint64s(make([]uint64, n))
+			data := e.Data()
+			b.SetBytes(int64(8 * n))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				d := NewDecoder(data, KindBloom)
+				if vs := d.Uint64s(); len(vs) != n {
+					b.Fatalf("want len %d, got %d", n, len(vs))
+				}
+				if err := d.Close(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package binfmt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestRoundTrip(t *testing.T) {
+	e := NewEncoder(KindBloom)
+	e.Uvarint(0)
+	e.Uvarint(math.MaxUint64)
+	e.Varint(-42)
+	e.Varint(math.MinInt64)
+	e.Uint64(0xdeadbeef)
+	e.Float64(math.Pi)
+	e.Bytes(nil)
+	e.Bytes([]byte("hello"))
+	e.Uint16s([]uint16{1, 2, math.MaxUint16})
+	e.Uint64s([]uint64{1, 2, math.MaxUint64})
+	e.Uvarint(10)
+
+	d := NewDecoder(e.Data(), KindBloom)
+	if got := d.Uvarint(); got != 0 {
+		t.Fatalf("want 0, got %d", got)
+	}
+	if got := d.Uvarint(); got != math.MaxUint64 {
+		t.Fatalf("want max uint64, got %d", got)
+	}
+	if got := d.Varint(); got != -42 {
+		t.Fatalf("want -42, got %d", got)
+	}
+	if got := d.Varint(); got != math.MinInt64 {
+		t.Fatalf("want min int64, got %d", got)
+	}
+	if got := d.Uint64(); got != 0xdeadbeef {
+		t.Fatalf("want 0xdeadbeef, got %x", got)
+	}
+	if got := d.Float64(); got != math.Pi {
+		t.Fatalf("want pi, got %f", got)
+	}
+	if got := d.Bytes(); len(got) != 0 {
+		t.Fatalf("want empty bytes, got %v", got)
+	}
+	if got := d.Bytes(); string(got) != "hello" {
+		t.Fatalf("want hello, got %q", got)
+	}
+	if got := d.Uint16s(); !cmp.Equal([]uint16{1, 2, math.MaxUint16}, got, cmpopts.EquateEmpty()) {
+		t.Fatalf("want uint16s, got %v", got)
+	}
+	if got := d.Uint64s(); !cmp.Equal([]uint64{1, 2, math.MaxUint64}, got, cmpopts.EquateEmpty()) {
+		t.Fatalf("want uint64s, got %v", got)
+	}
+	if got := d.Int(10); got != 10 {
+		t.Fatalf("want 10, got %d", got)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	valid := NewEncoder(KindBloom)
+	valid.Uvarint(1)
+	data := valid.Data()
+
+	cases := []struct {
+		desc string
+		data []byte
+		kind Kind
+		fn   func(d *Decoder)
+	}{
+		{"empty", nil, KindBloom, nil},
+		{"short header", data[:3], KindBloom, nil},
+		{"bad magic", append([]byte("ALGO"), data[4:]...), KindBloom, nil},
+		{"bad version", append(append([]byte("algo"), Version+1), data[5:]...), KindBloom, nil},
+		{"zero version", append(append([]byte("algo"), 0), data[5:]...), KindBloom, nil},
+		{"bad kind", data, KindBloom + 1, nil},
+		{"trailing data", data, KindBloom, func(d *Decoder) {}},
+		{"missing uvarint", data, KindBloom, func(d *Decoder) { d.Uvarint(); d.Uvarint() }},
+		{"missing uint64", data, KindBloom, func(d *Decoder) { d.Uint64() }},
+		{"int out of range", data, KindBloom, func(d *Decoder) { d.Int(0) }},
+		{"bytes too long", data, KindBloom, func(d *Decoder) { d.Bytes() }},
+		{"uint16s too long", data, KindBloom, func(d *Decoder) { d.Uint16s() }},
+		{"uint64s too long", data, KindBloom, func(d *Decoder) { d.Uint64s() }},
+		{"fail", data, KindBloom, func(d *Decoder) { d.Uvarint(); d.Fail() }},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			d := NewDecoder(c.data, c.kind)
+			if c.fn != nil {
+				c.fn(d)
+			}
+			if err := d.Close(); err != ErrInvalid {
+				t.Fatalf("want ErrInvalid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeAfterError(t *testing.T) {
+	d := NewDecoder(nil, KindBloom)
+	if d.Err() != ErrInvalid {
+		t.Fatalf("want ErrInvalid, got %v", d.Err())
+	}
+	if d.Uvarint() != 0 || d.Varint() != 0 || d.Uint64() != 0 || d.Float64() != 0 ||
+		d.Bytes() != nil || d.Uint16s() != nil || d.Uint64s() != nil || d.Int(10) != 0 {
+		t.Fatal("want zero values after error")
+	}
+}
@@ -0,0 +1,385 @@
+package intervaltrees
+
+type (
+	K = int // NOTE: generic type placeholder
+	V = int // NOTE: generic type placeholder
+)
+
+// Tree is a map of half-open intervals [lo, hi) to values, backed by an
+// augmented balanced binary search tree (a left-leaning red-black tree
+// ordered by lo, then hi, where each node also keeps the largest hi of its
+// subtree). It finds the intervals that overlap a range or that contain a
+// point without visiting the subtrees that cannot contain any. An interval
+// is a key of the map, inserting the same interval twice replaces its value.
+// Its zero-value is ready to use.
+//
+// See "Introduction to Algorithms", by Cormen, Leiserson, Rivest and
+// Stein, chapter 14.3 (Interval trees).
+type Tree /*[K algo.Ordered, V algo.Any]*/ struct {
+	root *node /*[K, V]*/
+	len  int
+}
+
+type node /*[K algo.Ordered, V algo.Any]*/ struct {
+	lo, hi      K
+	val         V
+	left, right *node /*[K, V]*/
+	max         K     // largest hi of the subtree rooted at this node
+	red         bool  // color of the link from the parent
+}
+
+// Make returns an interval tree of some endpoint and value types.
+func Make /*[K algo.Ordered, V algo.Any]*/ () *Tree /*[K, V]*/ {
+	return new(Tree /*[K, V]*/)
+}
+
+// returns the ordering of the intervals [lo1, hi1) and [lo2, hi2), by lo
+// and then by hi.
+func compare /*[K algo.Ordered]*/ (lo1, hi1, lo2, hi2 K) int {
+	switch {
+	case lo1 < lo2:
+		return -1
+	case lo1 > lo2:
+		return 1
+	case hi1 < hi2:
+		return -1
+	case hi1 > hi2:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Len returns the number of intervals in the tree t.
+func (t *Tree /*[K, V]*/) Len() int {
+	return t.len
+}
+
+// Get returns the value associated with the interval [lo, hi) and true, or
+// the zero value of V and false if the interval is not in the tree.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (t *Tree /*[K, V]*/) Get(lo, hi K) (V, bool) {
+	if n := t.find(lo, hi); n != nil {
+		return n.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether the interval [lo, hi) is in the tree t.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (t *Tree /*[K, V]*/) Contains(lo, hi K) bool {
+	return t.find(lo, hi) != nil
+}
+
+func (t *Tree /*[K, V]*/) find(lo, hi K) *node /*[K, V]*/ {
+	n := t.root
+	for n != nil {
+		c := compare(lo, hi, n.lo, n.hi)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// Insert associates the value v with the interval [lo, hi) in the tree t,
+// replacing the previous value if the interval is already in t. It panics
+// if the interval is empty, i.e. if lo >= hi.
+//
+// It runs in O(log n) time complexity.
+func (t *Tree /*[K, V]*/) Insert(lo, hi K, v V) {
+	if !(lo < hi) {
+		panic("intervaltrees: empty interval")
+	}
+	t.root = t.insert(t.root, lo, hi, v)
+	t.root.red = false
+}
+
+func (t *Tree /*[K, V]*/) insert(n *node /*[K, V]*/, lo, hi K, v V) *node /*[K, V]*/ {
+	if n == nil {
+		t.len++
+		return &node /*[K, V]*/ {lo: lo, hi: hi, val: v, max: hi, red: true}
+	}
+
+	c := compare(lo, hi, n.lo, n.hi)
+	switch {
+	case c < 0:
+		n.left = t.insert(n.left, lo, hi, v)
+	case c > 0:
+		n.right = t.insert(n.right, lo, hi, v)
+	default:
+		n.val = v
+	}
+	return n.fixUp()
+}
+
+// Delete removes the interval [lo, hi) and its associated value from the
+// tree t and returns true, or false if the interval is not in t.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (t *Tree /*[K, V]*/) Delete(lo, hi K) bool {
+	if !t.Contains(lo, hi) {
+		return false
+	}
+
+	// the deletion moves a red link down the search path, so that the
+	// deleted node is never a 2-node (a black node with no red child).
+	if !t.root.left.isRed() && !t.root.right.isRed() {
+		t.root.red = true
+	}
+	t.root = t.delete(t.root, lo, hi)
+	if t.root != nil {
+		t.root.red = false
+	}
+	t.len--
+	return true
+}
+
+// deletes [lo, hi), which must be in the subtree rooted at n, and returns
+// the new root of the subtree.
+func (t *Tree /*[K, V]*/) delete(n *node /*[K, V]*/, lo, hi K) *node /*[K, V]*/ {
+	if compare(lo, hi, n.lo, n.hi) < 0 {
+		if !n.left.isRed() && !n.left.left.isRed() {
+			n = n.moveRedLeft()
+		}
+		n.left = t.delete(n.left, lo, hi)
+		return n.fixUp()
+	}
+
+	if n.left.isRed() {
+		n = n.rotateRight()
+	}
+	if compare(lo, hi, n.lo, n.hi) == 0 && n.right == nil {
+		return nil
+	}
+	if !n.right.isRed() && !n.right.left.isRed() {
+		n = n.moveRedRight()
+	}
+	if compare(lo, hi, n.lo, n.hi) == 0 {
+		// replace the node with its successor, the smallest interval of the
+		// right subtree, and remove that successor.
+		succ := n.right.min()
+		n.lo, n.hi, n.val = succ.lo, succ.hi, succ.val
+		n.right = n.right.deleteMin()
+	} else {
+		n.right = t.delete(n.right, lo, hi)
+	}
+	return n.fixUp()
+}
+
+// Overlapping calls fn for each interval of the tree that overlaps the
+// half-open range [lo, hi), i.e. that has at least one point in common with
+// it, and its value, in ascending order of intervals, until all those
+// intervals have been visited or fn returns false. If lo >= hi, the range is
+// empty and no interval overlaps it. The tree must not be modified during
+// the iteration.
+//
+// It runs in O(min(n, m log n)) time complexity where m is the number of
+// intervals visited, i.e. in O(log n) if none overlaps the range.
+func (t *Tree /*[K, V]*/) Overlapping(lo, hi K, fn func(lo, hi K, v V) bool) {
+	if lo < hi {
+		t.root.overlapping(lo, hi, fn)
+	}
+}
+
+// Stabbing calls fn for each interval of the tree that contains the point
+// p, i.e. such that lo <= p < hi, and its value, in ascending order of
+// intervals, until all those intervals have been visited or fn returns
+// false. The tree must not be modified during the iteration.
+//
+// It runs in O(min(n, m log n)) time complexity where m is the number of
+// intervals visited, i.e. in O(log n) if none contains the point.
+func (t *Tree /*[K, V]*/) Stabbing(p K, fn func(lo, hi K, v V) bool) {
+	t.root.stabbing(p, fn)
+}
+
+// AnyOverlap returns one of the intervals of the tree that overlap the
+// half-open range [lo, hi) and its value, and true, or the zero values and
+// false if there is no such interval. It is faster than Overlapping to check
+// if a range overlaps any interval, e.g. to detect a conflict.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (t *Tree /*[K, V]*/) AnyOverlap(lo, hi K) (K, K, V, bool) {
+	if lo < hi {
+		for n := t.root; n != nil; {
+			if n.lo < hi && lo < n.hi {
+				return n.lo, n.hi, n.val, true
+			}
+			// if the left subtree has an interval that ends after lo but does not
+			// overlap the range, that interval starts at or after hi and so do all
+			// intervals on the right, so only one side can have an overlap.
+			if n.left != nil && n.left.max > lo {
+				n = n.left
+			} else {
+				n = n.right
+			}
+		}
+	}
+	var zk K
+	var zv V
+	return zk, zk, zv, false
+}
+
+// Ascend calls fn for each interval of the tree and its value, in ascending
+// order of intervals (by lo, then by hi), until all intervals have been
+// visited or fn returns false. The tree must not be modified during the
+// iteration.
+//
+// It runs in O(n) time complexity where n is the number of intervals.
+func (t *Tree /*[K, V]*/) Ascend(fn func(lo, hi K, v V) bool) {
+	t.root.ascend(fn)
+}
+
+func (n *node /*[K, V]*/) overlapping(lo, hi K, fn func(lo, hi K, v V) bool) bool {
+	// only visit the subtrees that have an interval that ends after lo
+	if n == nil || !(n.max > lo) {
+		return true
+	}
+	if !n.left.overlapping(lo, hi, fn) {
+		return false
+	}
+	if !(n.lo < hi) {
+		// this interval and those on the right start at or after hi
+		return true
+	}
+	if lo < n.hi && !fn(n.lo, n.hi, n.val) {
+		return false
+	}
+	return n.right.overlapping(lo, hi, fn)
+}
+
+func (n *node /*[K, V]*/) stabbing(p K, fn func(lo, hi K, v V) bool) bool {
+	// only visit the subtrees that have an interval that ends after p
+	if n == nil || !(n.max > p) {
+		return true
+	}
+	if !n.left.stabbing(p, fn) {
+		return false
+	}
+	if p < n.lo {
+		// this interval and those on the right start after p
+		return true
+	}
+	if p < n.hi && !fn(n.lo, n.hi, n.val) {
+		return false
+	}
+	return n.right.stabbing(p, fn)
+}
+
+func (n *node /*[K, V]*/) ascend(fn func(lo, hi K, v V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.ascend(fn) && fn(n.lo, n.hi, n.val) && n.right.ascend(fn)
+}
+
+func (n *node /*[K, V]*/) isRed() bool {
+	return n != nil && n.red
+}
+
+func (n *node /*[K, V]*/) min() *node /*[K, V]*/ {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// updates the largest hi of the subtree rooted at n from its children. The
+// rotations call it on both nodes they move, lower node first.
+func (n *node /*[K, V]*/) updateMax() {
+	n.max = n.hi
+	if n.left != nil && n.left.max > n.max {
+		n.max = n.left.max
+	}
+	if n.right != nil && n.right.max > n.max {
+		n.max = n.right.max
+	}
+}
+
+// deletes the smallest interval of the subtree rooted at n and returns the
+// new root of the subtree.
+func (n *node /*[K, V]*/) deleteMin() *node /*[K, V]*/ {
+	if n.left == nil {
+		return nil
+	}
+	if !n.left.isRed() && !n.left.left.isRed() {
+		n = n.moveRedLeft()
+	}
+	n.left = n.left.deleteMin()
+	return n.fixUp()
+}
+
+// restores the left-leaning red-black properties of n on the way up after
+// an insertion or a deletion (no right-leaning red link and no two red links
+// in a row), and updates its largest hi. It returns the new root of the
+// subtree.
+func (n *node /*[K, V]*/) fixUp() *node /*[K, V]*/ {
+	if n.right.isRed() && !n.left.isRed() {
+		n = n.rotateLeft()
+	}
+	if n.left.isRed() && n.left.left.isRed() {
+		n = n.rotateRight()
+	}
+	if n.left.isRed() && n.right.isRed() {
+		n.flipColors()
+	}
+	n.updateMax()
+	return n
+}
+
+// assuming n is red and both its children are black, makes its left child
+// or one of its children red.
+func (n *node /*[K, V]*/) moveRedLeft() *node /*[K, V]*/ {
+	n.flipColors()
+	if n.right.left.isRed() {
+		n.right = n.right.rotateRight()
+		n = n.rotateLeft()
+		n.flipColors()
+	}
+	return n
+}
+
+// assuming n is red and both its right child and its left grandchild are
+// black, makes its right child or one of its children red.
+func (n *node /*[K, V]*/) moveRedRight() *node /*[K, V]*/ {
+	n.flipColors()
+	if n.left.left.isRed() {
+		n = n.rotateRight()
+		n.flipColors()
+	}
+	return n
+}
+
+func (n *node /*[K, V]*/) flipColors() {
+	n.red = !n.red
+	n.left.red = !n.left.red
+	n.right.red = !n.right.red
+}
+
+func (n *node /*[K, V]*/) rotateLeft() *node /*[K, V]*/ {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	r.red, n.red = n.red, true
+	n.updateMax()
+	r.updateMax()
+	return r
+}
+
+func (n *node /*[K, V]*/) rotateRight() *node /*[K, V]*/ {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	l.red, n.red = n.red, true
+	n.updateMax()
+	l.updateMax()
+	return l
+}
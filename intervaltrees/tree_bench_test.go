@@ -0,0 +1,127 @@
+package intervaltrees
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// returns n random intervals with lo in [0, 10*n) and a length in [1, 100].
+func benchIntervals(n int) []interval {
+	r := rand.New(rand.NewSource(1))
+	ivs := make([]interval, n)
+	for i := range ivs {
+		lo := r.Intn(10 * n)
+		ivs[i] = interval{lo, lo + 1 + r.Intn(100), i}
+	}
+	return ivs
+}
+
+func benchTree(n int) *Tree {
+	tr := Make()
+	for _, iv := range benchIntervals(n) {
+		tr.Insert(iv.Lo, iv.Hi, iv.Val)
+	}
+	return tr
+}
+
+func BenchmarkTree_InsertDelete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			tr := benchTree(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// a negative lo is never in the tree
+				lo := -(i % n) - 1
+				tr.Insert(lo, lo+10, i)
+				tr.Delete(lo, lo+10)
+			}
+		})
+	}
+}
+
+func BenchmarkTree_Overlapping(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			tr := benchTree(n)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				lo := r.Intn(10 * n)
+				tr.Overlapping(lo, lo+100, func(K, K, V) bool { return true })
+			}
+		})
+	}
+}
+
+func BenchmarkTree_Stabbing(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			tr := benchTree(n)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				tr.Stabbing(r.Intn(10*n), func(K, K, V) bool { return true })
+			}
+		})
+	}
+}
+
+func BenchmarkTree_AnyOverlap(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			tr := benchTree(n)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				lo := r.Intn(10 * n)
+				_, _, _, _ = tr.AnyOverlap(lo, lo+100)
+			}
+		})
+	}
+}
+
+// for comparison with the intervals overlapping a range found by iterating
+// over a slice.
+func BenchmarkSlice_Overlapping(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			ivs := benchIntervals(n)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				lo := r.Intn(10 * n)
+				hi := lo + 100
+				for _, iv := range ivs {
+					if iv.Lo < hi && lo < iv.Hi {
+						_ = iv.Val
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSlice_Stabbing(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			ivs := benchIntervals(n)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				p := r.Intn(10 * n)
+				for _, iv := range ivs {
+					if iv.Lo <= p && p < iv.Hi {
+						_ = iv.Val
+					}
+				}
+			}
+		})
+	}
+}
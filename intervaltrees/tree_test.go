@@ -0,0 +1,321 @@
+package intervaltrees
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+type interval struct {
+	Lo, Hi K
+	Val    V
+}
+
+func TestTree(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var tr Tree
+		if tr.Len() != 0 {
+			t.Fatal("want empty tree")
+		}
+		if _, ok := tr.Get(1, 2); ok {
+			t.Fatal("want no value")
+		}
+		if _, _, _, ok := tr.AnyOverlap(0, 10); ok {
+			t.Fatal("want no overlap")
+		}
+		if tr.Delete(1, 2) {
+			t.Fatal("want no delete")
+		}
+		tr.Insert(1, 2, 3)
+		if v, ok := tr.Get(1, 2); !ok || v != 3 || tr.Len() != 1 {
+			t.Fatal("want single interval")
+		}
+	})
+
+	t.Run("InsertReplace", func(t *testing.T) {
+		tr := Make()
+		tr.Insert(1, 5, 10)
+		tr.Insert(1, 6, 20)
+		tr.Insert(1, 5, 11)
+		checkTree(t, tr, []interval{{1, 5, 11}, {1, 6, 20}})
+	})
+
+	t.Run("InsertDeleteRandom", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		tr := Make()
+		ref := make(map[[2]K]V)
+		for i := 0; i < 10000; i++ {
+			lo := r.Intn(1000)
+			hi := lo + 1 + r.Intn(50)
+			key := [2]K{lo, hi}
+			switch r.Intn(4) {
+			case 0:
+				_, want := ref[key]
+				if got := tr.Delete(lo, hi); got != want {
+					t.Fatalf("%v: want deleted %t, got %t", key, want, got)
+				}
+				delete(ref, key)
+			case 1:
+				_, want := ref[key]
+				if got := tr.Contains(lo, hi); got != want {
+					t.Fatalf("%v: want contains %t, got %t", key, want, got)
+				}
+			default:
+				v := r.Int()
+				tr.Insert(lo, hi, v)
+				ref[key] = v
+			}
+			if i%500 == 0 {
+				checkTree(t, tr, refIntervals(ref))
+			}
+		}
+		checkTree(t, tr, refIntervals(ref))
+
+		for key := range ref {
+			tr.Delete(key[0], key[1])
+		}
+		checkTree(t, tr, nil)
+	})
+}
+
+func TestTreeQueries(t *testing.T) {
+	tr := Make()
+	ivs := []interval{{0, 10, 0}, {2, 4, 1}, {3, 8, 2}, {5, 6, 3}, {10, 12, 4}, {15, 20, 5}}
+	for _, iv := range ivs {
+		tr.Insert(iv.Lo, iv.Hi, iv.Val)
+	}
+
+	overlaps := []struct {
+		lo, hi K
+		want   []V
+	}{
+		{-5, 0, nil},
+		{-5, 1, []V{0}},
+		{4, 5, []V{0, 2}},
+		{4, 6, []V{0, 2, 3}},
+		{9, 11, []V{0, 4}},
+		{10, 10, nil},
+		{12, 15, nil},
+		{12, 16, []V{5}},
+		{6, 3, nil},
+		{-100, 100, []V{0, 1, 2, 3, 4, 5}},
+	}
+	for _, c := range overlaps {
+		var got []V
+		tr.Overlapping(c.lo, c.hi, func(lo, hi K, v V) bool {
+			got = append(got, v)
+			return true
+		})
+		if diff := cmp.Diff(c.want, got); diff != "" {
+			t.Fatalf("overlapping [%d, %d): %s", c.lo, c.hi, diff)
+		}
+
+		_, _, v, ok := tr.AnyOverlap(c.lo, c.hi)
+		if ok != (len(c.want) > 0) {
+			t.Fatalf("any overlap [%d, %d): want %t, got %t", c.lo, c.hi, len(c.want) > 0, ok)
+		}
+		if ok && !containsValue(c.want, v) {
+			t.Fatalf("any overlap [%d, %d): want one of %v, got %d", c.lo, c.hi, c.want, v)
+		}
+	}
+
+	stabs := []struct {
+		p    K
+		want []V
+	}{
+		{-1, nil},
+		{0, []V{0}},
+		{3, []V{0, 1, 2}},
+		{4, []V{0, 2}},
+		{10, []V{4}},
+		{12, nil},
+		{19, []V{5}},
+		{20, nil},
+	}
+	for _, c := range stabs {
+		var got []V
+		tr.Stabbing(c.p, func(lo, hi K, v V) bool {
+			got = append(got, v)
+			return true
+		})
+		if diff := cmp.Diff(c.want, got); diff != "" {
+			t.Fatalf("stabbing %d: %s", c.p, diff)
+		}
+	}
+
+	// stop early
+	var got []V
+	tr.Overlapping(0, 100, func(lo, hi K, v V) bool {
+		got = append(got, v)
+		return v < 2
+	})
+	if diff := cmp.Diff([]V{0, 1, 2}, got); diff != "" {
+		t.Fatal(diff)
+	}
+	got = nil
+	tr.Stabbing(3, func(lo, hi K, v V) bool {
+		got = append(got, v)
+		return false
+	})
+	if diff := cmp.Diff([]V{0}, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestTreeQueriesRandom(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	tr := Make()
+	ref := make(map[[2]K]V)
+	for i := 0; i < 1000; i++ {
+		lo := r.Intn(10000)
+		hi := lo + 1 + r.Intn(500)
+		tr.Insert(lo, hi, i)
+		ref[[2]K{lo, hi}] = i
+	}
+	ivs := refIntervals(ref)
+
+	for i := 0; i < 1000; i++ {
+		lo := r.Intn(11000) - 500
+		hi := lo + r.Intn(200)
+
+		var want, got []interval
+		for _, iv := range ivs {
+			if lo < hi && iv.Lo < hi && lo < iv.Hi {
+				want = append(want, iv)
+			}
+		}
+		tr.Overlapping(lo, hi, func(lo, hi K, v V) bool {
+			got = append(got, interval{lo, hi, v})
+			return true
+		})
+		if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Fatalf("overlapping [%d, %d): %s", lo, hi, diff)
+		}
+		if _, _, _, ok := tr.AnyOverlap(lo, hi); ok != (len(want) > 0) {
+			t.Fatalf("any overlap [%d, %d): want %t, got %t", lo, hi, len(want) > 0, ok)
+		}
+
+		want, got = nil, nil
+		for _, iv := range ivs {
+			if iv.Lo <= lo && lo < iv.Hi {
+				want = append(want, iv)
+			}
+		}
+		tr.Stabbing(lo, func(lo, hi K, v V) bool {
+			got = append(got, interval{lo, hi, v})
+			return true
+		})
+		if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Fatalf("stabbing %d: %s", lo, diff)
+		}
+	}
+}
+
+func TestTreePanics(t *testing.T) {
+	tr := Make()
+	cases := []struct {
+		desc string
+		fn   func()
+	}{
+		{"Insert empty", func() { tr.Insert(1, 1, 0) }},
+		{"Insert inverted", func() { tr.Insert(2, 1, 0) }},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("want panic")
+				}
+			}()
+			c.fn()
+		})
+	}
+}
+
+func containsValue(vals []V, v V) bool {
+	for _, vv := range vals {
+		if vv == v {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the intervals of ref in ascending order.
+func refIntervals(ref map[[2]K]V) []interval {
+	ivs := make([]interval, 0, len(ref))
+	for k, v := range ref {
+		ivs = append(ivs, interval{k[0], k[1], v})
+	}
+	sort.Slice(ivs, func(i, j int) bool {
+		return compare(ivs[i].Lo, ivs[i].Hi, ivs[j].Lo, ivs[j].Hi) < 0
+	})
+	return ivs
+}
+
+// checkTree checks that tr contains the intervals in want, in order, and
+// that its tree is a valid left-leaning red-black tree where each node has
+// the largest hi of its subtree.
+func checkTree(t *testing.T, tr *Tree, want []interval) {
+	t.Helper()
+
+	if tr.Len() != len(want) {
+		t.Fatalf("want len %d, got %d", len(want), tr.Len())
+	}
+	var got []interval
+	tr.Ascend(func(lo, hi K, v V) bool {
+		got = append(got, interval{lo, hi, v})
+		return true
+	})
+	if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("intervals: %s", diff)
+	}
+	if tr.root.isRed() {
+		t.Fatal("want black root")
+	}
+	checkNode(t, tr.root)
+}
+
+// checkNode checks the red-black properties and the largest hi of the
+// subtree rooted at n, and returns its black height.
+func checkNode(t *testing.T, n *node) int {
+	t.Helper()
+
+	if n == nil {
+		return 1
+	}
+	if n.right.isRed() {
+		t.Fatalf("[%d, %d): want no right-leaning red link", n.lo, n.hi)
+	}
+	if n.red && n.left.isRed() {
+		t.Fatalf("[%d, %d): want no two red links in a row", n.lo, n.hi)
+	}
+	wantMax := n.hi
+	for _, c := range []*node{n.left, n.right} {
+		if c != nil && c.max > wantMax {
+			wantMax = c.max
+		}
+	}
+	if n.max != wantMax {
+		t.Fatalf("[%d, %d): want max %d, got %d", n.lo, n.hi, wantMax, n.max)
+	}
+
+	lh, rh := checkNode(t, n.left), checkNode(t, n.right)
+	if lh != rh {
+		t.Fatalf("[%d, %d): want same black height, got %d and %d", n.lo, n.hi, lh, rh)
+	}
+	if !n.red {
+		lh++
+	}
+	return lh
+}
@@ -0,0 +1,144 @@
+package lists
+
+// Floyd detects the cycle in the sequence of values x0, next(x0),
+// next(next(x0)), and so on, using Floyd's "tortoise and hare" algorithm. The
+// sequence must be infinite and eventually periodic, which is always the case
+// when next maps a finite set of values to itself (e.g. a pseudo-random
+// number generator or the iteration of a function modulo some number). It
+// returns the index mu of the first value of the cycle in the sequence and
+// the length lambda of the cycle.
+//
+// It runs in O(mu + lambda) time complexity and O(1) space complexity. It
+// does not allocate.
+func Floyd /*[T algo.Comparable]*/ (x0 T, next func(T) T) (mu, lambda int) {
+	// the hare moves twice as fast as the tortoise, they meet inside the cycle
+	// at a position that is a multiple of lambda.
+	tortoise, hare := next(x0), next(next(x0))
+	for tortoise != hare {
+		tortoise = next(tortoise)
+		hare = next(next(hare))
+	}
+
+	// the distance from x0 to the start of the cycle is the same as the
+	// distance from the meeting point to the start of the cycle, so moving
+	// both at the same speed finds mu.
+	tortoise = x0
+	for tortoise != hare {
+		tortoise = next(tortoise)
+		hare = next(hare)
+		mu++
+	}
+
+	// finally, find lambda by moving the hare around the cycle once.
+	lambda = 1
+	for hare = next(tortoise); tortoise != hare; hare = next(hare) {
+		lambda++
+	}
+	return mu, lambda
+}
+
+// Brent detects the cycle in the sequence of values x0, next(x0),
+// next(next(x0)), and so on, using Brent's algorithm. Like Floyd, the
+// sequence must be infinite and eventually periodic, and it returns the index
+// mu of the first value of the cycle in the sequence and the length lambda of
+// the cycle. It finds lambda directly and usually calls next fewer times than
+// Floyd.
+//
+// It runs in O(mu + lambda) time complexity and O(1) space complexity. It
+// does not allocate.
+func Brent /*[T algo.Comparable]*/ (x0 T, next func(T) T) (mu, lambda int) {
+	// the tortoise teleports to the hare's position at every power of two,
+	// lambda is found when the hare meets the tortoise.
+	power, lambda := 1, 1
+	tortoise, hare := x0, next(x0)
+	for tortoise != hare {
+		if power == lambda {
+			tortoise = hare
+			power *= 2
+			lambda = 0
+		}
+		hare = next(hare)
+		lambda++
+	}
+
+	// start the hare lambda steps ahead of the tortoise, they meet at the start
+	// of the cycle.
+	tortoise, hare = x0, x0
+	for i := 0; i < lambda; i++ {
+		hare = next(hare)
+	}
+	for tortoise != hare {
+		tortoise = next(tortoise)
+		hare = next(hare)
+		mu++
+	}
+	return mu, lambda
+}
+
+// FloydNode detects a cycle in the linked nodes starting at head using
+// Floyd's "tortoise and hare" algorithm. It returns the first node of the
+// cycle and the length of the cycle, or nil and 0 if the nodes are not
+// cyclic (that is, the last node has a nil Next field).
+//
+// It runs in O(n) time complexity where n is the number of distinct nodes,
+// and O(1) space complexity. It does not allocate.
+func FloydNode /*[T algo.Any]*/ (head *SNode /*[T]*/) (*SNode /*[T]*/, int) {
+	tortoise, hare := head, head
+	for {
+		if hare == nil || hare.Next == nil {
+			return nil, 0
+		}
+		tortoise, hare = tortoise.Next, hare.Next.Next
+		if tortoise == hare {
+			break
+		}
+	}
+
+	tortoise = head
+	for tortoise != hare {
+		tortoise, hare = tortoise.Next, hare.Next
+	}
+
+	lambda := 1
+	for hare = tortoise.Next; tortoise != hare; hare = hare.Next {
+		lambda++
+	}
+	return tortoise, lambda
+}
+
+// BrentNode detects a cycle in the linked nodes starting at head using
+// Brent's algorithm. It returns the first node of the cycle and the length of
+// the cycle, or nil and 0 if the nodes are not cyclic (that is, the last node
+// has a nil Next field).
+//
+// It runs in O(n) time complexity where n is the number of distinct nodes,
+// and O(1) space complexity. It does not allocate.
+func BrentNode /*[T algo.Any]*/ (head *SNode /*[T]*/) (*SNode /*[T]*/, int) {
+	if head == nil {
+		return nil, 0
+	}
+
+	power, lambda := 1, 1
+	tortoise, hare := head, head.Next
+	for tortoise != hare {
+		if hare == nil {
+			return nil, 0
+		}
+		if power == lambda {
+			tortoise = hare
+			power *= 2
+			lambda = 0
+		}
+		hare = hare.Next
+		lambda++
+	}
+
+	tortoise, hare = head, head
+	for i := 0; i < lambda; i++ {
+		hare = hare.Next
+	}
+	for tortoise != hare {
+		tortoise, hare = tortoise.Next, hare.Next
+	}
+	return tortoise, lambda
+}
@@ -0,0 +1,87 @@
+package lists
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkFloyd(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		// the sequence has a tail of n values followed by a cycle of n values
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			next := tailCycleFunc(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if mu, lambda := Floyd(0, next); mu != n || lambda != n {
+					b.Fatalf("want (%d, %d), got (%d, %d)", n, n, mu, lambda)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBrent(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			next := tailCycleFunc(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if mu, lambda := Brent(0, next); mu != n || lambda != n {
+					b.Fatalf("want (%d, %d), got (%d, %d)", n, n, mu, lambda)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFloydNode(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			head := tailCycleNodes(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, lambda := FloydNode(head); lambda != n {
+					b.Fatalf("want %d, got %d", n, lambda)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBrentNode(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			head := tailCycleNodes(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, lambda := BrentNode(head); lambda != n {
+					b.Fatalf("want %d, got %d", n, lambda)
+				}
+			}
+		})
+	}
+}
+
+// returns a function that generates 0, 1, ... 2n-1, n, n+1, ... 2n-1, n, ...
+func tailCycleFunc(n int) func(int) int {
+	return func(x int) int {
+		if x == 2*n-1 {
+			return n
+		}
+		return x + 1
+	}
+}
+
+// returns the head of 2n nodes where the last one links to the n-th.
+func tailCycleNodes(n int) *SNode {
+	nodes := make([]SNode, 2*n)
+	for i := range nodes[:len(nodes)-1] {
+		nodes[i].Next = &nodes[i+1]
+	}
+	nodes[len(nodes)-1].Next = &nodes[n]
+	return &nodes[0]
+}
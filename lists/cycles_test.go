@@ -0,0 +1,85 @@
+package lists
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFloydBrent(t *testing.T) {
+	cases := []struct {
+		x0         int
+		next       func(int) int
+		mu, lambda int
+	}{
+		// fixed point
+		{0, func(x int) int { return x }, 0, 1},
+		// pure cycle of 0..9
+		{0, func(x int) int { return (x + 1) % 10 }, 0, 10},
+		{5, func(x int) int { return (x + 1) % 10 }, 0, 10},
+		// 0, 1, 2 ... 9, 5, 6 ... 9, 5 ...
+		{0, func(x int) int {
+			if x == 9 {
+				return 5
+			}
+			return x + 1
+		}, 5, 5},
+		// 3 => 10 => 101 => 2 => 5 => 26 => 167 => 95 => 101 => ...
+		{3, func(x int) int { return (x*x + 1) % 255 }, 2, 6},
+		// 0 => 1 => 2 => 5 => 26 => 167 => 95 => 101 => 2 => ...
+		{0, func(x int) int { return (x*x + 1) % 255 }, 2, 6},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			mu, lambda := Floyd(c.x0, c.next)
+			if mu != c.mu || lambda != c.lambda {
+				t.Fatalf("Floyd: want (%d, %d), got (%d, %d)", c.mu, c.lambda, mu, lambda)
+			}
+			mu, lambda = Brent(c.x0, c.next)
+			if mu != c.mu || lambda != c.lambda {
+				t.Fatalf("Brent: want (%d, %d), got (%d, %d)", c.mu, c.lambda, mu, lambda)
+			}
+		})
+	}
+}
+
+func TestFloydBrentNode(t *testing.T) {
+	cases := []struct {
+		n      int
+		cycle  int // index of the node the last node links to, -1 for none
+		lambda int
+	}{
+		{0, -1, 0},
+		{1, -1, 0},
+		{1, 0, 1},
+		{2, -1, 0},
+		{2, 0, 2},
+		{2, 1, 1},
+		{10, -1, 0},
+		{10, 0, 10},
+		{10, 3, 7},
+		{10, 9, 1},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("n=%d;cycle=%d", c.n, c.cycle), func(t *testing.T) {
+			nodes := make([]SNode, c.n)
+			var l SList
+			for i := range nodes {
+				l.PushBackNode(&nodes[i])
+			}
+			var want *SNode
+			if c.cycle >= 0 {
+				want = &nodes[c.cycle]
+				l.Back().Next = want
+			}
+
+			got, lambda := FloydNode(l.Front())
+			if got != want || lambda != c.lambda {
+				t.Fatalf("FloydNode: want (%p, %d), got (%p, %d)", want, c.lambda, got, lambda)
+			}
+			got, lambda = BrentNode(l.Front())
+			if got != want || lambda != c.lambda {
+				t.Fatalf("BrentNode: want (%p, %d), got (%p, %d)", want, c.lambda, got, lambda)
+			}
+		})
+	}
+}
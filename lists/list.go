@@ -0,0 +1,246 @@
+package lists
+
+type T = int // NOTE: generic type placeholder
+
+// Node is a node of a doubly linked List. It can be allocated by the List
+// when a value is added (e.g. with PushBack), or by the caller and added to
+// the list with the *Node methods (e.g. PushBackNode), in which case the list
+// is intrusive and does not allocate. This can be used to allocate nodes in
+// bulk, or to embed the Node in a larger struct.
+type Node /*[T algo.Any]*/ struct {
+	// Value is the value stored in the node.
+	Value T
+
+	next, prev *Node /*[T]*/
+	sentinel   bool // true only for the root node of a List
+}
+
+// Next returns the next node in the list or nil.
+func (n *Node /*[T]*/) Next() *Node /*[T]*/ {
+	if p := n.next; p != nil && !p.sentinel {
+		return p
+	}
+	return nil
+}
+
+// Prev returns the previous node in the list or nil.
+func (n *Node /*[T]*/) Prev() *Node /*[T]*/ {
+	if p := n.prev; p != nil && !p.sentinel {
+		return p
+	}
+	return nil
+}
+
+// List is a doubly linked list. All insertions and removals of nodes run in
+// O(1) time complexity. Its zero-value is ready to use.
+type List /*[T algo.Any]*/ struct {
+	// root is a sentinel node, root.next is the front of the list and
+	// root.prev is the back, so that the list is circular and there is no
+	// special case for the first and last nodes.
+	root Node /*[T]*/
+	len  int
+}
+
+// Make returns a list of some element type.
+func Make /*[T algo.Any]*/ () *List /*[T]*/ {
+	return new(List).lazyInit()
+}
+
+// MakeFrom returns a list of some element type initialized with the
+// provided values, in order.
+func MakeFrom /*[T algo.Any]*/ (vs ...T) *List /*[T]*/ {
+	l := Make /*[T]*/ ()
+	for _, v := range vs {
+		l.PushBack(v)
+	}
+	return l
+}
+
+func (l *List /*[T]*/) lazyInit() *List /*[T]*/ {
+	if l.root.next == nil {
+		l.root.next = &l.root
+		l.root.prev = &l.root
+		l.root.sentinel = true
+	}
+	return l
+}
+
+// Len reports the number of elements in l.
+func (l *List /*[T]*/) Len() int {
+	return l.len
+}
+
+// Front returns the first node of l or nil if the list is empty.
+func (l *List /*[T]*/) Front() *Node /*[T]*/ {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last node of l or nil if the list is empty.
+func (l *List /*[T]*/) Back() *Node /*[T]*/ {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// Values returns a slice of all values of the list, in order.
+//
+// It runs in O(n) time complexity.
+func (l *List /*[T]*/) Values() []T {
+	var vals []T
+	if l.len > 0 {
+		vals = make([]T, 0, l.len)
+		for n := l.Front(); n != nil; n = n.Next() {
+			vals = append(vals, n.Value)
+		}
+	}
+	return vals
+}
+
+// PushFront adds v at the front of l and returns its new node.
+//
+// It runs in O(1) time complexity.
+func (l *List /*[T]*/) PushFront(v T) *Node /*[T]*/ {
+	return l.PushFrontNode(&Node{Value: v})
+}
+
+// PushFrontNode adds node n at the front of l and returns it. The node must
+// not already be part of a list.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *List /*[T]*/) PushFrontNode(n *Node /*[T]*/) *Node /*[T]*/ {
+	l.lazyInit()
+	return l.insert(n, &l.root)
+}
+
+// PushBack adds v at the back of l and returns its new node.
+//
+// It runs in O(1) time complexity.
+func (l *List /*[T]*/) PushBack(v T) *Node /*[T]*/ {
+	return l.PushBackNode(&Node{Value: v})
+}
+
+// PushBackNode adds node n at the back of l and returns it. The node must not
+// already be part of a list.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *List /*[T]*/) PushBackNode(n *Node /*[T]*/) *Node /*[T]*/ {
+	l.lazyInit()
+	return l.insert(n, l.root.prev)
+}
+
+// InsertAfter adds v immediately after mark and returns its new node. The
+// mark node must be part of l.
+//
+// It runs in O(1) time complexity.
+func (l *List /*[T]*/) InsertAfter(v T, mark *Node /*[T]*/) *Node /*[T]*/ {
+	return l.InsertNodeAfter(&Node{Value: v}, mark)
+}
+
+// InsertNodeAfter adds node n immediately after mark and returns it. The node
+// must not already be part of a list, and mark must be part of l.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *List /*[T]*/) InsertNodeAfter(n, mark *Node /*[T]*/) *Node /*[T]*/ {
+	return l.insert(n, mark)
+}
+
+// InsertBefore adds v immediately before mark and returns its new node. The
+// mark node must be part of l.
+//
+// It runs in O(1) time complexity.
+func (l *List /*[T]*/) InsertBefore(v T, mark *Node /*[T]*/) *Node /*[T]*/ {
+	return l.InsertNodeBefore(&Node{Value: v}, mark)
+}
+
+// InsertNodeBefore adds node n immediately before mark and returns it. The
+// node must not already be part of a list, and mark must be part of l.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *List /*[T]*/) InsertNodeBefore(n, mark *Node /*[T]*/) *Node /*[T]*/ {
+	return l.insert(n, mark.prev)
+}
+
+// inserts n after at.
+func (l *List /*[T]*/) insert(n, at *Node /*[T]*/) *Node /*[T]*/ {
+	n.prev = at
+	n.next = at.next
+	n.prev.next = n
+	n.next.prev = n
+	l.len++
+	return n
+}
+
+// Remove removes node n from l and returns its value. The node must be part
+// of l. Once removed, the node can be added to a list again.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *List /*[T]*/) Remove(n *Node /*[T]*/) T {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	n.next, n.prev = nil, nil
+	l.len--
+	return n.Value
+}
+
+// SpliceAfter moves all nodes of other immediately after mark, in order,
+// leaving other empty. The mark node must be part of l, and other must not
+// be l.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *List /*[T]*/) SpliceAfter(mark *Node /*[T]*/, other *List /*[T]*/) {
+	if other.len == 0 {
+		return
+	}
+
+	first, last := other.root.next, other.root.prev
+
+	first.prev = mark
+	last.next = mark.next
+	mark.next.prev = last
+	mark.next = first
+	l.len += other.len
+
+	other.root.next = &other.root
+	other.root.prev = &other.root
+	other.len = 0
+}
+
+// PushBackList moves all nodes of other at the back of l, in order, leaving
+// other empty. The other list must not be l.
+//
+// Its time complexity is the same as SpliceAfter.
+func (l *List /*[T]*/) PushBackList(other *List /*[T]*/) {
+	l.lazyInit()
+	l.SpliceAfter(l.root.prev, other)
+}
+
+// PushFrontList moves all nodes of other at the front of l, in order, leaving
+// other empty. The other list must not be l.
+//
+// Its time complexity is the same as SpliceAfter.
+func (l *List /*[T]*/) PushFrontList(other *List /*[T]*/) {
+	l.lazyInit()
+	l.SpliceAfter(&l.root, other)
+}
+
+// Reverse reverses the order of the nodes of l in-place.
+//
+// It runs in O(n) time complexity and O(1) space complexity. It does not
+// allocate.
+func (l *List /*[T]*/) Reverse() {
+	l.lazyInit()
+
+	// swap the next and prev pointers of every node, including the sentinel.
+	n := &l.root
+	for {
+		n.next, n.prev = n.prev, n.next
+		n = n.prev // the old next
+		if n == &l.root {
+			break
+		}
+	}
+}
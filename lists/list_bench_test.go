@@ -0,0 +1,74 @@
+package lists
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkList_PushBack(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			l := MakeFrom(sortedSlice(n)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				l.PushBack(i)
+			}
+		})
+
+		// with caller-allocated nodes, PushBackNode does not allocate
+		b.Run(fmt.Sprintf("intrusive n=%d", n), func(b *testing.B) {
+			l := MakeFrom(sortedSlice(n)...)
+			nodes := make([]Node, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				l.PushBackNode(&nodes[i])
+			}
+		})
+	}
+}
+
+func BenchmarkList_Remove(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			l := MakeFrom(sortedSlice(n)...)
+			b.ResetTimer()
+
+			// remove and re-insert the front node, to keep the list size stable
+			for i := 0; i < b.N; i++ {
+				node := l.Front()
+				l.Remove(node)
+				l.PushBackNode(node)
+			}
+		})
+	}
+}
+
+func BenchmarkList_PushBackList(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			l1, l2 := MakeFrom(sortedSlice(n)...), MakeFrom(sortedSlice(n)...)
+			b.ResetTimer()
+
+			// move the nodes back and forth between the two lists
+			for i := 0; i < b.N; i++ {
+				l1.PushBackList(l2)
+				l1, l2 = l2, l1
+			}
+		})
+	}
+}
+
+func BenchmarkList_Reverse(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			l := MakeFrom(sortedSlice(n)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				l.Reverse()
+			}
+		})
+	}
+}
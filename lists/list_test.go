@@ -0,0 +1,161 @@
+package lists
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestList(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var l List
+		if l.Len() != 0 || l.Front() != nil || l.Back() != nil || l.Values() != nil {
+			t.Fatal("want empty list")
+		}
+		l.Reverse()
+		n := l.PushBack(1)
+		if l.Len() != 1 || l.Front() != n || l.Back() != n {
+			t.Fatal("want single node list")
+		}
+		if n.Next() != nil || n.Prev() != nil {
+			t.Fatal("want no next or prev node")
+		}
+	})
+
+	t.Run("PushInsertRemove", func(t *testing.T) {
+		l := Make()
+		n2 := l.PushBack(2)
+		n1 := l.PushFront(1)
+		n4 := l.PushBack(4)
+		n3 := l.InsertAfter(3, n2)
+		n0 := l.InsertBefore(0, n1)
+		checkList(t, l, []int{0, 1, 2, 3, 4})
+
+		if v := l.Remove(n0); v != 0 {
+			t.Fatalf("want removed %d, got %d", 0, v)
+		}
+		checkList(t, l, []int{1, 2, 3, 4})
+		if n0.Next() != nil || n0.Prev() != nil {
+			t.Fatal("want removed node to be unlinked")
+		}
+
+		l.Remove(n4)
+		l.Remove(n2)
+		checkList(t, l, []int{1, 3})
+		l.Remove(n1)
+		l.Remove(n3)
+		checkList(t, l, nil)
+
+		// the removed nodes can be added back
+		l.PushBackNode(n3)
+		l.PushFrontNode(n1)
+		l.InsertNodeAfter(n2, n1)
+		l.InsertNodeBefore(n0, n1)
+		checkList(t, l, []int{0, 1, 2, 3})
+	})
+
+	t.Run("Intrusive", func(t *testing.T) {
+		nodes := make([]Node, 5)
+		var l List
+		for i := range nodes {
+			nodes[i].Value = i
+			l.PushFrontNode(&nodes[i])
+		}
+		checkList(t, &l, []int{4, 3, 2, 1, 0})
+	})
+
+	t.Run("Splice", func(t *testing.T) {
+		cases := []struct {
+			l, other []int
+			front    []int
+			back     []int
+			afterOne []int // splice after the first node, if any
+		}{
+			{nil, nil, nil, nil, nil},
+			{[]int{1}, nil, []int{1}, []int{1}, []int{1}},
+			{nil, []int{1}, []int{1}, []int{1}, nil},
+			{[]int{1, 2}, []int{3, 4}, []int{3, 4, 1, 2}, []int{1, 2, 3, 4}, []int{1, 3, 4, 2}},
+		}
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%v + %v", c.l, c.other), func(t *testing.T) {
+				l, other := MakeFrom(c.l...), MakeFrom(c.other...)
+				l.PushFrontList(other)
+				checkList(t, l, c.front)
+				checkList(t, other, nil)
+
+				l, other = MakeFrom(c.l...), MakeFrom(c.other...)
+				l.PushBackList(other)
+				checkList(t, l, c.back)
+				checkList(t, other, nil)
+
+				if len(c.l) > 0 {
+					l, other = MakeFrom(c.l...), MakeFrom(c.other...)
+					l.SpliceAfter(l.Front(), other)
+					checkList(t, l, c.afterOne)
+					checkList(t, other, nil)
+
+					// other is still usable
+					other.PushBack(5)
+					checkList(t, other, []int{5})
+				}
+			})
+		}
+	})
+
+	t.Run("Reverse", func(t *testing.T) {
+		cases := [][]int{
+			nil,
+			{1},
+			{1, 2},
+			{1, 2, 3},
+			sortedSlice(10),
+		}
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%v", c), func(t *testing.T) {
+				l := MakeFrom(c...)
+				l.Reverse()
+
+				want := make([]int, len(c))
+				for i, v := range c {
+					want[len(c)-1-i] = v
+				}
+				checkList(t, l, want)
+			})
+		}
+	})
+}
+
+// checks the values of the list in both directions.
+func checkList(t *testing.T, l *List, want []int) {
+	t.Helper()
+
+	if l.Len() != len(want) {
+		t.Fatalf("want len %d, got %d", len(want), l.Len())
+	}
+	if got := l.Values(); !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	var back []int
+	for n := l.Back(); n != nil; n = n.Prev() {
+		back = append(back, n.Value)
+	}
+	if len(back) != len(want) {
+		t.Fatalf("want %d values in reverse, got %d", len(want), len(back))
+	}
+	for i, v := range back {
+		if want[len(want)-1-i] != v {
+			t.Fatalf("want %v in reverse, got %v", want, back)
+		}
+	}
+}
+
+func sortedSlice(n int) []int {
+	vals := make([]int, n)
+	for i := 0; i < n; i++ {
+		vals[i] = i + 1
+	}
+	return vals
+}
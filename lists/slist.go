@@ -0,0 +1,212 @@
+package lists
+
+// SNode is a node of a singly linked SList. Like Node, it can be allocated by
+// the SList or by the caller, in which case the list is intrusive and does
+// not allocate.
+//
+// Unlike Node, its link to the next node is exported, so that SNode can
+// also be used as a building block for custom linked structures - modifying
+// it for a node that is part of an SList breaks the list's invariants. See
+// FloydNode and BrentNode to detect cycles in such structures.
+type SNode /*[T algo.Any]*/ struct {
+	// Value is the value stored in the node.
+	Value T
+	// Next is the next node in the list or nil.
+	Next *SNode /*[T]*/
+}
+
+// SList is a singly linked list. Insertions at the front and back of the
+// list and insertions and removals after a node run in O(1) time complexity.
+// Its zero-value is ready to use.
+type SList /*[T algo.Any]*/ struct {
+	head, tail *SNode /*[T]*/
+	len        int
+}
+
+// MakeSingly returns a singly linked list of some element type.
+func MakeSingly /*[T algo.Any]*/ () *SList /*[T]*/ {
+	return new(SList)
+}
+
+// MakeSinglyFrom returns a singly linked list of some element type initialized
+// with the provided values, in order.
+func MakeSinglyFrom /*[T algo.Any]*/ (vs ...T) *SList /*[T]*/ {
+	l := MakeSingly /*[T]*/ ()
+	for _, v := range vs {
+		l.PushBack(v)
+	}
+	return l
+}
+
+// Len reports the number of elements in l.
+func (l *SList /*[T]*/) Len() int {
+	return l.len
+}
+
+// Front returns the first node of l or nil if the list is empty.
+func (l *SList /*[T]*/) Front() *SNode /*[T]*/ {
+	return l.head
+}
+
+// Back returns the last node of l or nil if the list is empty.
+func (l *SList /*[T]*/) Back() *SNode /*[T]*/ {
+	return l.tail
+}
+
+// Values returns a slice of all values of the list, in order.
+//
+// It runs in O(n) time complexity.
+func (l *SList /*[T]*/) Values() []T {
+	var vals []T
+	if l.len > 0 {
+		vals = make([]T, 0, l.len)
+		for n := l.head; n != nil; n = n.Next {
+			vals = append(vals, n.Value)
+		}
+	}
+	return vals
+}
+
+// PushFront adds v at the front of l and returns its new node.
+//
+// It runs in O(1) time complexity.
+func (l *SList /*[T]*/) PushFront(v T) *SNode /*[T]*/ {
+	return l.PushFrontNode(&SNode{Value: v})
+}
+
+// PushFrontNode adds node n at the front of l and returns it. The node must
+// not already be part of a list.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *SList /*[T]*/) PushFrontNode(n *SNode /*[T]*/) *SNode /*[T]*/ {
+	n.Next = l.head
+	l.head = n
+	if l.tail == nil {
+		l.tail = n
+	}
+	l.len++
+	return n
+}
+
+// PushBack adds v at the back of l and returns its new node.
+//
+// It runs in O(1) time complexity.
+func (l *SList /*[T]*/) PushBack(v T) *SNode /*[T]*/ {
+	return l.PushBackNode(&SNode{Value: v})
+}
+
+// PushBackNode adds node n at the back of l and returns it. The node must
+// not already be part of a list.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *SList /*[T]*/) PushBackNode(n *SNode /*[T]*/) *SNode /*[T]*/ {
+	if l.tail == nil {
+		return l.PushFrontNode(n)
+	}
+	return l.InsertNodeAfter(n, l.tail)
+}
+
+// InsertAfter adds v immediately after mark and returns its new node. The
+// mark node must be part of l.
+//
+// It runs in O(1) time complexity.
+func (l *SList /*[T]*/) InsertAfter(v T, mark *SNode /*[T]*/) *SNode /*[T]*/ {
+	return l.InsertNodeAfter(&SNode{Value: v}, mark)
+}
+
+// InsertNodeAfter adds node n immediately after mark and returns it. The
+// node must not already be part of a list, and mark must be part of l.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *SList /*[T]*/) InsertNodeAfter(n, mark *SNode /*[T]*/) *SNode /*[T]*/ {
+	n.Next = mark.Next
+	mark.Next = n
+	if l.tail == mark {
+		l.tail = n
+	}
+	l.len++
+	return n
+}
+
+// RemoveFront removes the first node of l and returns it, or returns nil if
+// the list is empty. Once removed, the node can be added to a list again.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *SList /*[T]*/) RemoveFront() *SNode /*[T]*/ {
+	n := l.head
+	if n == nil {
+		return nil
+	}
+	l.head = n.Next
+	if l.head == nil {
+		l.tail = nil
+	}
+	n.Next = nil
+	l.len--
+	return n
+}
+
+// RemoveAfter removes the node immediately after mark and returns it, or
+// returns nil if mark is the last node. The mark node must be part of l. Once
+// removed, the node can be added to a list again. As it is a singly linked
+// list, a node cannot be removed without its predecessor in O(1).
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *SList /*[T]*/) RemoveAfter(mark *SNode /*[T]*/) *SNode /*[T]*/ {
+	n := mark.Next
+	if n == nil {
+		return nil
+	}
+	mark.Next = n.Next
+	if l.tail == n {
+		l.tail = mark
+	}
+	n.Next = nil
+	l.len--
+	return n
+}
+
+// PushBackList moves all nodes of other at the back of l, in order, leaving
+// other empty. The other list must not be l.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *SList /*[T]*/) PushBackList(other *SList /*[T]*/) {
+	if other.len == 0 {
+		return
+	}
+	if l.tail == nil {
+		l.head = other.head
+	} else {
+		l.tail.Next = other.head
+	}
+	l.tail = other.tail
+	l.len += other.len
+	*other = SList{}
+}
+
+// PushFrontList moves all nodes of other at the front of l, in order,
+// leaving other empty. The other list must not be l.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (l *SList /*[T]*/) PushFrontList(other *SList /*[T]*/) {
+	if other.len == 0 {
+		return
+	}
+	other.PushBackList(l)
+	*l, *other = *other, SList{}
+}
+
+// Reverse reverses the order of the nodes of l in-place.
+//
+// It runs in O(n) time complexity and O(1) space complexity. It does not
+// allocate.
+func (l *SList /*[T]*/) Reverse() {
+	var prev *SNode /*[T]*/
+	l.tail = l.head
+	for n := l.head; n != nil; {
+		next := n.Next
+		n.Next = prev
+		prev, n = n, next
+	}
+	l.head = prev
+}
@@ -0,0 +1,57 @@
+package lists
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkSList_PushBack(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			l := MakeSinglyFrom(sortedSlice(n)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				l.PushBack(i)
+			}
+		})
+
+		// with caller-allocated nodes, PushBackNode does not allocate
+		b.Run(fmt.Sprintf("intrusive n=%d", n), func(b *testing.B) {
+			l := MakeSinglyFrom(sortedSlice(n)...)
+			nodes := make([]SNode, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				l.PushBackNode(&nodes[i])
+			}
+		})
+	}
+}
+
+func BenchmarkSList_RemoveFront(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			l := MakeSinglyFrom(sortedSlice(n)...)
+			b.ResetTimer()
+
+			// remove and re-insert the front node, to keep the list size stable
+			for i := 0; i < b.N; i++ {
+				l.PushBackNode(l.RemoveFront())
+			}
+		})
+	}
+}
+
+func BenchmarkSList_Reverse(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			l := MakeSinglyFrom(sortedSlice(n)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				l.Reverse()
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package lists
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestSList(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var l SList
+		if l.Len() != 0 || l.Front() != nil || l.Back() != nil || l.Values() != nil {
+			t.Fatal("want empty list")
+		}
+		if n := l.RemoveFront(); n != nil {
+			t.Fatalf("want nil removed node, got %v", n)
+		}
+		l.Reverse()
+		n := l.PushBack(1)
+		if l.Len() != 1 || l.Front() != n || l.Back() != n {
+			t.Fatal("want single node list")
+		}
+	})
+
+	t.Run("PushInsertRemove", func(t *testing.T) {
+		l := MakeSingly()
+		n2 := l.PushBack(2)
+		n1 := l.PushFront(1)
+		l.PushBack(4)
+		l.InsertAfter(3, n2)
+		checkSList(t, l, []int{1, 2, 3, 4})
+
+		if n := l.RemoveAfter(n2); n.Value != 3 {
+			t.Fatalf("want removed %d, got %d", 3, n.Value)
+		}
+		checkSList(t, l, []int{1, 2, 4})
+		if n := l.RemoveAfter(n2); n.Value != 4 {
+			t.Fatalf("want removed %d, got %d", 4, n.Value)
+		}
+		checkSList(t, l, []int{1, 2})
+		if n := l.RemoveAfter(n2); n != nil {
+			t.Fatalf("want nil removed node, got %v", n)
+		}
+		if n := l.RemoveFront(); n != n1 {
+			t.Fatalf("want removed %v, got %v", n1, n)
+		}
+		checkSList(t, l, []int{2})
+		l.RemoveFront()
+		checkSList(t, l, nil)
+
+		// the removed nodes can be added back
+		l.PushBackNode(n1)
+		l.PushBackNode(n2)
+		checkSList(t, l, []int{1, 2})
+	})
+
+	t.Run("Intrusive", func(t *testing.T) {
+		nodes := make([]SNode, 5)
+		var l SList
+		for i := range nodes {
+			nodes[i].Value = i
+			l.PushBackNode(&nodes[i])
+		}
+		checkSList(t, &l, []int{0, 1, 2, 3, 4})
+	})
+
+	t.Run("PushList", func(t *testing.T) {
+		cases := []struct {
+			l, other []int
+			front    []int
+			back     []int
+		}{
+			{nil, nil, nil, nil},
+			{[]int{1}, nil, []int{1}, []int{1}},
+			{nil, []int{1}, []int{1}, []int{1}},
+			{[]int{1, 2}, []int{3, 4}, []int{3, 4, 1, 2}, []int{1, 2, 3, 4}},
+		}
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%v + %v", c.l, c.other), func(t *testing.T) {
+				l, other := MakeSinglyFrom(c.l...), MakeSinglyFrom(c.other...)
+				l.PushFrontList(other)
+				checkSList(t, l, c.front)
+				checkSList(t, other, nil)
+
+				l, other = MakeSinglyFrom(c.l...), MakeSinglyFrom(c.other...)
+				l.PushBackList(other)
+				checkSList(t, l, c.back)
+				checkSList(t, other, nil)
+			})
+		}
+	})
+
+	t.Run("Reverse", func(t *testing.T) {
+		cases := [][]int{
+			nil,
+			{1},
+			{1, 2},
+			{1, 2, 3},
+			sortedSlice(10),
+		}
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%v", c), func(t *testing.T) {
+				l := MakeSinglyFrom(c...)
+				l.Reverse()
+
+				want := make([]int, len(c))
+				for i, v := range c {
+					want[len(c)-1-i] = v
+				}
+				checkSList(t, l, want)
+
+				// tail must be correct after reverse
+				l.PushBack(42)
+				checkSList(t, l, append(want, 42))
+			})
+		}
+	})
+}
+
+func checkSList(t *testing.T, l *SList, want []int) {
+	t.Helper()
+
+	if l.Len() != len(want) {
+		t.Fatalf("want len %d, got %d", len(want), l.Len())
+	}
+	if got := l.Values(); !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	if len(want) > 0 {
+		if l.Front().Value != want[0] || l.Back().Value != want[len(want)-1] {
+			t.Fatalf("want front %d and back %d, got %d and %d", want[0], want[len(want)-1], l.Front().Value, l.Back().Value)
+		}
+		if l.Back().Next != nil {
+			t.Fatal("want nil next after back node")
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package random
+
+import (
+	"math"
+	"math/rand"
+)
+
+type T = int // NOTE: generic type placeholder
+
+// Sample randomly selects k values of vals using the provided *rand.Rand,
+// by performing a partial Fisher-Yates shuffle. The selected values are moved
+// to the front of vals in-place and vals[:k] is returned, so the returned
+// slice shares the underlying array of vals. Each subset of k values is
+// equally likely to be selected, and the selected values are in random order.
+// If k is greater than the number of values, it is capped to that number
+// (i.e. all values are shuffled). It panics if k is negative.
+//
+// It runs in O(k) time complexity and O(1) space complexity. It does not
+// allocate.
+func Sample /*[T algo.Any]*/ (r *rand.Rand, vals []T, k int) []T {
+	if k < 0 {
+		panic("random: negative sample size")
+	}
+	if k > len(vals) {
+		k = len(vals)
+	}
+
+	// select each position i in turn from the values not yet selected, i.e.
+	// vals[i:], and swap the selected value into that position.
+	for i := 0; i < k; i++ {
+		j := i + r.Intn(len(vals)-i)
+		vals[i], vals[j] = vals[j], vals[i]
+	}
+	return vals[:k]
+}
+
+// Reservoir is a reservoir sampler, that is a sampler that selects k values
+// uniformly at random from a stream of values of unknown length in a single
+// pass. It implements Vitter's Algorithm R, which generates a random number
+// for each value added. See ReservoirL for a faster algorithm when the
+// stream is much longer than k.
+type Reservoir /*[T algo.Any]*/ struct {
+	r    *rand.Rand
+	vals []T
+	n    int
+}
+
+// MakeReservoir returns a reservoir sampler of some element type that
+// selects k values using the provided *rand.Rand.
+func MakeReservoir /*[T algo.Any]*/ (r *rand.Rand, k int) *Reservoir /*[T]*/ {
+	return &Reservoir{
+		r:    r,
+		vals: make([]T, 0, k),
+	}
+}
+
+// Add adds values vs to the stream of values to sample from.
+//
+// It runs in O(1) time complexity (O(n) with respect to the number of values
+// to add). It does not allocate.
+func (s *Reservoir /*[T]*/) Add(vs ...T) {
+	for _, v := range vs {
+		s.n++
+		if len(s.vals) < cap(s.vals) {
+			s.vals = append(s.vals, v)
+			continue
+		}
+
+		// the n-th value replaces a random value of the reservoir with
+		// probability k/n.
+		if j := s.r.Intn(s.n); j < len(s.vals) {
+			s.vals[j] = v
+		}
+	}
+}
+
+// Len returns the number of values added to the stream so far.
+func (s *Reservoir /*[T]*/) Len() int {
+	return s.n
+}
+
+// Values returns the sampled values, which is the whole stream if fewer than
+// k values were added. The returned slice is only valid until the next call
+// to Add, use slices.Clone to keep a copy of it.
+func (s *Reservoir /*[T]*/) Values() []T {
+	return s.vals
+}
+
+// ReservoirL is a reservoir sampler like Reservoir, but it implements Li's
+// Algorithm L, which computes how many values to skip before the next one
+// gets selected, so that it only generates random numbers for the selected
+// values. It is much faster than Reservoir when the stream is much longer
+// than k.
+type ReservoirL /*[T algo.Any]*/ struct {
+	r    *rand.Rand
+	vals []T
+	n    int
+	w    float64
+	next int // 1-based position in the stream of the next selected value
+}
+
+// MakeReservoirL returns a reservoir sampler of some element type that
+// selects k values using the provided *rand.Rand.
+func MakeReservoirL /*[T algo.Any]*/ (r *rand.Rand, k int) *ReservoirL /*[T]*/ {
+	return &ReservoirL{
+		r:    r,
+		vals: make([]T, 0, k),
+	}
+}
+
+// Add adds values vs to the stream of values to sample from.
+//
+// It runs in O(1) time complexity (O(n) with respect to the number of values
+// to add). It does not allocate.
+func (s *ReservoirL /*[T]*/) Add(vs ...T) {
+	k := cap(s.vals)
+	for _, v := range vs {
+		s.n++
+		if len(s.vals) < k {
+			s.vals = append(s.vals, v)
+			if len(s.vals) == k {
+				s.w = math.Exp(math.Log(s.randFloat()) / float64(k))
+				s.skip()
+			}
+			continue
+		}
+
+		if s.n == s.next {
+			s.vals[s.r.Intn(k)] = v
+			s.w *= math.Exp(math.Log(s.randFloat()) / float64(k))
+			s.skip()
+		}
+	}
+}
+
+// computes the position of the next value to select.
+func (s *ReservoirL /*[T]*/) skip() {
+	skip := math.Floor(math.Log(s.randFloat()) / math.Log(1-s.w))
+	if skip >= float64(maxInt-s.n-1) {
+		// the stream will never get that long, no other value gets selected
+		s.next = maxInt
+		return
+	}
+	s.next = s.n + int(skip) + 1
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// returns a random number in (0, 1], so that its log is finite.
+func (s *ReservoirL /*[T]*/) randFloat() float64 {
+	return 1 - s.r.Float64()
+}
+
+// Len returns the number of values added to the stream so far.
+func (s *ReservoirL /*[T]*/) Len() int {
+	return s.n
+}
+
+// Values returns the sampled values, which is the whole stream if fewer than
+// k values were added. The returned slice is only valid until the next call
+// to Add, use slices.Clone to keep a copy of it.
+func (s *ReservoirL /*[T]*/) Values() []T {
+	return s.vals
+}
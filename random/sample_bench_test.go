@@ -0,0 +1,61 @@
+package random
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func BenchmarkSample(b *testing.B) {
+	src := time.Now().UnixNano()
+	r := rand.New(rand.NewSource(src))
+	b.Logf("random seed: %d", src)
+
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d;k=10", n), func(b *testing.B) {
+			vals := sortedSlice(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				Sample(r, vals, 10)
+			}
+		})
+	}
+}
+
+func BenchmarkReservoir(b *testing.B) {
+	src := time.Now().UnixNano()
+	r := rand.New(rand.NewSource(src))
+	b.Logf("random seed: %d", src)
+
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d;k=10", n), func(b *testing.B) {
+			vals := sortedSlice(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s := MakeReservoir(r, 10)
+				s.Add(vals...)
+			}
+		})
+	}
+}
+
+func BenchmarkReservoirL(b *testing.B) {
+	src := time.Now().UnixNano()
+	r := rand.New(rand.NewSource(src))
+	b.Logf("random seed: %d", src)
+
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d;k=10", n), func(b *testing.B) {
+			vals := sortedSlice(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s := MakeReservoirL(r, 10)
+				s.Add(vals...)
+			}
+		})
+	}
+}
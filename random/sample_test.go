@@ -0,0 +1,158 @@
+package random
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSample(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	cases := []struct {
+		n, k, want int
+	}{
+		{0, 0, 0},
+		{0, 1, 0},
+		{1, 0, 0},
+		{1, 1, 1},
+		{10, 3, 3},
+		{10, 10, 10},
+		{10, 20, 10},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%d of %d", c.k, c.n), func(t *testing.T) {
+			vals := sortedSlice(c.n)
+			got := Sample(r, vals, c.k)
+			if len(got) != c.want {
+				t.Fatalf("want %d values, got %d", c.want, len(got))
+			}
+
+			// all values must still be present, the sample must be distinct
+			sort.Ints(vals)
+			if !cmp.Equal(sortedSlice(c.n), vals) {
+				t.Fatalf("values were lost: %v", vals)
+			}
+		})
+	}
+
+	t.Run("Deterministic", func(t *testing.T) {
+		s1 := Sample(rand.New(rand.NewSource(seed)), sortedSlice(100), 10)
+		s2 := Sample(rand.New(rand.NewSource(seed)), sortedSlice(100), 10)
+		if !cmp.Equal(s1, s2) {
+			t.Fatalf("want same samples, got %v and %v", s1, s2)
+		}
+	})
+
+	t.Run("Uniform", func(t *testing.T) {
+		counts := make([]int, 10)
+		for i := 0; i < 10000; i++ {
+			for _, v := range Sample(r, sortedSlice(10), 3) {
+				counts[v-1]++
+			}
+		}
+		// each value is expected 3000 times
+		checkUniform(t, counts, 3000)
+	})
+}
+
+func TestReservoir(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+
+	type sampler interface {
+		Add(...T)
+		Len() int
+		Values() []T
+	}
+	makers := map[string]func(r *rand.Rand, k int) sampler{
+		"R": func(r *rand.Rand, k int) sampler { return MakeReservoir(r, k) },
+		"L": func(r *rand.Rand, k int) sampler { return MakeReservoirL(r, k) },
+	}
+
+	for name, mk := range makers {
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(seed))
+
+			cases := []struct {
+				n, k, want int
+			}{
+				{0, 0, 0},
+				{0, 1, 0},
+				{1, 0, 0},
+				{1, 1, 1},
+				{10, 3, 3},
+				{10, 10, 10},
+				{10, 20, 10},
+				{1000, 10, 10},
+			}
+			for _, c := range cases {
+				t.Run(fmt.Sprintf("%d of %d", c.k, c.n), func(t *testing.T) {
+					s := mk(r, c.k)
+					s.Add(sortedSlice(c.n)...)
+					if s.Len() != c.n {
+						t.Fatalf("want len %d, got %d", c.n, s.Len())
+					}
+					got := s.Values()
+					if len(got) != c.want {
+						t.Fatalf("want %d values, got %d", c.want, len(got))
+					}
+					seen := make(map[int]bool)
+					for _, v := range got {
+						if v < 1 || v > c.n || seen[v] {
+							t.Fatalf("invalid or duplicate value in sample %v", got)
+						}
+						seen[v] = true
+					}
+				})
+			}
+
+			t.Run("Deterministic", func(t *testing.T) {
+				s1, s2 := mk(rand.New(rand.NewSource(seed)), 10), mk(rand.New(rand.NewSource(seed)), 10)
+				for _, v := range sortedSlice(1000) {
+					s1.Add(v)
+					s2.Add(v)
+				}
+				if !cmp.Equal(s1.Values(), s2.Values()) {
+					t.Fatalf("want same samples, got %v and %v", s1.Values(), s2.Values())
+				}
+			})
+
+			t.Run("Uniform", func(t *testing.T) {
+				counts := make([]int, 10)
+				for i := 0; i < 10000; i++ {
+					s := mk(r, 3)
+					s.Add(sortedSlice(10)...)
+					for _, v := range s.Values() {
+						counts[v-1]++
+					}
+				}
+				checkUniform(t, counts, 3000)
+			})
+		})
+	}
+}
+
+// checks that each count is within 10% of the expected count.
+func checkUniform(t *testing.T, counts []int, want int) {
+	t.Helper()
+	for i, c := range counts {
+		if c < want*9/10 || c > want*11/10 {
+			t.Fatalf("value at %d: want count close to %d, got %d (%v)", i, want, c, counts)
+		}
+	}
+}
+
+func sortedSlice(n int) []int {
+	vals := make([]int, n)
+	for i := 0; i < n; i++ {
+		vals[i] = i + 1
+	}
+	return vals
+}
@@ -0,0 +1,188 @@
+package random
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+)
+
+// Alias is a weighted random sampler that implements Walker's alias method
+// (using Vose's construction), so that drawing a random index according to
+// the weights runs in O(1) time complexity. It is immutable once created.
+type Alias struct {
+	r     *rand.Rand
+	prob  []float64
+	alias []int
+}
+
+// MakeAlias returns an alias sampler that draws indices of weights with a
+// probability proportional to their weight, using the provided *rand.Rand.
+// It panics if weights is empty, if any weight is negative, infinite or NaN,
+// or if all weights are 0.
+//
+// It runs in O(n) time and space complexity.
+func MakeAlias(r *rand.Rand, weights []float64) *Alias {
+	n := len(weights)
+	if n == 0 {
+		panic("random: no weights")
+	}
+
+	var sum float64
+	for _, w := range weights {
+		if w < 0 || math.IsInf(w, 0) || math.IsNaN(w) {
+			panic("random: invalid weight")
+		}
+		sum += w
+	}
+	if sum == 0 {
+		panic("random: all weights are 0")
+	}
+
+	a := &Alias{
+		r:     r,
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+
+	// scale the weights so that their average is 1, and split them in those
+	// that are smaller than the average and those that are larger. Each small
+	// one fills its column with a part of a large one (its alias), which
+	// becomes smaller and is put back in the appropriate worklist.
+	scaled := make([]float64, n)
+	small, large := make([]int, 0, n), make([]int, 0, n)
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / sum
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+
+		a.prob[s] = scaled[s]
+		a.alias[s] = l
+		scaled[l] = (scaled[l] + scaled[s]) - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+
+	// the remaining columns are full, any value left in small is due to
+	// floating-point rounding errors.
+	for _, l := range large {
+		a.prob[l] = 1
+	}
+	for _, s := range small {
+		a.prob[s] = 1
+	}
+	return a
+}
+
+// Len returns the number of weights of the sampler.
+func (a *Alias) Len() int {
+	return len(a.prob)
+}
+
+// Draw returns a random index of the weights used to create the sampler,
+// with a probability proportional to its weight.
+//
+// It runs in O(1) time and space complexity. It does not allocate.
+func (a *Alias) Draw() int {
+	i := a.r.Intn(len(a.prob))
+	if a.r.Float64() < a.prob[i] {
+		return i
+	}
+	return a.alias[i]
+}
+
+// WeightedReservoir is a weighted reservoir sampler, that is a sampler that
+// selects k values from a stream of weighted values of unknown length in a
+// single pass, where the probability of each value to be selected is
+// proportional to its weight. It implements Efraimidis and Spirakis's
+// algorithm A-Res.
+type WeightedReservoir /*[T algo.Any]*/ struct {
+	r     *rand.Rand
+	k     int
+	n     int
+	items keyedHeap /*[T]*/
+}
+
+// MakeWeightedReservoir returns a weighted reservoir sampler of some element
+// type that selects k values using the provided *rand.Rand.
+func MakeWeightedReservoir /*[T algo.Any]*/ (r *rand.Rand, k int) *WeightedReservoir /*[T]*/ {
+	return &WeightedReservoir{
+		r:     r,
+		k:     k,
+		items: make(keyedHeap, 0, k),
+	}
+}
+
+// Add adds value v with weight w to the stream of values to sample from. A
+// value with a weight of 0 (or smaller) is never selected.
+//
+// It runs in O(log k) time complexity.
+func (s *WeightedReservoir /*[T]*/) Add(v T, w float64) {
+	s.n++
+	if w <= 0 || s.k == 0 {
+		return
+	}
+
+	// each value gets a random key u^(1/w), and the k values with the
+	// largest keys are selected. The heap keeps the smallest selected key at
+	// the root so that it can be replaced efficiently.
+	key := math.Pow(1-s.r.Float64(), 1/w)
+	if len(s.items) < s.k {
+		heap.Push(&s.items, keyedValue{key: key, val: v})
+		return
+	}
+	if key > s.items[0].key {
+		s.items[0] = keyedValue{key: key, val: v}
+		heap.Fix(&s.items, 0)
+	}
+}
+
+// Len returns the number of values added to the stream so far.
+func (s *WeightedReservoir /*[T]*/) Len() int {
+	return s.n
+}
+
+// Values returns a new slice with the sampled values, in no particular
+// order. It contains fewer than k values if fewer than k values with a
+// positive weight were added.
+//
+// It runs in O(k) time and space complexity.
+func (s *WeightedReservoir /*[T]*/) Values() []T {
+	if len(s.items) == 0 {
+		return nil
+	}
+	vals := make([]T, len(s.items))
+	for i, item := range s.items {
+		vals[i] = item.val
+	}
+	return vals
+}
+
+type keyedValue /*[T algo.Any]*/ struct {
+	key float64
+	val T
+}
+
+// keyedHeap is a min-heap of keyed values, it implements heap.Interface.
+type keyedHeap /*[T algo.Any]*/ []keyedValue /*[T]*/
+
+func (h keyedHeap) Len() int            { return len(h) }
+func (h keyedHeap) Less(i, j int) bool  { return h[i].key < h[j].key }
+func (h keyedHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *keyedHeap) Push(x interface{}) { *h = append(*h, x.(keyedValue)) }
+func (h *keyedHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,73 @@
+package random
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func BenchmarkMakeAlias(b *testing.B) {
+	src := time.Now().UnixNano()
+	r := rand.New(rand.NewSource(src))
+	b.Logf("random seed: %d", src)
+
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			weights := randomWeights(r, n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				MakeAlias(r, weights)
+			}
+		})
+	}
+}
+
+func BenchmarkAlias_Draw(b *testing.B) {
+	src := time.Now().UnixNano()
+	r := rand.New(rand.NewSource(src))
+	b.Logf("random seed: %d", src)
+
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		// drawing is O(1) regardless of the number of weights
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			a := MakeAlias(r, randomWeights(r, n))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if ix := a.Draw(); ix < 0 || ix >= n {
+					b.Fatalf("invalid index %d", ix)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkWeightedReservoir(b *testing.B) {
+	src := time.Now().UnixNano()
+	r := rand.New(rand.NewSource(src))
+	b.Logf("random seed: %d", src)
+
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d;k=10", n), func(b *testing.B) {
+			weights := randomWeights(r, n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s := MakeWeightedReservoir(r, 10)
+				for j, w := range weights {
+					s.Add(j, w)
+				}
+			}
+		})
+	}
+}
+
+func randomWeights(r *rand.Rand, n int) []float64 {
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = r.Float64() + 0.1
+	}
+	return weights
+}
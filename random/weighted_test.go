@@ -0,0 +1,145 @@
+package random
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestAlias(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	cases := [][]float64{
+		{1},
+		{1, 1},
+		{1, 0, 1},
+		{1, 2, 3, 4},
+		{0.1, 0.2, 0.7},
+		{10, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c), func(t *testing.T) {
+			a := MakeAlias(r, c)
+			if a.Len() != len(c) {
+				t.Fatalf("want len %d, got %d", len(c), a.Len())
+			}
+
+			const draws = 100000
+			counts := make([]int, len(c))
+			for i := 0; i < draws; i++ {
+				counts[a.Draw()]++
+			}
+			checkWeighted(t, counts, c, draws)
+		})
+	}
+
+	t.Run("Deterministic", func(t *testing.T) {
+		w := []float64{1, 2, 3, 4}
+		a1, a2 := MakeAlias(rand.New(rand.NewSource(seed)), w), MakeAlias(rand.New(rand.NewSource(seed)), w)
+		for i := 0; i < 100; i++ {
+			if d1, d2 := a1.Draw(), a2.Draw(); d1 != d2 {
+				t.Fatalf("want same draws, got %d and %d", d1, d2)
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, w := range [][]float64{nil, {0}, {1, -1}} {
+			func() {
+				defer func() {
+					if e := recover(); e == nil {
+						t.Fatalf("%v: want panic", w)
+					}
+				}()
+				MakeAlias(r, w)
+			}()
+		}
+	})
+}
+
+func TestWeightedReservoir(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	t.Run("Sizes", func(t *testing.T) {
+		cases := []struct {
+			weights []float64
+			k       int
+			want    []int
+		}{
+			{nil, 1, nil},
+			{[]float64{1}, 0, nil},
+			{[]float64{1}, 1, []int{0}},
+			{[]float64{1, 0, 2}, 3, []int{0, 2}},
+			{[]float64{0, 0, 1}, 1, []int{2}},
+		}
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%d of %v", c.k, c.weights), func(t *testing.T) {
+				s := MakeWeightedReservoir(r, c.k)
+				for i, w := range c.weights {
+					s.Add(i, w)
+				}
+				if s.Len() != len(c.weights) {
+					t.Fatalf("want len %d, got %d", len(c.weights), s.Len())
+				}
+				got := s.Values()
+				if !cmp.Equal(c.want, got, cmpopts.SortSlices(func(a, b int) bool { return a < b })) {
+					t.Fatalf("want %v, got %v", c.want, got)
+				}
+			})
+		}
+	})
+
+	t.Run("Weighted", func(t *testing.T) {
+		// with k=1, the probability of each value is exactly proportional to its
+		// weight.
+		weights := []float64{1, 2, 3, 4}
+		const draws = 100000
+		counts := make([]int, len(weights))
+		for i := 0; i < draws; i++ {
+			s := MakeWeightedReservoir(r, 1)
+			for j, w := range weights {
+				s.Add(j, w)
+			}
+			counts[s.Values()[0]]++
+		}
+		checkWeighted(t, counts, weights, draws)
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		s1 := MakeWeightedReservoir(rand.New(rand.NewSource(seed)), 5)
+		s2 := MakeWeightedReservoir(rand.New(rand.NewSource(seed)), 5)
+		for i := 0; i < 100; i++ {
+			s1.Add(i, float64(i%7))
+			s2.Add(i, float64(i%7))
+		}
+		if !cmp.Equal(s1.Values(), s2.Values()) {
+			t.Fatalf("want same samples, got %v and %v", s1.Values(), s2.Values())
+		}
+	})
+}
+
+// checks that each count is within 1% (of the total) of the expected
+// proportion.
+func checkWeighted(t *testing.T, counts []int, weights []float64, total int) {
+	t.Helper()
+
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	for i, c := range counts {
+		want := weights[i] / sum
+		got := float64(c) / float64(total)
+		if got < want-0.01 || got > want+0.01 {
+			t.Fatalf("index %d: want proportion close to %.3f, got %.3f (%v)", i, want, got, counts)
+		}
+	}
+}
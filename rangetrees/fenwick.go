@@ -0,0 +1,121 @@
+package rangetrees
+
+import "math/bits"
+
+// Fenwick is a Fenwick tree (or binary indexed tree), an array of numbers
+// that supports updating a value and computing the sum of a range of values
+// in O(log n) time complexity. It uses the same memory as the array itself.
+// Its zero-value is an empty tree of length 0.
+type Fenwick /*[T algo.Number]*/ struct {
+	// tree[i-1] is the sum of the values in (i - i&-i, i], i.e. i is a 1-based
+	// index into the implicit tree.
+	tree []T
+}
+
+// MakeFenwick returns a Fenwick tree of n values, all zero. It panics if n
+// is negative.
+func MakeFenwick /*[T algo.Number]*/ (n int) *Fenwick /*[T]*/ {
+	if n < 0 {
+		panic("rangetrees: negative length")
+	}
+	return &Fenwick /*[T]*/ {tree: make([]T, n)}
+}
+
+// MakeFenwickFrom returns a Fenwick tree initialized with the values vals.
+// The slice is not retained by the tree.
+//
+// It runs in O(n) time complexity.
+func MakeFenwickFrom /*[T algo.Number]*/ (vals []T) *Fenwick /*[T]*/ {
+	tree := make([]T, len(vals))
+	copy(tree, vals)
+	for i := 1; i <= len(tree); i++ {
+		// add each node to its parent, which covers a range that includes it
+		if j := i + i&-i; j <= len(tree) {
+			tree[j-1] += tree[i-1]
+		}
+	}
+	return &Fenwick /*[T]*/ {tree: tree}
+}
+
+// Len returns the number of values in the Fenwick tree f.
+func (f *Fenwick /*[T]*/) Len() int {
+	return len(f.tree)
+}
+
+// Add adds delta to the value at index i. It panics if i is out of range.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (f *Fenwick /*[T]*/) Add(i int, delta T) {
+	checkIndex(i, len(f.tree))
+	for i++; i <= len(f.tree); i += i & -i {
+		f.tree[i-1] += delta
+	}
+}
+
+// Set sets the value at index i to v. It panics if i is out of range.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (f *Fenwick /*[T]*/) Set(i int, v T) {
+	f.Add(i, v-f.At(i))
+}
+
+// At returns the value at index i. It panics if i is out of range.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (f *Fenwick /*[T]*/) At(i int) T {
+	checkIndex(i, len(f.tree))
+	return f.RangeSum(i, i+1)
+}
+
+// PrefixSum returns the sum of the values in [0, i), i.e. of the first i
+// values. It panics if i is not in [0, Len()].
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (f *Fenwick /*[T]*/) PrefixSum(i int) T {
+	checkRange(0, i, len(f.tree))
+	var sum T
+	for ; i > 0; i -= i & -i {
+		sum += f.tree[i-1]
+	}
+	return sum
+}
+
+// RangeSum returns the sum of the values in [i, j). It panics if the range
+// is out of bounds or if i > j.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (f *Fenwick /*[T]*/) RangeSum(i, j int) T {
+	checkRange(i, j, len(f.tree))
+
+	// only sum the nodes that are not common to both prefixes
+	var sum T
+	for ; j > i; j -= j & -j {
+		sum += f.tree[j-1]
+	}
+	for ; i > j; i -= i & -i {
+		sum -= f.tree[i-1]
+	}
+	return sum
+}
+
+// Search returns the smallest index i such that the sum of the values in
+// [0, i] is greater than or equal to sum, or Len() if there is no such index.
+// The values must all be non-negative, so that the prefix sums are sorted.
+// This can be used e.g. to find the position of a weighted random sample or
+// of the k-th element of a frequency table.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (f *Fenwick /*[T]*/) Search(sum T) int {
+	if len(f.tree) == 0 {
+		return 0
+	}
+
+	var i int
+	for step := 1 << (bits.Len(uint(len(f.tree))) - 1); step > 0; step >>= 1 {
+		if j := i + step; j <= len(f.tree) && f.tree[j-1] < sum {
+			i = j
+			sum -= f.tree[j-1]
+		}
+	}
+	return i
+}
@@ -0,0 +1,94 @@
+package rangetrees
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// returns n random values in [0, 100).
+func benchValues(n int) []T {
+	r := rand.New(rand.NewSource(1))
+	vals := make([]T, n)
+	for i := range vals {
+		vals[i] = r.Intn(100)
+	}
+	return vals
+}
+
+func BenchmarkMakeFenwickFrom(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := benchValues(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = MakeFenwickFrom(vals)
+			}
+		})
+	}
+}
+
+func BenchmarkFenwick_Add(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			f := MakeFenwickFrom(benchValues(n))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				f.Add(i%n, 1)
+			}
+		})
+	}
+}
+
+func BenchmarkFenwick_RangeSum(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			f := MakeFenwickFrom(benchValues(n))
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				lo := r.Intn(n)
+				_ = f.RangeSum(lo, lo+r.Intn(n-lo+1))
+			}
+		})
+	}
+}
+
+func BenchmarkFenwick_Search(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			f := MakeFenwickFrom(benchValues(n))
+			total := f.PrefixSum(n)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = f.Search(r.Intn(total + 1))
+			}
+		})
+	}
+}
+
+// for comparison with the sum of a range computed by iterating over a
+// slice.
+func BenchmarkSlice_RangeSum(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := benchValues(n)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				lo := r.Intn(n)
+				var sum T
+				for _, v := range vals[lo : lo+r.Intn(n-lo+1)] {
+					sum += v
+				}
+				_ = sum
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package rangetrees
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestFenwick(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var f Fenwick
+		if f.Len() != 0 || f.PrefixSum(0) != 0 || f.RangeSum(0, 0) != 0 {
+			t.Fatal("want empty tree")
+		}
+		if i := f.Search(1); i != 0 {
+			t.Fatalf("want search 0, got %d", i)
+		}
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		f := MakeFenwickFrom([]T{3, 0, 2, 5, 1})
+		checkFenwick(t, f, []T{3, 0, 2, 5, 1})
+		f.Add(1, 4)
+		checkFenwick(t, f, []T{3, 4, 2, 5, 1})
+		f.Set(4, 10)
+		checkFenwick(t, f, []T{3, 4, 2, 5, 10})
+		if s := f.RangeSum(1, 4); s != 11 {
+			t.Fatalf("want range sum 11, got %d", s)
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		for _, n := range []int{1, 2, 3, 7, 8, 9, 100, 1000} {
+			ref := make([]T, n)
+			for i := range ref {
+				ref[i] = r.Intn(100)
+			}
+			f := MakeFenwickFrom(ref)
+			if r.Intn(2) == 0 {
+				// build the same tree with point updates
+				f = MakeFenwick(n)
+				for i, v := range ref {
+					f.Add(i, v)
+				}
+			}
+			checkFenwick(t, f, ref)
+
+			for i := 0; i < 100; i++ {
+				j, v := r.Intn(n), r.Intn(100)
+				if r.Intn(2) == 0 {
+					f.Set(j, v)
+					ref[j] = v
+				} else {
+					f.Add(j, v)
+					ref[j] += v
+				}
+			}
+			checkFenwick(t, f, ref)
+		}
+	})
+}
+
+func TestFenwickSearch(t *testing.T) {
+	f := MakeFenwickFrom([]T{2, 0, 3, 1, 0, 4})
+	cases := []struct {
+		sum  T
+		want int
+	}{
+		{-1, 0},
+		{0, 0},
+		{1, 0},
+		{2, 0},
+		{3, 2},
+		{5, 2},
+		{6, 3},
+		{7, 5},
+		{10, 5},
+		{11, 6},
+		{100, 6},
+	}
+	for _, c := range cases {
+		if got := f.Search(c.sum); got != c.want {
+			t.Fatalf("%d: want index %d, got %d", c.sum, c.want, got)
+		}
+	}
+}
+
+func TestFenwickPanics(t *testing.T) {
+	f := MakeFenwickFrom([]T{1, 2, 3})
+	cases := []struct {
+		desc string
+		fn   func()
+	}{
+		{"Make negative", func() { MakeFenwick(-1) }},
+		{"At negative", func() { f.At(-1) }},
+		{"At too big", func() { f.At(3) }},
+		{"Add too big", func() { f.Add(3, 1) }},
+		{"Set too big", func() { f.Set(3, 1) }},
+		{"PrefixSum negative", func() { f.PrefixSum(-1) }},
+		{"PrefixSum too big", func() { f.PrefixSum(4) }},
+		{"RangeSum too big", func() { f.RangeSum(0, 4) }},
+		{"RangeSum inverted", func() { f.RangeSum(2, 1) }},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("want panic")
+				}
+			}()
+			c.fn()
+		})
+	}
+}
+
+// checkFenwick checks that f contains the values in want, and that all its
+// prefix and range sums and searches are consistent with those values.
+func checkFenwick(t *testing.T, f *Fenwick, want []T) {
+	t.Helper()
+
+	if f.Len() != len(want) {
+		t.Fatalf("want len %d, got %d", len(want), f.Len())
+	}
+	prefix := make([]T, len(want)+1)
+	for i, v := range want {
+		if got := f.At(i); got != v {
+			t.Fatalf("%d: want value %d, got %d", i, v, got)
+		}
+		prefix[i+1] = prefix[i] + v
+	}
+	for i := 0; i <= len(want); i++ {
+		if got := f.PrefixSum(i); got != prefix[i] {
+			t.Fatalf("%d: want prefix sum %d, got %d", i, prefix[i], got)
+		}
+		for j := i; j <= len(want) && j < i+10; j++ {
+			if got := f.RangeSum(i, j); got != prefix[j]-prefix[i] {
+				t.Fatalf("[%d, %d): want range sum %d, got %d", i, j, prefix[j]-prefix[i], got)
+			}
+		}
+	}
+	for i := 1; i <= len(want); i++ {
+		// the first index where the prefix sum reaches prefix[i]
+		wantIdx := i - 1
+		for wantIdx > 0 && prefix[wantIdx] >= prefix[i] {
+			wantIdx--
+		}
+		if got := f.Search(prefix[i]); got != wantIdx {
+			t.Fatalf("%d: want search index %d, got %d", prefix[i], wantIdx, got)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package rangetrees
+
+type (
+	T = int // NOTE: generic type placeholder
+	U = int // NOTE: generic type placeholder
+)
+
+// panics if i is not a valid index for an array of length n.
+func checkIndex(i, n int) {
+	if i < 0 || i >= n {
+		panic("rangetrees: index out of range")
+	}
+}
+
+// panics if [i, j) is not a valid range for an array of length n.
+func checkRange(i, j, n int) {
+	if i < 0 || j > n || i > j {
+		panic("rangetrees: range out of bounds")
+	}
+}
@@ -0,0 +1,279 @@
+package rangetrees
+
+// Segment is a segment tree, an array of values that supports updating a
+// value and combining the values of a range in O(log n) time complexity.
+// The values are combined with an associative function, e.g. addition,
+// minimum or maximum, that is not required to be commutative. It uses twice
+// the memory of the array itself. Its zero-value is not ready to use, it
+// must be created with MakeSegment or MakeSegmentFrom.
+type Segment /*[T algo.Any]*/ struct {
+	// the values are stored in the leaves tree[n:], and tree[i] for i in
+	// [1, n) combines tree[2*i] and tree[2*i+1].
+	tree     []T
+	identity T
+	combine  func(a, b T) T
+}
+
+// MakeSegment returns a segment tree of n values, all set to identity. The
+// combine function must be associative and identity must be its identity
+// element, i.e. combine(identity, v) == combine(v, identity) == v for all v.
+// It panics if n is negative.
+func MakeSegment /*[T algo.Any]*/ (n int, identity T, combine func(a, b T) T) *Segment /*[T]*/ {
+	if n < 0 {
+		panic("rangetrees: negative length")
+	}
+	tree := make([]T, 2*n)
+	for i := range tree {
+		tree[i] = identity
+	}
+	return &Segment /*[T]*/ {tree: tree, identity: identity, combine: combine}
+}
+
+// MakeSegmentFrom returns a segment tree initialized with the values vals.
+// The combine function must be associative and identity must be its
+// identity element. The slice is not retained by the tree.
+//
+// It runs in O(n) time complexity.
+func MakeSegmentFrom /*[T algo.Any]*/ (vals []T, identity T, combine func(a, b T) T) *Segment /*[T]*/ {
+	n := len(vals)
+	tree := make([]T, 2*n)
+	copy(tree[n:], vals)
+	for i := n - 1; i > 0; i-- {
+		tree[i] = combine(tree[2*i], tree[2*i+1])
+	}
+	return &Segment /*[T]*/ {tree: tree, identity: identity, combine: combine}
+}
+
+// Len returns the number of values in the segment tree s.
+func (s *Segment /*[T]*/) Len() int {
+	return len(s.tree) / 2
+}
+
+// At returns the value at index i. It panics if i is out of range.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (s *Segment /*[T]*/) At(i int) T {
+	n := len(s.tree) / 2
+	checkIndex(i, n)
+	return s.tree[n+i]
+}
+
+// Set sets the value at index i to v. It panics if i is out of range.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (s *Segment /*[T]*/) Set(i int, v T) {
+	n := len(s.tree) / 2
+	checkIndex(i, n)
+	i += n
+	s.tree[i] = v
+	for i > 1 {
+		i /= 2
+		s.tree[i] = s.combine(s.tree[2*i], s.tree[2*i+1])
+	}
+}
+
+// Query returns the combination of the values in [i, j), in order, or the
+// identity element if the range is empty. It panics if the range is out of
+// bounds or if i > j.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (s *Segment /*[T]*/) Query(i, j int) T {
+	n := len(s.tree) / 2
+	checkRange(i, j, n)
+
+	// combine the nodes from the left and from the right separately, so that
+	// the order of the values is preserved.
+	left, right := s.identity, s.identity
+	for i, j = i+n, j+n; i < j; i, j = i/2, j/2 {
+		if i&1 == 1 {
+			left = s.combine(left, s.tree[i])
+			i++
+		}
+		if j&1 == 1 {
+			j--
+			right = s.combine(s.tree[j], right)
+		}
+	}
+	return s.combine(left, right)
+}
+
+// LazySegment is a segment tree that supports updating all values of a
+// range in O(log n) time complexity, in addition to combining the values of
+// a range. The updates are applied lazily: an update of a range is recorded
+// on the nodes that cover it, and pushed down to their children only when a
+// later operation needs to visit them. It uses about four times the memory
+// of the array itself. Its zero-value is not ready to use, it must be
+// created with MakeLazySegmentFrom.
+type LazySegment /*[T, U algo.Any]*/ struct {
+	n        int
+	tree     []T    // 1-based, the children of node i are 2*i and 2*i+1
+	lazy     []U    // update of node i not yet applied to its children
+	pending  []bool // lazy[i] is set
+	identity T
+	combine  func(a, b T) T
+	apply    func(v T, u U, n int) T
+	compose  func(u1, u2 U) U
+}
+
+// MakeLazySegmentFrom returns a segment tree with range updates initialized
+// with the values vals. The slice is not retained by the tree.
+//
+// The combine function must be associative and identity must be its
+// identity element. The apply function returns the combination v of n
+// consecutive values after the update u has been applied to each of those
+// values, e.g. v+u*n for a sum where the update adds u to each value. The
+// compose function returns the update that is equivalent to applying u1 and
+// then u2, e.g. u1+u2 for additions or u2 for assignments.
+//
+// It runs in O(n) time complexity.
+func MakeLazySegmentFrom /*[T, U algo.Any]*/ (vals []T, identity T, combine func(a, b T) T, apply func(v T, u U, n int) T, compose func(u1, u2 U) U) *LazySegment /*[T, U]*/ {
+	size := 1
+	for size < len(vals) {
+		size *= 2
+	}
+	s := &LazySegment /*[T, U]*/ {
+		n:        len(vals),
+		tree:     make([]T, 2*size),
+		lazy:     make([]U, 2*size),
+		pending:  make([]bool, 2*size),
+		identity: identity,
+		combine:  combine,
+		apply:    apply,
+		compose:  compose,
+	}
+	if len(vals) > 0 {
+		s.build(1, 0, len(vals), vals)
+	}
+	return s
+}
+
+func (s *LazySegment /*[T, U]*/) build(node, lo, hi int, vals []T) {
+	if hi-lo == 1 {
+		s.tree[node] = vals[lo]
+		return
+	}
+	mid := lo + (hi-lo)/2
+	s.build(2*node, lo, mid, vals)
+	s.build(2*node+1, mid, hi, vals)
+	s.tree[node] = s.combine(s.tree[2*node], s.tree[2*node+1])
+}
+
+// Len returns the number of values in the segment tree s.
+func (s *LazySegment /*[T, U]*/) Len() int {
+	return s.n
+}
+
+// At returns the value at index i. It panics if i is out of range.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (s *LazySegment /*[T, U]*/) At(i int) T {
+	checkIndex(i, s.n)
+	return s.query(1, 0, s.n, i, i+1)
+}
+
+// Set sets the value at index i to v. It panics if i is out of range.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (s *LazySegment /*[T, U]*/) Set(i int, v T) {
+	checkIndex(i, s.n)
+	s.set(1, 0, s.n, i, v)
+}
+
+// Query returns the combination of the values in [i, j), in order, or the
+// identity element if the range is empty. It panics if the range is out of
+// bounds or if i > j. As it may push pending updates down the tree, it
+// modifies the tree and is not safe for concurrent use.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (s *LazySegment /*[T, U]*/) Query(i, j int) T {
+	checkRange(i, j, s.n)
+	if i == j {
+		return s.identity
+	}
+	return s.query(1, 0, s.n, i, j)
+}
+
+// Update applies the update u to each value in [i, j). It panics if the
+// range is out of bounds or if i > j.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (s *LazySegment /*[T, U]*/) Update(i, j int, u U) {
+	checkRange(i, j, s.n)
+	if i == j {
+		return
+	}
+	s.update(1, 0, s.n, i, j, u)
+}
+
+// applies u to the node that covers n values.
+func (s *LazySegment /*[T, U]*/) applyNode(node, n int, u U) {
+	s.tree[node] = s.apply(s.tree[node], u, n)
+	if n > 1 {
+		if s.pending[node] {
+			s.lazy[node] = s.compose(s.lazy[node], u)
+		} else {
+			s.lazy[node] = u
+			s.pending[node] = true
+		}
+	}
+}
+
+// pushes the pending update of the node that covers [lo, hi) to its
+// children.
+func (s *LazySegment /*[T, U]*/) push(node, lo, hi int) {
+	if !s.pending[node] {
+		return
+	}
+	mid := lo + (hi-lo)/2
+	s.applyNode(2*node, mid-lo, s.lazy[node])
+	s.applyNode(2*node+1, hi-mid, s.lazy[node])
+
+	var zero U
+	s.lazy[node], s.pending[node] = zero, false
+}
+
+func (s *LazySegment /*[T, U]*/) query(node, lo, hi, i, j int) T {
+	if i <= lo && hi <= j {
+		return s.tree[node]
+	}
+	s.push(node, lo, hi)
+	mid := lo + (hi-lo)/2
+	switch {
+	case j <= mid:
+		return s.query(2*node, lo, mid, i, j)
+	case i >= mid:
+		return s.query(2*node+1, mid, hi, i, j)
+	}
+	return s.combine(s.query(2*node, lo, mid, i, j), s.query(2*node+1, mid, hi, i, j))
+}
+
+func (s *LazySegment /*[T, U]*/) update(node, lo, hi, i, j int, u U) {
+	if i <= lo && hi <= j {
+		s.applyNode(node, hi-lo, u)
+		return
+	}
+	s.push(node, lo, hi)
+	mid := lo + (hi-lo)/2
+	if i < mid {
+		s.update(2*node, lo, mid, i, j, u)
+	}
+	if j > mid {
+		s.update(2*node+1, mid, hi, i, j, u)
+	}
+	s.tree[node] = s.combine(s.tree[2*node], s.tree[2*node+1])
+}
+
+func (s *LazySegment /*[T, U]*/) set(node, lo, hi, i int, v T) {
+	if hi-lo == 1 {
+		s.tree[node] = v
+		return
+	}
+	s.push(node, lo, hi)
+	mid := lo + (hi-lo)/2
+	if i < mid {
+		s.set(2*node, lo, mid, i, v)
+	} else {
+		s.set(2*node+1, mid, hi, i, v)
+	}
+	s.tree[node] = s.combine(s.tree[2*node], s.tree[2*node+1])
+}
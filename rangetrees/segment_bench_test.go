@@ -0,0 +1,104 @@
+package rangetrees
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func BenchmarkMakeSegmentFrom(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := benchValues(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = MakeSegmentFrom(vals, maxInt, minimum)
+			}
+		})
+	}
+}
+
+func BenchmarkSegment_Set(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeSegmentFrom(benchValues(n), maxInt, minimum)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Set(i%n, i)
+			}
+		})
+	}
+}
+
+func BenchmarkSegment_Query(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeSegmentFrom(benchValues(n), maxInt, minimum)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				lo := r.Intn(n)
+				_ = s.Query(lo, lo+r.Intn(n-lo+1))
+			}
+		})
+	}
+}
+
+func BenchmarkLazySegment_Update(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeLazySegmentFrom(benchValues(n), 0, sum, applyAdd, composeAdd)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				lo := r.Intn(n)
+				s.Update(lo, lo+r.Intn(n-lo+1), 1)
+			}
+		})
+	}
+}
+
+func BenchmarkLazySegment_Query(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeLazySegmentFrom(benchValues(n), 0, sum, applyAdd, composeAdd)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				lo := r.Intn(n)
+				hi := lo + r.Intn(n-lo+1)
+				if i%2 == 0 {
+					s.Update(lo, hi, 1)
+				} else {
+					_ = s.Query(lo, hi)
+				}
+			}
+		})
+	}
+}
+
+// for comparison with the minimum of a range computed by iterating over a
+// slice.
+func BenchmarkSlice_RangeMin(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := benchValues(n)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				lo := r.Intn(n)
+				m := maxInt
+				for _, v := range vals[lo : lo+r.Intn(n-lo+1)] {
+					m = minimum(m, v)
+				}
+				_ = m
+			}
+		})
+	}
+}
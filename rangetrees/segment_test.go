@@ -0,0 +1,224 @@
+package rangetrees
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// maxInt is the largest value of an int, the identity of minimum.
+const maxInt = int(^uint(0) >> 1)
+
+func sum(a, b T) T { return a + b }
+
+func minimum(a, b T) T {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// returns a if it is set, b otherwise, an associative but non-commutative
+// function with identity -1.
+func first(a, b T) T {
+	if a != -1 {
+		return a
+	}
+	return b
+}
+
+// apply and compose functions for updates that add a value.
+func applyAdd(v T, u U, n int) T { return v + u*n }
+func composeAdd(u1, u2 U) U      { return u1 + u2 }
+
+// apply and compose functions for updates that assign a value, for the
+// minimum of a range.
+func applyAssign(v T, u U, n int) T { return u }
+func composeAssign(u1, u2 U) U      { return u2 }
+
+func TestSegment(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		s := MakeSegmentFrom(nil, 0, sum)
+		if s.Len() != 0 || s.Query(0, 0) != 0 {
+			t.Fatal("want empty tree")
+		}
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		s := MakeSegment(5, -1, first)
+		checkSegment(t, s.Len, s.Query, -1, first, []T{-1, -1, -1, -1, -1})
+		s.Set(3, 30)
+		s.Set(1, 10)
+		checkSegment(t, s.Len, s.Query, -1, first, []T{-1, 10, -1, 30, -1})
+		if v := s.At(3); v != 30 {
+			t.Fatalf("want value 30, got %d", v)
+		}
+		if v := s.Query(2, 5); v != 30 {
+			t.Fatalf("want first value 30, got %d", v)
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		fns := []struct {
+			identity T
+			combine  func(a, b T) T
+		}{
+			{0, sum},
+			{maxInt, minimum},
+			{-1, first},
+		}
+		for _, fn := range fns {
+			for _, n := range []int{1, 2, 3, 7, 8, 9, 100} {
+				ref := make([]T, n)
+				for i := range ref {
+					ref[i] = r.Intn(100) - 1
+				}
+				s := MakeSegmentFrom(ref, fn.identity, fn.combine)
+				checkSegment(t, s.Len, s.Query, fn.identity, fn.combine, ref)
+
+				for i := 0; i < 100; i++ {
+					j, v := r.Intn(n), r.Intn(100)-1
+					s.Set(j, v)
+					ref[j] = v
+				}
+				checkSegment(t, s.Len, s.Query, fn.identity, fn.combine, ref)
+			}
+		}
+	})
+}
+
+func TestLazySegment(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		s := MakeLazySegmentFrom(nil, 0, sum, applyAdd, composeAdd)
+		s.Update(0, 0, 1)
+		if s.Len() != 0 || s.Query(0, 0) != 0 {
+			t.Fatal("want empty tree")
+		}
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		s := MakeLazySegmentFrom([]T{1, 2, 3, 4, 5}, 0, sum, applyAdd, composeAdd)
+		s.Update(1, 4, 10)
+		checkSegment(t, s.Len, s.Query, 0, sum, []T{1, 12, 13, 14, 5})
+		s.Update(0, 2, -1)
+		s.Set(3, 0)
+		checkSegment(t, s.Len, s.Query, 0, sum, []T{0, 11, 13, 0, 5})
+		if v := s.At(1); v != 11 {
+			t.Fatalf("want value 11, got %d", v)
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		fns := []struct {
+			identity T
+			combine  func(a, b T) T
+			apply    func(v T, u U, n int) T
+			compose  func(u1, u2 U) U
+			update   func(v T, u U) T
+		}{
+			{0, sum, applyAdd, composeAdd, func(v T, u U) T { return v + u }},
+			{maxInt, minimum, applyAssign, composeAssign, func(v T, u U) T { return u }},
+		}
+		for _, fn := range fns {
+			for _, n := range []int{1, 2, 3, 7, 8, 9, 100} {
+				ref := make([]T, n)
+				for i := range ref {
+					ref[i] = r.Intn(100)
+				}
+				s := MakeLazySegmentFrom(ref, fn.identity, fn.combine, fn.apply, fn.compose)
+				checkSegment(t, s.Len, s.Query, fn.identity, fn.combine, ref)
+
+				for i := 0; i < 100; i++ {
+					lo := r.Intn(n)
+					hi := lo + r.Intn(n-lo+1)
+					v := r.Intn(100)
+					switch r.Intn(3) {
+					case 0:
+						s.Set(lo, v)
+						ref[lo] = v
+					case 1:
+						want := fn.identity
+						for _, rv := range ref[lo:hi] {
+							want = fn.combine(want, rv)
+						}
+						if got := s.Query(lo, hi); got != want {
+							t.Fatalf("[%d, %d): want %d, got %d", lo, hi, want, got)
+						}
+					default:
+						s.Update(lo, hi, v)
+						for j := lo; j < hi; j++ {
+							ref[j] = fn.update(ref[j], v)
+						}
+					}
+				}
+				checkSegment(t, s.Len, s.Query, fn.identity, fn.combine, ref)
+				for i, v := range ref {
+					if got := s.At(i); got != v {
+						t.Fatalf("%d: want value %d, got %d", i, v, got)
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestSegmentPanics(t *testing.T) {
+	s := MakeSegmentFrom([]T{1, 2, 3}, 0, sum)
+	ls := MakeLazySegmentFrom([]T{1, 2, 3}, 0, sum, applyAdd, composeAdd)
+	cases := []struct {
+		desc string
+		fn   func()
+	}{
+		{"Make negative", func() { MakeSegment(-1, 0, sum) }},
+		{"At negative", func() { s.At(-1) }},
+		{"At too big", func() { s.At(3) }},
+		{"Set too big", func() { s.Set(3, 0) }},
+		{"Query too big", func() { s.Query(0, 4) }},
+		{"Query inverted", func() { s.Query(2, 1) }},
+		{"Lazy At too big", func() { ls.At(3) }},
+		{"Lazy Set negative", func() { ls.Set(-1, 0) }},
+		{"Lazy Query too big", func() { ls.Query(1, 4) }},
+		{"Lazy Update too big", func() { ls.Update(0, 4, 1) }},
+		{"Lazy Update inverted", func() { ls.Update(2, 1, 1) }},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("want panic")
+				}
+			}()
+			c.fn()
+		})
+	}
+}
+
+// checkSegment checks that the segment tree with the len and query functions
+// contains the values in want, by comparing the result of all its range
+// queries with the values combined in order.
+func checkSegment(t *testing.T, lenFn func() int, query func(i, j int) T, identity T, combine func(a, b T) T, want []T) {
+	t.Helper()
+
+	if n := lenFn(); n != len(want) {
+		t.Fatalf("want len %d, got %d", len(want), n)
+	}
+	for i := 0; i <= len(want); i++ {
+		v := identity
+		for j := i; j <= len(want); j++ {
+			if got := query(i, j); got != v {
+				t.Fatalf("[%d, %d): want %d, got %d", i, j, v, got)
+			}
+			if j < len(want) {
+				v = combine(v, want[j])
+			}
+		}
+	}
+}
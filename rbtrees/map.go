@@ -0,0 +1,610 @@
+package rbtrees
+
+type (
+	K = int // NOTE: generic type placeholder
+	V = int // NOTE: generic type placeholder
+)
+
+// Map is an ordered map of keys to values, backed by a balanced binary
+// search tree (a left-leaning red-black tree). Its keys are kept in
+// ascending order, as defined by the standard <, <=, >, >= operators or by
+// the comparison function provided to MakeFunc, so that they can be
+// iterated in order and queried by range. Each node also keeps the size of
+// its subtree, so that keys can be queried by rank. Its zero-value is ready
+// to use, with the standard ordering of keys.
+//
+// See "Left-leaning Red-Black Trees", by Robert Sedgewick.
+type Map /*[K algo.Ordered, V algo.Any]*/ struct {
+	root *node /*[K, V]*/
+	cmp  func(K, K) int
+}
+
+type node /*[K algo.Ordered, V algo.Any]*/ struct {
+	key         K
+	val         V
+	left, right *node /*[K, V]*/
+	size        int   // number of nodes in the subtree rooted at this node
+	red         bool  // color of the link from the parent
+}
+
+// Make returns an ordered map of some key and value types, using the
+// standard ordering of keys.
+func Make /*[K algo.Ordered, V algo.Any]*/ () *Map /*[K, V]*/ {
+	return new(Map /*[K, V]*/)
+}
+
+// MakeFunc returns an ordered map of some key and value types, using cmp to
+// order the keys. It should return -1 if the first key is smaller, 1 if it
+// is larger, and 0 if they are equal (the keys are then the same key of the
+// map). It panics if cmp is nil.
+func MakeFunc /*[K algo.Ordered, V algo.Any]*/ (cmp func(K, K) int) *Map /*[K, V]*/ {
+	if cmp == nil {
+		panic("rbtrees: nil comparison function")
+	}
+	return &Map /*[K, V]*/ {cmp: cmp}
+}
+
+// compare returns the ordering of k1 and k2, using m.cmp if set.
+func (m *Map /*[K, V]*/) compare(k1, k2 K) int {
+	if m.cmp != nil {
+		return m.cmp(k1, k2)
+	}
+	switch {
+	case k1 < k2:
+		return -1
+	case k1 > k2:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Len returns the number of keys in the map m.
+func (m *Map /*[K, V]*/) Len() int {
+	return m.root.len()
+}
+
+// Get returns the value associated with k and true, or the zero value of V
+// and false if k is not in the map.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Get(k K) (V, bool) {
+	if n := m.find(k); n != nil {
+		return n.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether k is in the map m.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Contains(k K) bool {
+	return m.find(k) != nil
+}
+
+func (m *Map /*[K, V]*/) find(k K) *node /*[K, V]*/ {
+	n := m.root
+	for n != nil {
+		c := m.compare(k, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// Put associates the value v with the key k in the map m, replacing the
+// previous value if k is already in m.
+//
+// It runs in O(log n) time complexity.
+func (m *Map /*[K, V]*/) Put(k K, v V) {
+	m.root = m.put(m.root, k, v)
+	m.root.red = false
+}
+
+func (m *Map /*[K, V]*/) put(n *node /*[K, V]*/, k K, v V) *node /*[K, V]*/ {
+	if n == nil {
+		return &node /*[K, V]*/ {key: k, val: v, size: 1, red: true}
+	}
+
+	c := m.compare(k, n.key)
+	switch {
+	case c < 0:
+		n.left = m.put(n.left, k, v)
+	case c > 0:
+		n.right = m.put(n.right, k, v)
+	default:
+		n.val = v
+	}
+	return n.fixUp()
+}
+
+// Delete removes the key k and its associated value from the map m and
+// returns true, or false if k is not in m.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Delete(k K) bool {
+	if !m.Contains(k) {
+		return false
+	}
+
+	// the deletion moves a red link down the search path, so that the
+	// deleted node is never a 2-node (a black node with no red child).
+	if !m.root.left.isRed() && !m.root.right.isRed() {
+		m.root.red = true
+	}
+	m.root = m.delete(m.root, k)
+	if m.root != nil {
+		m.root.red = false
+	}
+	return true
+}
+
+// deletes k, which must be in the subtree rooted at n, and returns the new
+// root of the subtree.
+func (m *Map /*[K, V]*/) delete(n *node /*[K, V]*/, k K) *node /*[K, V]*/ {
+	if m.compare(k, n.key) < 0 {
+		if !n.left.isRed() && !n.left.left.isRed() {
+			n = n.moveRedLeft()
+		}
+		n.left = m.delete(n.left, k)
+		return n.fixUp()
+	}
+
+	if n.left.isRed() {
+		n = n.rotateRight()
+	}
+	if m.compare(k, n.key) == 0 && n.right == nil {
+		return nil
+	}
+	if !n.right.isRed() && !n.right.left.isRed() {
+		n = n.moveRedRight()
+	}
+	if m.compare(k, n.key) == 0 {
+		// replace the node with its successor, the smallest key of the right
+		// subtree, and remove that successor.
+		succ := n.right.min()
+		n.key, n.val = succ.key, succ.val
+		n.right = n.right.deleteMin()
+	} else {
+		n.right = m.delete(n.right, k)
+	}
+	return n.fixUp()
+}
+
+// Min returns the smallest key of the map and its value, and true, or the
+// zero values and false if the map is empty.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Min() (K, V, bool) {
+	if m.root == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	n := m.root.min()
+	return n.key, n.val, true
+}
+
+// Max returns the largest key of the map and its value, and true, or the
+// zero values and false if the map is empty.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Max() (K, V, bool) {
+	if m.root == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	n := m.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.val, true
+}
+
+// Floor returns the largest key of the map that is smaller than or equal to
+// k and its value, and true, or the zero values and false if there is no
+// such key.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Floor(k K) (K, V, bool) {
+	var floor *node /*[K, V]*/
+	for n := m.root; n != nil; {
+		c := m.compare(k, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			// this is a candidate, but there may be a larger one on the right
+			floor = n
+			n = n.right
+		default:
+			return n.key, n.val, true
+		}
+	}
+	return floor.entry()
+}
+
+// Ceiling returns the smallest key of the map that is larger than or equal
+// to k and its value, and true, or the zero values and false if there is no
+// such key.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Ceiling(k K) (K, V, bool) {
+	var ceil *node /*[K, V]*/
+	for n := m.root; n != nil; {
+		c := m.compare(k, n.key)
+		switch {
+		case c > 0:
+			n = n.right
+		case c < 0:
+			// this is a candidate, but there may be a smaller one on the left
+			ceil = n
+			n = n.left
+		default:
+			return n.key, n.val, true
+		}
+	}
+	return ceil.entry()
+}
+
+// Rank returns the number of keys of the map that are smaller than k, which
+// is the index k would have in the sorted keys of the map.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Rank(k K) int {
+	var rank int
+	for n := m.root; n != nil; {
+		c := m.compare(k, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			rank += n.left.len() + 1
+			n = n.right
+		default:
+			return rank + n.left.len()
+		}
+	}
+	return rank
+}
+
+// Select returns the key of the map with rank i, that is the i-th smallest
+// key (0-based), and its value, and true, or the zero values and false if i
+// is not in the range [0, m.Len()). It is the reverse of Rank.
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Select(i int) (K, V, bool) {
+	if i < 0 || i >= m.Len() {
+		var k K
+		var v V
+		return k, v, false
+	}
+	n := m.root
+	for {
+		switch l := n.left.len(); {
+		case i < l:
+			n = n.left
+		case i > l:
+			i -= l + 1
+			n = n.right
+		default:
+			return n.key, n.val, true
+		}
+	}
+}
+
+// RangeLen returns the number of keys of the map that are in the half-open
+// range [lo, hi).
+//
+// It runs in O(log n) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) RangeLen(lo, hi K) int {
+	if m.compare(lo, hi) >= 0 {
+		return 0
+	}
+	return m.Rank(hi) - m.Rank(lo)
+}
+
+// Keys returns a slice of all keys of the map, in ascending order.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Keys() []K {
+	var keys []K
+	if l := m.Len(); l > 0 {
+		keys = make([]K, 0, l)
+		m.Ascend(func(k K, _ V) bool {
+			keys = append(keys, k)
+			return true
+		})
+	}
+	return keys
+}
+
+// Values returns a slice of all values of the map, in ascending order of
+// their keys.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Values() []V {
+	var vals []V
+	if l := m.Len(); l > 0 {
+		vals = make([]V, 0, l)
+		m.Ascend(func(_ K, v V) bool {
+			vals = append(vals, v)
+			return true
+		})
+	}
+	return vals
+}
+
+// Ascend calls fn for each key and value of the map, in ascending order of
+// keys, until all keys have been visited or fn returns false. The map must
+// not be modified during the iteration.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Ascend(fn func(K, V) bool) {
+	m.root.ascend(fn)
+}
+
+// Descend calls fn for each key and value of the map, in descending order of
+// keys, until all keys have been visited or fn returns false. The map must
+// not be modified during the iteration.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Descend(fn func(K, V) bool) {
+	m.root.descend(fn)
+}
+
+// AscendRange calls fn for each key and value of the map in the half-open
+// range [lo, hi), in ascending order of keys, until all keys in the range
+// have been visited or fn returns false. The map must not be modified during
+// the iteration.
+//
+// It runs in O(log n + m) time complexity where m is the number of keys
+// visited.
+func (m *Map /*[K, V]*/) AscendRange(lo, hi K, fn func(K, V) bool) {
+	m.ascendRange(m.root, lo, hi, fn)
+}
+
+func (m *Map /*[K, V]*/) ascendRange(n *node /*[K, V]*/, lo, hi K, fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	// only visit the subtrees that may contain keys in the range
+	cl, ch := m.compare(lo, n.key), m.compare(n.key, hi)
+	if cl < 0 && !m.ascendRange(n.left, lo, hi, fn) {
+		return false
+	}
+	if cl <= 0 && ch < 0 && !fn(n.key, n.val) {
+		return false
+	}
+	if ch < 0 {
+		return m.ascendRange(n.right, lo, hi, fn)
+	}
+	return true
+}
+
+// DescendRange calls fn for each key and value of the map in the half-open
+// range [lo, hi), in descending order of keys, until all keys in the range
+// have been visited or fn returns false. The map must not be modified during
+// the iteration.
+//
+// It runs in O(log n + m) time complexity where m is the number of keys
+// visited.
+func (m *Map /*[K, V]*/) DescendRange(lo, hi K, fn func(K, V) bool) {
+	m.descendRange(m.root, lo, hi, fn)
+}
+
+func (m *Map /*[K, V]*/) descendRange(n *node /*[K, V]*/, lo, hi K, fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	cl, ch := m.compare(lo, n.key), m.compare(n.key, hi)
+	if ch < 0 && !m.descendRange(n.right, lo, hi, fn) {
+		return false
+	}
+	if cl <= 0 && ch < 0 && !fn(n.key, n.val) {
+		return false
+	}
+	if cl < 0 {
+		return m.descendRange(n.left, lo, hi, fn)
+	}
+	return true
+}
+
+// Iterator is an iterator over the keys and values of a Map, in ascending or
+// descending order of keys. Next advances the iterator to the next key,
+// which is then available via Key and Value, and returns false when there
+// are no more keys. The map must not be modified during the iteration.
+type Iterator /*[K algo.Ordered, V algo.Any]*/ struct {
+	stack   []*node /*[K, V]*/
+	cur     *node   /*[K, V]*/
+	reverse bool
+}
+
+// Iter returns an iterator over the keys and values of the map m, in
+// ascending order of keys. Iterating over the whole map runs in O(n) time
+// complexity and O(log n) space complexity.
+func (m *Map /*[K, V]*/) Iter() *Iterator /*[K, V]*/ {
+	it := &Iterator /*[K, V]*/ {}
+	it.pushPath(m.root)
+	return it
+}
+
+// ReverseIter returns an iterator over the keys and values of the map m, in
+// descending order of keys. Iterating over the whole map runs in O(n) time
+// complexity and O(log n) space complexity.
+func (m *Map /*[K, V]*/) ReverseIter() *Iterator /*[K, V]*/ {
+	it := &Iterator /*[K, V]*/ {reverse: true}
+	it.pushPath(m.root)
+	return it
+}
+
+// pushes the nodes on the path from n to the next node of the iteration.
+func (it *Iterator /*[K, V]*/) pushPath(n *node /*[K, V]*/) {
+	for n != nil {
+		it.stack = append(it.stack, n)
+		if it.reverse {
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+}
+
+// Next advances the iterator to the next key and returns true, or returns
+// false if there are no more keys.
+func (it *Iterator /*[K, V]*/) Next() bool {
+	if len(it.stack) == 0 {
+		it.cur = nil
+		return false
+	}
+	it.cur = it.stack[len(it.stack)-1]
+	it.stack = it.stack[:len(it.stack)-1]
+	if it.reverse {
+		it.pushPath(it.cur.left)
+	} else {
+		it.pushPath(it.cur.right)
+	}
+	return true
+}
+
+// Key returns the current key of the iterator. It panics if Next has not
+// been called or if it returned false.
+func (it *Iterator /*[K, V]*/) Key() K {
+	return it.cur.key
+}
+
+// Value returns the current value of the iterator. It panics if Next has not
+// been called or if it returned false.
+func (it *Iterator /*[K, V]*/) Value() V {
+	return it.cur.val
+}
+
+func (n *node /*[K, V]*/) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *node /*[K, V]*/) isRed() bool {
+	return n != nil && n.red
+}
+
+// returns the key and value of n and true, or the zero values and false if
+// n is nil.
+func (n *node /*[K, V]*/) entry() (K, V, bool) {
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return n.key, n.val, true
+}
+
+func (n *node /*[K, V]*/) min() *node /*[K, V]*/ {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (n *node /*[K, V]*/) ascend(fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.ascend(fn) && fn(n.key, n.val) && n.right.ascend(fn)
+}
+
+func (n *node /*[K, V]*/) descend(fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.right.descend(fn) && fn(n.key, n.val) && n.left.descend(fn)
+}
+
+// deletes the smallest key of the subtree rooted at n and returns the new
+// root of the subtree.
+func (n *node /*[K, V]*/) deleteMin() *node /*[K, V]*/ {
+	if n.left == nil {
+		return nil
+	}
+	if !n.left.isRed() && !n.left.left.isRed() {
+		n = n.moveRedLeft()
+	}
+	n.left = n.left.deleteMin()
+	return n.fixUp()
+}
+
+// restores the left-leaning red-black properties of n on the way up after
+// an insertion or a deletion (no right-leaning red link and no two red links
+// in a row), and updates its size. It returns the new root of the subtree.
+func (n *node /*[K, V]*/) fixUp() *node /*[K, V]*/ {
+	if n.right.isRed() && !n.left.isRed() {
+		n = n.rotateLeft()
+	}
+	if n.left.isRed() && n.left.left.isRed() {
+		n = n.rotateRight()
+	}
+	if n.left.isRed() && n.right.isRed() {
+		n.flipColors()
+	}
+	n.size = n.left.len() + n.right.len() + 1
+	return n
+}
+
+// assuming n is red and both its children are black, makes its left child
+// or one of its children red.
+func (n *node /*[K, V]*/) moveRedLeft() *node /*[K, V]*/ {
+	n.flipColors()
+	if n.right.left.isRed() {
+		n.right = n.right.rotateRight()
+		n = n.rotateLeft()
+		n.flipColors()
+	}
+	return n
+}
+
+// assuming n is red and both its right child and its left grandchild are
+// black, makes its right child or one of its children red.
+func (n *node /*[K, V]*/) moveRedRight() *node /*[K, V]*/ {
+	n.flipColors()
+	if n.left.left.isRed() {
+		n = n.rotateRight()
+		n.flipColors()
+	}
+	return n
+}
+
+func (n *node /*[K, V]*/) flipColors() {
+	n.red = !n.red
+	n.left.red = !n.left.red
+	n.right.red = !n.right.red
+}
+
+func (n *node /*[K, V]*/) rotateLeft() *node /*[K, V]*/ {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	r.red, n.red = n.red, true
+	r.size = n.size
+	n.size = n.left.len() + n.right.len() + 1
+	return r
+}
+
+func (n *node /*[K, V]*/) rotateRight() *node /*[K, V]*/ {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	l.red, n.red = n.red, true
+	l.size = n.size
+	n.size = n.left.len() + n.right.len() + 1
+	return l
+}
@@ -0,0 +1,386 @@
+package rbtrees
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	algosort "github.com/mna/algo/sort"
+)
+
+func TestMap(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var m Map
+		if m.Len() != 0 || m.Keys() != nil || m.Values() != nil {
+			t.Fatal("want empty map")
+		}
+		if _, ok := m.Get(1); ok {
+			t.Fatal("want no value")
+		}
+		if _, _, ok := m.Min(); ok {
+			t.Fatal("want no min")
+		}
+		if _, _, ok := m.Max(); ok {
+			t.Fatal("want no max")
+		}
+		if _, _, ok := m.Floor(1); ok {
+			t.Fatal("want no floor")
+		}
+		if _, _, ok := m.Ceiling(1); ok {
+			t.Fatal("want no ceiling")
+		}
+		if _, _, ok := m.Select(0); ok {
+			t.Fatal("want no select")
+		}
+		if m.Rank(1) != 0 || m.RangeLen(0, 10) != 0 {
+			t.Fatal("want zero rank")
+		}
+		if m.Delete(1) {
+			t.Fatal("want no delete")
+		}
+		if m.Iter().Next() || m.ReverseIter().Next() {
+			t.Fatal("want no iteration")
+		}
+		m.Put(1, 2)
+		if v, ok := m.Get(1); !ok || v != 2 || m.Len() != 1 {
+			t.Fatal("want single key")
+		}
+	})
+
+	t.Run("PutReplace", func(t *testing.T) {
+		m := Make()
+		m.Put(1, 10)
+		m.Put(2, 20)
+		m.Put(1, 11)
+		if m.Len() != 2 {
+			t.Fatalf("want len 2, got %d", m.Len())
+		}
+		if v, _ := m.Get(1); v != 11 {
+			t.Fatalf("want replaced value 11, got %d", v)
+		}
+	})
+
+	t.Run("PutDeleteRandom", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		m := Make()
+		ref := make(map[K]V)
+		for i := 0; i < 10000; i++ {
+			k := r.Intn(1000)
+			if r.Intn(3) == 0 {
+				_, want := ref[k]
+				if got := m.Delete(k); got != want {
+					t.Fatalf("%d: want deleted %t, got %t", k, want, got)
+				}
+				delete(ref, k)
+			} else {
+				v := r.Int()
+				m.Put(k, v)
+				ref[k] = v
+			}
+			if i%100 == 0 {
+				checkMap(t, m, ref)
+			}
+		}
+		checkMap(t, m, ref)
+
+		// delete all keys, in random order
+		for _, k := range r.Perm(1000) {
+			m.Delete(k)
+			delete(ref, k)
+		}
+		checkMap(t, m, ref)
+		if m.root != nil {
+			t.Fatal("want empty tree")
+		}
+	})
+
+	t.Run("Sequential", func(t *testing.T) {
+		// sorted insertions and deletions keep the tree balanced
+		m := Make()
+		ref := make(map[K]V)
+		for i := 0; i < 1000; i++ {
+			m.Put(i, -i)
+			ref[i] = -i
+		}
+		checkMap(t, m, ref)
+		for i := 999; i >= 500; i-- {
+			m.Delete(i)
+			delete(ref, i)
+		}
+		checkMap(t, m, ref)
+	})
+}
+
+func TestMapQueries(t *testing.T) {
+	m := Make()
+	for _, k := range []K{50, 10, 40, 20, 30} {
+		m.Put(k, k*10)
+	}
+	if k, v, ok := m.Min(); !ok || k != 10 || v != 100 {
+		t.Fatalf("want min 10, got %d", k)
+	}
+	if k, v, ok := m.Max(); !ok || k != 50 || v != 500 {
+		t.Fatalf("want max 50, got %d", k)
+	}
+
+	cases := []struct {
+		k           K
+		floor, ceil K // -1 if none
+		rank        int
+	}{
+		{5, -1, 10, 0},
+		{10, 10, 10, 0},
+		{15, 10, 20, 1},
+		{30, 30, 30, 2},
+		{45, 40, 50, 4},
+		{50, 50, 50, 4},
+		{55, 50, -1, 5},
+	}
+	for _, c := range cases {
+		k, v, ok := m.Floor(c.k)
+		if (c.floor == -1 && ok) || (c.floor != -1 && (!ok || k != c.floor || v != c.floor*10)) {
+			t.Fatalf("%d: want floor %d, got %d (%t)", c.k, c.floor, k, ok)
+		}
+		k, v, ok = m.Ceiling(c.k)
+		if (c.ceil == -1 && ok) || (c.ceil != -1 && (!ok || k != c.ceil || v != c.ceil*10)) {
+			t.Fatalf("%d: want ceiling %d, got %d (%t)", c.k, c.ceil, k, ok)
+		}
+		if got := m.Rank(c.k); got != c.rank {
+			t.Fatalf("%d: want rank %d, got %d", c.k, c.rank, got)
+		}
+	}
+
+	for i, want := range []K{10, 20, 30, 40, 50} {
+		if k, v, ok := m.Select(i); !ok || k != want || v != want*10 {
+			t.Fatalf("%d: want select %d, got %d", i, want, k)
+		}
+	}
+	for _, i := range []int{-1, 5} {
+		if _, _, ok := m.Select(i); ok {
+			t.Fatalf("%d: want no select", i)
+		}
+	}
+
+	ranges := []struct {
+		lo, hi K
+		want   []K
+	}{
+		{0, 100, []K{10, 20, 30, 40, 50}},
+		{10, 50, []K{10, 20, 30, 40}},
+		{11, 50, []K{20, 30, 40}},
+		{20, 21, []K{20}},
+		{21, 29, nil},
+		{30, 30, nil},
+		{40, 20, nil},
+		{60, 70, nil},
+	}
+	for _, c := range ranges {
+		var asc, desc []K
+		m.AscendRange(c.lo, c.hi, func(k K, v V) bool {
+			asc = append(asc, k)
+			return true
+		})
+		m.DescendRange(c.lo, c.hi, func(k K, v V) bool {
+			desc = append(desc, k)
+			return true
+		})
+		if diff := cmp.Diff(c.want, asc); diff != "" {
+			t.Fatalf("[%d, %d): %s", c.lo, c.hi, diff)
+		}
+		algosort.Reverse(desc)
+		if diff := cmp.Diff(c.want, desc); diff != "" {
+			t.Fatalf("[%d, %d) descending: %s", c.lo, c.hi, diff)
+		}
+		if got := m.RangeLen(c.lo, c.hi); got != len(c.want) {
+			t.Fatalf("[%d, %d): want range len %d, got %d", c.lo, c.hi, len(c.want), got)
+		}
+	}
+
+	// stop early
+	var got []K
+	m.AscendRange(0, 100, func(k K, v V) bool {
+		got = append(got, k)
+		return k < 30
+	})
+	if diff := cmp.Diff([]K{10, 20, 30}, got); diff != "" {
+		t.Fatal(diff)
+	}
+	got = nil
+	m.DescendRange(0, 100, func(k K, v V) bool {
+		got = append(got, k)
+		return k > 30
+	})
+	if diff := cmp.Diff([]K{50, 40, 30}, got); diff != "" {
+		t.Fatal(diff)
+	}
+	got = nil
+	m.Descend(func(k K, v V) bool {
+		got = append(got, k)
+		return len(got) < 2
+	})
+	if diff := cmp.Diff([]K{50, 40}, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestMapFunc(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("want panic")
+		}
+	}()
+
+	// reverse order
+	m := MakeFunc(algosort.ReverseCmpFunc(func(a, b K) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}))
+	for _, k := range []K{3, 1, 4, 1, 5, 9, 2, 6} {
+		m.Put(k, k)
+	}
+	if diff := cmp.Diff([]K{9, 6, 5, 4, 3, 2, 1}, m.Keys()); diff != "" {
+		t.Fatal(diff)
+	}
+	if k, _, _ := m.Min(); k != 9 {
+		t.Fatalf("want min 9, got %d", k)
+	}
+	if k, _, _ := m.Floor(7); k != 9 {
+		t.Fatalf("want floor 9, got %d", k)
+	}
+	if m.Rank(4) != 3 {
+		t.Fatalf("want rank 3, got %d", m.Rank(4))
+	}
+	m.Delete(5)
+	if diff := cmp.Diff([]K{9, 6, 4, 3, 2, 1}, m.Keys()); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// keys that compare equal are the same key
+	m = MakeFunc(func(a, b K) int { return a/10 - b/10 })
+	m.Put(11, 1)
+	m.Put(15, 2)
+	if v, ok := m.Get(19); !ok || v != 2 || m.Len() != 1 {
+		t.Fatal("want keys in the same tens to be equal")
+	}
+
+	MakeFunc(nil)
+}
+
+func TestIterator(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	for _, n := range []int{0, 1, 2, 10, 1000} {
+		m := Make()
+		for _, k := range r.Perm(n) {
+			m.Put(k, k*2)
+		}
+
+		var got []K
+		for it := m.Iter(); it.Next(); {
+			if it.Value() != it.Key()*2 {
+				t.Fatalf("%d: want value %d, got %d", it.Key(), it.Key()*2, it.Value())
+			}
+			got = append(got, it.Key())
+		}
+		if diff := cmp.Diff(m.Keys(), got); diff != "" {
+			t.Fatal(diff)
+		}
+
+		got = got[:0]
+		for it := m.ReverseIter(); it.Next(); {
+			got = append(got, it.Key())
+		}
+		want := m.Keys()
+		algosort.Reverse(want)
+		if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Fatal(diff)
+		}
+	}
+}
+
+// checkMap checks that m contains the same keys and values as ref, and that
+// its tree is a valid left-leaning red-black tree.
+func checkMap(t *testing.T, m *Map, ref map[K]V) {
+	t.Helper()
+
+	keys := make([]K, 0, len(ref))
+	vals := make([]V, 0, len(ref))
+	for k := range ref {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	for _, k := range keys {
+		vals = append(vals, ref[k])
+	}
+
+	if m.Len() != len(ref) {
+		t.Fatalf("want len %d, got %d", len(ref), m.Len())
+	}
+	if diff := cmp.Diff(keys, m.Keys(), cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("keys: %s", diff)
+	}
+	if diff := cmp.Diff(vals, m.Values(), cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("values: %s", diff)
+	}
+	for i, k := range keys {
+		if v, ok := m.Get(k); !ok || v != ref[k] {
+			t.Fatalf("%d: want value %d, got %d", k, ref[k], v)
+		}
+		if m.Rank(k) != i {
+			t.Fatalf("%d: want rank %d, got %d", k, i, m.Rank(k))
+		}
+		if got, _, _ := m.Select(i); got != k {
+			t.Fatalf("%d: want select %d, got %d", i, k, got)
+		}
+	}
+
+	if m.root.isRed() {
+		t.Fatal("want black root")
+	}
+	checkNode(t, m.root, nil, nil)
+}
+
+// checkNode checks the red-black properties of the subtree rooted at n,
+// whose keys must be in the range (lo, hi), and returns its black height.
+func checkNode(t *testing.T, n *node, lo, hi *K) int {
+	t.Helper()
+
+	if n == nil {
+		return 0
+	}
+	if (lo != nil && n.key <= *lo) || (hi != nil && n.key >= *hi) {
+		t.Fatalf("%d: key out of order", n.key)
+	}
+	if n.right.isRed() {
+		t.Fatalf("%d: right-leaning red link", n.key)
+	}
+	if n.red && n.left.isRed() {
+		t.Fatalf("%d: two red links in a row", n.key)
+	}
+	if n.size != n.left.len()+n.right.len()+1 {
+		t.Fatalf("%d: want size %d, got %d", n.key, n.left.len()+n.right.len()+1, n.size)
+	}
+
+	lh := checkNode(t, n.left, lo, &n.key)
+	rh := checkNode(t, n.right, &n.key, hi)
+	if lh != rh {
+		t.Fatalf("%d: black heights differ: %d and %d", n.key, lh, rh)
+	}
+	if !n.red {
+		lh++
+	}
+	return lh
+}
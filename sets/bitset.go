@@ -0,0 +1,449 @@
+package sets
+
+import "math/bits"
+
+// BitSet is a set of non-negative integers backed by a slice of 64-bit
+// words, where the value v is in the set if the bit v%64 of the word v/64 is
+// set. It uses a single bit per possible value, so it is very compact when
+// the values are dense integers (e.g. identifiers assigned sequentially), but
+// its size is proportional to the largest value in the set, not to the
+// number of values. See Roaring for sparse values. Its zero-value is ready to
+// use.
+//
+// As the values must be integers, BitSet is not generic.
+type BitSet struct {
+	words []uint64
+}
+
+const wordBits = 64
+
+// MakeBits returns a bit set.
+func MakeBits() *BitSet {
+	return new(BitSet)
+}
+
+// MakeBitsCap returns a bit set with an initial capacity to store values up
+// to max-1 without growing.
+func MakeBitsCap(max int) *BitSet {
+	return &BitSet{
+		words: make([]uint64, 0, (max+wordBits-1)/wordBits),
+	}
+}
+
+// MakeBitsFrom returns a bit set initialized with the provided values. It
+// panics if any value is negative.
+func MakeBitsFrom(vs ...int) *BitSet {
+	s := MakeBits()
+	s.Add(vs...)
+	return s
+}
+
+// Add adds value(s) to the set s. If v is already in s this has no effect.
+// It panics if v is negative.
+//
+// It runs in O(1) (amortized) time complexity, as the storage may need to
+// grow to store v. Of course it is O(n) with respect to the number of values
+// to add.
+func (s *BitSet) Add(vs ...int) {
+	for _, v := range vs {
+		if v < 0 {
+			panic("sets: negative BitSet value")
+		}
+		i := v / wordBits
+		s.grow(i + 1)
+		s.words[i] |= 1 << uint(v%wordBits)
+	}
+}
+
+// Delete removes v from the set s. If v is not in s this has no effect.
+//
+// It runs in O(1) time complexity (O(n) with respect to the number of values
+// to delete). It does not allocate.
+func (s *BitSet) Delete(vs ...int) {
+	for _, v := range vs {
+		if i := v / wordBits; v >= 0 && i < len(s.words) {
+			s.words[i] &^= 1 << uint(v%wordBits)
+		}
+	}
+	s.trim()
+}
+
+// Contains reports whether v is in s.
+//
+// It runs in O(1) time complexity.
+func (s *BitSet) Contains(v int) bool {
+	i := v / wordBits
+	return v >= 0 && i < len(s.words) && s.words[i]&(1<<uint(v%wordBits)) != 0
+}
+
+// Len reports the number of elements in s, by counting the bits that are set
+// in each word.
+//
+// It runs in O(m) time complexity where m is the number of words, that is
+// the largest value of the set divided by 64.
+func (s *BitSet) Len() int {
+	var n int
+	for _, w := range s.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Values returns a slice of all values present in the set, in ascending
+// order.
+//
+// It runs in O(n + m) time complexity where n is the number of values in the
+// set and m the number of words.
+func (s *BitSet) Values() []int {
+	var vals []int
+	if n := s.Len(); n > 0 {
+		vals = make([]int, 0, n)
+		for v, ok := s.NextSet(0); ok; v, ok = s.NextSet(v + 1) {
+			vals = append(vals, v)
+		}
+	}
+	return vals
+}
+
+// NextSet returns the smallest value of s that is greater than or equal to
+// v, and true, or false if there is no such value. It can be used to iterate
+// over the values in ascending order:
+//
+//	for v, ok := s.NextSet(0); ok; v, ok = s.NextSet(v + 1) {
+//	  ...
+//	}
+//
+// It runs in O(m) time complexity where m is the number of words, but it
+// skips 64 values at a time so it is fast in practice. It does not allocate.
+func (s *BitSet) NextSet(v int) (int, bool) {
+	if v < 0 {
+		v = 0
+	}
+	i := v / wordBits
+	if i >= len(s.words) {
+		return 0, false
+	}
+
+	// mask the bits of the first word that are smaller than v
+	w := s.words[i] >> uint(v%wordBits)
+	if w != 0 {
+		return v + bits.TrailingZeros64(w), true
+	}
+	for i++; i < len(s.words); i++ {
+		if w := s.words[i]; w != 0 {
+			return i*wordBits + bits.TrailingZeros64(w), true
+		}
+	}
+	return 0, false
+}
+
+// Rank returns the number of values of s that are smaller than v, which is
+// the index v would have in the sorted values of s.
+//
+// It runs in O(m) time complexity where m is the number of words. It does
+// not allocate.
+func (s *BitSet) Rank(v int) int {
+	if v <= 0 {
+		return 0
+	}
+	i := v / wordBits
+	if i >= len(s.words) {
+		return s.Len()
+	}
+
+	var n int
+	for _, w := range s.words[:i] {
+		n += bits.OnesCount64(w)
+	}
+	mask := uint64(1)<<uint(v%wordBits) - 1
+	return n + bits.OnesCount64(s.words[i]&mask)
+}
+
+// Select returns the value of s with rank k, that is the k-th smallest value
+// (0-based), and true, or false if k is not in the range [0, s.Len()). It is
+// the reverse of Rank.
+//
+// It runs in O(m) time complexity where m is the number of words. It does
+// not allocate.
+func (s *BitSet) Select(k int) (int, bool) {
+	if k < 0 {
+		return 0, false
+	}
+	for i, w := range s.words {
+		n := bits.OnesCount64(w)
+		if k >= n {
+			k -= n
+			continue
+		}
+
+		// the value is in this word, clear the k lowest set bits
+		for ; k > 0; k-- {
+			w &= w - 1
+		}
+		return i*wordBits + bits.TrailingZeros64(w), true
+	}
+	return 0, false
+}
+
+// IsDisjoint returns true if the intersection of s and other is an empty
+// set, false otherwise.
+//
+// It runs in O(m) time complexity where m is the number of words of the
+// smallest set between s and other. It does not allocate.
+func (s *BitSet) IsDisjoint(other *BitSet) bool {
+	n := len(s.words)
+	if len(other.words) < n {
+		n = len(other.words)
+	}
+	for i := 0; i < n; i++ {
+		if s.words[i]&other.words[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubset returns true if every value of s is in other, false otherwise. If
+// strict is true and s contains the same values as other, then it returns
+// false.
+//
+// It runs in O(m) time complexity where m is the number of words of s. It
+// does not allocate.
+func (s *BitSet) IsSubset(other *BitSet, strict bool) bool {
+	// the words are trimmed, so s cannot be a subset if it has more words.
+	if len(s.words) > len(other.words) {
+		return false
+	}
+	for i, w := range s.words {
+		if w&^other.words[i] != 0 {
+			return false
+		}
+	}
+	return !strict || !s.IsEqual(other)
+}
+
+// IsSuperset returns true if every value of other is in s, false otherwise.
+// If strict is true and other contains the same values as s, then it returns
+// false.
+//
+// It runs in O(m) time complexity where m is the number of words of other.
+// It does not allocate.
+func (s *BitSet) IsSuperset(other *BitSet, strict bool) bool {
+	return other.IsSubset(s, strict)
+}
+
+// IsEqual returns true if s contains the same values as other, false
+// otherwise.
+//
+// It runs in O(m) time complexity where m is the number of words of s. It
+// does not allocate.
+func (s *BitSet) IsEqual(other *BitSet) bool {
+	if len(s.words) != len(other.words) {
+		return false
+	}
+	for i, w := range s.words {
+		if other.words[i] != w {
+			return false
+		}
+	}
+	return true
+}
+
+// grows the words of s so that it has at least n words.
+func (s *BitSet) grow(n int) {
+	if n <= len(s.words) {
+		return
+	}
+	if n <= cap(s.words) {
+		old := len(s.words)
+		s.words = s.words[:n]
+		for i := old; i < n; i++ {
+			s.words[i] = 0
+		}
+		return
+	}
+	words := make([]uint64, n, 2*n)
+	copy(words, s.words)
+	s.words = words
+}
+
+// trims the trailing zero words of s, so that two equal sets always have
+// the same number of words.
+func (s *BitSet) trim() {
+	n := len(s.words)
+	for n > 0 && s.words[n-1] == 0 {
+		n--
+	}
+	s.words = s.words[:n]
+}
+
+// IntersectBits returns a new BitSet that contains the intersection of all
+// sets. If no set is provided, it returns nil. If a single set is provided,
+// it returns a copy of that set (that is, it always creates a new set if at
+// least one set is provided).
+//
+// It runs in O(m * k) time complexity where m is the number of words of the
+// smallest set and k the number of sets.
+func IntersectBits(sets ...*BitSet) *BitSet {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeBits()
+	IntersectBitsInto(s, sets...)
+	return s
+}
+
+// IntersectBitsInto is like IntersectBits, but the intersection of the sets
+// is stored in dst. The dst set's values are not used to find the
+// intersection of values, only as destination storage. If no set is
+// provided, then dst is untouched.
+//
+// Its time complexity is the same as for IntersectBits. It does not allocate
+// if dst has enough capacity to store the intersection.
+func IntersectBitsInto(dst *BitSet, sets ...*BitSet) {
+	if len(sets) == 0 {
+		return
+	}
+
+	n := len(sets[0].words)
+	for _, set := range sets[1:] {
+		if len(set.words) < n {
+			n = len(set.words)
+		}
+	}
+	dst.grow(n)
+
+	// each word is computed from the words of the sets at the same index, so
+	// dst can safely be one of the sets.
+	for i := 0; i < n; i++ {
+		w := sets[0].words[i]
+		for _, set := range sets[1:] {
+			w &= set.words[i]
+		}
+		dst.words[i] |= w
+	}
+	dst.trim()
+}
+
+// UnionBits returns a new BitSet that is the union of all sets. If no set is
+// provided, it returns nil. If a single set is provided, it returns a copy of
+// that set (that is, it always creates a new set if at least one set is
+// provided).
+//
+// It runs in O(m * k) time complexity where m is the number of words of the
+// largest set and k the number of sets.
+func UnionBits(sets ...*BitSet) *BitSet {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeBits()
+	UnionBitsInto(s, sets...)
+	return s
+}
+
+// UnionBitsInto is like UnionBits, but the union of the sets is stored in
+// dst. If no set is provided for the union, then dst is untouched.
+//
+// Its time complexity is the same as UnionBits. It does not allocate if dst
+// has enough capacity to store the union.
+func UnionBitsInto(dst *BitSet, sets ...*BitSet) {
+	for _, set := range sets {
+		dst.grow(len(set.words))
+		for i, w := range set.words {
+			dst.words[i] |= w
+		}
+	}
+}
+
+// DiffBits returns a new BitSet that is the difference of all sets, that is,
+// the values in the first set that are not in any of the other sets. If no
+// set is provided, it returns nil. If a single set is provided, it returns a
+// copy of that set (it always creates a new set if at least one set is
+// provided).
+//
+// It runs in O(m * k) time complexity where m is the number of words of the
+// first set and k the number of sets.
+func DiffBits(sets ...*BitSet) *BitSet {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeBits()
+	DiffBitsInto(s, sets...)
+	return s
+}
+
+// DiffBitsInto is like DiffBits, but the difference of the sets is stored in
+// dst. The dst set's values are not used to find the difference of values,
+// only as destination storage. If no set is provided for the difference,
+// then dst is untouched.
+//
+// Its time complexity is the same as DiffBits. It does not allocate if dst
+// has enough capacity to store the difference.
+func DiffBitsInto(dst *BitSet, sets ...*BitSet) {
+	if len(sets) == 0 {
+		return
+	}
+
+	dst.grow(len(sets[0].words))
+	for i, w := range sets[0].words {
+		for _, set := range sets[1:] {
+			if i < len(set.words) {
+				w &^= set.words[i]
+			}
+		}
+		dst.words[i] |= w
+	}
+	dst.trim()
+}
+
+// SymmetricDiffBits returns a new BitSet that contains values that are in
+// either of the sets but not in any other. If no set is provided, it returns
+// nil. If a single set is provided, it returns a copy of that set (it always
+// creates a new set if at least one set is provided).
+//
+// It runs in O(m * k) time complexity where m is the number of words of the
+// largest set and k the number of sets.
+func SymmetricDiffBits(sets ...*BitSet) *BitSet {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeBits()
+	SymmetricDiffBitsInto(s, sets...)
+	return s
+}
+
+// SymmetricDiffBitsInto is like SymmetricDiffBits, but the resulting values
+// are stored in dst. The dst set's values are not used to find the symmetric
+// difference, only as destination storage. If no set is provided for the
+// symmetric difference, then dst is untouched.
+//
+// Its time complexity is the same as SymmetricDiffBits. It does not allocate
+// if dst has enough capacity to store the symmetric difference.
+func SymmetricDiffBitsInto(dst *BitSet, sets ...*BitSet) {
+	if len(sets) == 0 {
+		return
+	}
+
+	var n int
+	for _, set := range sets {
+		if len(set.words) > n {
+			n = len(set.words)
+		}
+	}
+	dst.grow(n)
+
+	for i := 0; i < n; i++ {
+		// once has the bits set in exactly one set so far, seen the bits set in
+		// any set so far.
+		var once, seen uint64
+		for _, set := range sets {
+			if i < len(set.words) {
+				w := set.words[i]
+				once = (once &^ w) | (w &^ seen)
+				seen |= w
+			}
+		}
+		dst.words[i] |= once
+	}
+	dst.trim()
+}
@@ -0,0 +1,256 @@
+package sets
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkBitSet_Add(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeBitsFrom(vals...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Add(n + 1 + i)
+			}
+		})
+	}
+}
+
+func BenchmarkBitSet_Delete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeBitsFrom(vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Delete(vals[indices[i]])
+			}
+		})
+	}
+}
+
+func BenchmarkBitSet_Contains(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeBitsFrom(vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s.Contains(vals[indices[i]]) {
+					b.Fatal("Contains returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBitSet_NextSet(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		// iterate over all values, with one value every 10
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeBitsFrom(sortedSlice(n, 10)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				var count int
+				for v, ok := s.NextSet(0); ok; v, ok = s.NextSet(v + 1) {
+					count++
+				}
+				if count != n {
+					b.Fatalf("want %d values, got %d", n, count)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBitSet_Rank(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeBitsFrom(vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if s.Rank(vals[indices[i]]) != indices[i] {
+					b.Fatal("Rank returned the wrong rank")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBitSet_Select(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeBitsFrom(vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if v, ok := s.Select(indices[i]); !ok || v != vals[indices[i]] {
+					b.Fatal("Select returned the wrong value")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBitSet_Union(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*BitSet, nsets)
+				for i := range sets {
+					sets[i] = MakeBitsFrom(sortedSlice(n, 1, (i+1)*n)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := UnionBits(sets...)
+					if s.Len() != nsets*n {
+						b.Fatalf("want len %d, got %d", nsets*n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkBitSet_Intersect(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*BitSet, nsets)
+				for i := range sets {
+					sets[i] = MakeBitsFrom(sortedSlice(n, 1)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := IntersectBits(sets...)
+					if s.Len() != n {
+						b.Fatalf("want len %d, got %d", n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkBitSet_Diff(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*BitSet, nsets)
+				for i := range sets {
+					sets[i] = MakeBitsFrom(sortedSlice(n, 1, (i+1)*n)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := DiffBits(sets...)
+					if s.Len() != n {
+						b.Fatalf("want len %d, got %d", n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkBitSet_SymmetricDiff(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*BitSet, nsets)
+				for i := range sets {
+					sets[i] = MakeBitsFrom(sortedSlice(n, 1, (i+1)*n)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := SymmetricDiffBits(sets...)
+					if s.Len() != nsets*n {
+						b.Fatalf("want len %d, got %d", nsets*n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkBitSet_IsSubset(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s1, s2 := MakeBitsFrom(vals...), MakeBitsFrom(vals...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsSubset(s2, false) {
+					b.Fatal("want is subset to return true")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBitSet_IsSuperset(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s1, s2 := MakeBitsFrom(vals...), MakeBitsFrom(vals...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsSuperset(s2, false) {
+					b.Fatal("want is superset to return true")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBitSet_IsDisjoint(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			v1, v2 := sortedSlice(n, 1), sortedSlice(n, 1, (2*n)+1)
+			s1, s2 := MakeBitsFrom(v1...), MakeBitsFrom(v2...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsDisjoint(s2) {
+					b.Fatal("want is disjoint to return true")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBitSet_IsEqual(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s1, s2 := MakeBitsFrom(vals...), MakeBitsFrom(vals...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsEqual(s2) {
+					b.Fatal("want is equal to return true")
+				}
+			}
+		})
+	}
+}
+
+// returns a slice of N valid indices into vals, to be used for benchmarks
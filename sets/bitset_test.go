@@ -0,0 +1,238 @@
+package sets
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestBitSet(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var s BitSet
+		if s.Len() != 0 || s.Values() != nil {
+			t.Fatal("want empty set")
+		}
+		if _, ok := s.NextSet(0); ok {
+			t.Fatal("want no next set")
+		}
+		if _, ok := s.Select(0); ok {
+			t.Fatal("want no select")
+		}
+		if r := s.Rank(10); r != 0 {
+			t.Fatalf("want rank 0, got %d", r)
+		}
+		s.Add(100)
+		if !s.Contains(100) || s.Len() != 1 {
+			t.Fatal("want single value")
+		}
+	})
+
+	t.Run("NegativeValue", func(t *testing.T) {
+		s := MakeBitsCap(100)
+		if s.Contains(-1) {
+			t.Fatal("want negative value to not be contained")
+		}
+		s.Delete(-1)
+
+		defer func() {
+			if e := recover(); e == nil {
+				t.Fatal("want panic")
+			}
+		}()
+		s.Add(-1)
+	})
+
+	t.Run("AddDeleteRandom", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		s := MakeBits()
+		ref := Make()
+		for i := 0; i < 10000; i++ {
+			v := r.Intn(1000)
+			if r.Intn(3) == 0 {
+				s.Delete(v)
+				ref.Delete(v)
+			} else {
+				s.Add(v)
+				ref.Add(v)
+			}
+		}
+
+		want := ref.Values()
+		sort.Ints(want)
+		if got := s.Values(); !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+		if s.Len() != ref.Len() {
+			t.Fatalf("want len %d, got %d", ref.Len(), s.Len())
+		}
+
+		// check Rank and Select against the sorted values
+		for k, v := range want {
+			if got := s.Rank(v); got != k {
+				t.Fatalf("want rank of %d to be %d, got %d", v, k, got)
+			}
+			if got, ok := s.Select(k); !ok || got != v {
+				t.Fatalf("want select of %d to be %d, got %d", k, v, got)
+			}
+		}
+		if _, ok := s.Select(len(want)); ok {
+			t.Fatal("want no select past the last value")
+		}
+		if got := s.Rank(2000); got != len(want) {
+			t.Fatalf("want rank past the last value to be %d, got %d", len(want), got)
+		}
+	})
+
+	t.Run("NextSet", func(t *testing.T) {
+		s := MakeBitsFrom(3, 63, 64, 200)
+		cases := []struct {
+			v, want int // want -1 if none
+		}{
+			{-5, 3},
+			{0, 3},
+			{3, 3},
+			{4, 63},
+			{64, 64},
+			{65, 200},
+			{200, 200},
+			{201, -1},
+			{1000, -1},
+		}
+		for _, c := range cases {
+			got, ok := s.NextSet(c.v)
+			if (c.want < 0) == ok || (ok && got != c.want) {
+				t.Fatalf("%d: want %d, got %d (%t)", c.v, c.want, got, ok)
+			}
+		}
+	})
+
+	t.Run("DeleteTrims", func(t *testing.T) {
+		s1, s2 := MakeBitsFrom(1, 1000), MakeBitsFrom(1)
+		s1.Delete(1000)
+		if !s1.IsEqual(s2) || !s2.IsEqual(s1) {
+			t.Fatal("want sets to be equal")
+		}
+
+		// growing again must not resurrect old bits
+		s1.Add(500)
+		if s1.Contains(1000) || s1.Len() != 2 {
+			t.Fatalf("want [1 500], got %v", s1.Values())
+		}
+	})
+
+	setOps := []struct {
+		name string
+		fn   func(...*BitSet) *BitSet
+		into func(*BitSet, ...*BitSet)
+		ref  func(...Set) Set
+	}{
+		{"Union", UnionBits, UnionBitsInto, Union},
+		{"Intersect", IntersectBits, IntersectBitsInto, Intersect},
+		{"Diff", DiffBits, DiffBitsInto, Diff},
+		{"SymmetricDiff", SymmetricDiffBits, SymmetricDiffBitsInto, SymmetricDiff},
+	}
+	setsValues := [][][]T{
+		nil,
+		{{}},
+		{sortedSlice(5, 1)},
+		{sortedSlice(5, 1), sortedSlice(3, 10)},
+		{sortedSlice(5, 1), sortedSlice(3, 2)},
+		{sortedSlice(3, 1), sortedSlice(2, 1), sortedSlice(1, 1)},
+		{{1, 3, 4, 6}, {3, 4}, {3, 4, 5}, {2, 3, 6}},
+		{{1, 2, 300}, {2, 300, 400}, {300, 400, 500}},
+		{{1, 200}, {200}},
+	}
+	for _, op := range setOps {
+		t.Run(op.name, func(t *testing.T) {
+			for _, vals := range setsValues {
+				for _, dstValues := range [][]T{nil, {}, {55}, {0, 1000}} {
+					t.Run(fmt.Sprintf("%v into %v", vals, dstValues), func(t *testing.T) {
+						sets := make([]*BitSet, len(vals))
+						refs := make([]Set, len(vals))
+						for i, vs := range vals {
+							sets[i] = MakeBitsFrom(vs...)
+							refs[i] = MakeFrom(vs...)
+						}
+
+						var got *BitSet
+						var want Set
+						if dstValues != nil {
+							got = MakeBitsFrom(dstValues...)
+							op.into(got, sets...)
+							want = MakeFrom(dstValues...)
+							UnionInto(want, op.ref(refs...))
+						} else {
+							got = op.fn(sets...)
+							want = op.ref(refs...)
+						}
+
+						wantVals := want.Values()
+						sort.Ints(wantVals)
+						var gotVals []T
+						if got != nil {
+							gotVals = got.Values()
+							if want := MakeBitsFrom(wantVals...); !got.IsEqual(want) {
+								t.Fatalf("want equal to %v", wantVals)
+							}
+						}
+						if !cmp.Equal(wantVals, gotVals, cmpopts.EquateEmpty()) {
+							t.Fatalf("want %v, got %v", wantVals, gotVals)
+						}
+					})
+				}
+			}
+		})
+	}
+
+	t.Run("IsDisjointSubsetSupersetEqual", func(t *testing.T) {
+		cases := []struct {
+			vals1    []T
+			vals2    []T
+			disjoint bool
+			subset   bool
+			strict   bool
+		}{
+			{nil, nil, true, true, false},
+			{nil, []T{1}, true, true, true},
+			{[]T{1}, []T{1}, false, true, false},
+			{[]T{1}, []T{1, 2}, false, true, true},
+			{[]T{1}, []T{1, 200}, false, true, true},
+			{[]T{3, 4}, []T{1, 2}, true, false, false},
+			{[]T{3, 400}, []T{1, 2}, true, false, false},
+			{[]T{1, 2, 3, 4}, []T{1, 2, 4, 5}, false, false, false},
+		}
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%v<=>%v", c.vals1, c.vals2), func(t *testing.T) {
+				s1, s2 := MakeBitsFrom(c.vals1...), MakeBitsFrom(c.vals2...)
+
+				if dis1, dis2 := s1.IsDisjoint(s2), s2.IsDisjoint(s1); dis1 != c.disjoint || dis2 != c.disjoint {
+					t.Fatalf("want disjoint %t, got %t and %t", c.disjoint, dis1, dis2)
+				}
+				if sub := s1.IsSubset(s2, false); sub != c.subset {
+					t.Fatalf("want subset %t, got %t", c.subset, sub)
+				}
+				if substr := s1.IsSubset(s2, true); substr != (c.subset && c.strict) {
+					t.Fatalf("want strict subset %t, got %t", c.subset && c.strict, substr)
+				}
+				if sup := s2.IsSuperset(s1, false); sup != c.subset {
+					t.Fatalf("want superset %t, got %t", c.subset, sup)
+				}
+				if supstr := s2.IsSuperset(s1, true); supstr != (c.subset && c.strict) {
+					t.Fatalf("want strict superset %t, got %t", c.subset && c.strict, supstr)
+				}
+				wantEq := c.subset && !c.strict
+				if eq1, eq2 := s1.IsEqual(s2), s2.IsEqual(s1); eq1 != wantEq || eq2 != wantEq {
+					t.Fatalf("want equal %t, got %t and %t", wantEq, eq1, eq2)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,447 @@
+package sets
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentSet is a set of values that is safe for concurrent use by
+// multiple goroutines. The values are distributed in a number of shards
+// based on their hash, each shard being a Set protected by its own lock, so
+// that goroutines that use values in different shards do not contend for
+// the same lock.
+type ConcurrentSet /*[T algo.Comparable]*/ struct {
+	id     uint64 // unique identifier, used to lock sets in a consistent order
+	hash   func(T) uint64
+	shards []concurrentShard /*[T]*/
+}
+
+type concurrentShard /*[T algo.Comparable]*/ struct {
+	sync.RWMutex
+	set Set /*[T]*/
+}
+
+// source of the unique ConcurrentSet identifiers.
+var concurrentSetID uint64
+
+// MakeConcurrent returns a concurrent set of some element type with the
+// specified number of shards. The hash function is used to select the shard
+// of a value, it must always return the same hash for the same value. If
+// shards is smaller than 1 or hash is nil, a single shard is used.
+func MakeConcurrent /*[T algo.Comparable]*/ (shards int, hash func(T) uint64) *ConcurrentSet /*[T]*/ {
+	if shards < 1 || hash == nil {
+		shards = 1
+	}
+	s := &ConcurrentSet{
+		id:     atomic.AddUint64(&concurrentSetID, 1),
+		hash:   hash,
+		shards: make([]concurrentShard /*[T]*/, shards),
+	}
+	for i := range s.shards {
+		s.shards[i].set = Make /*[T]*/ ()
+	}
+	return s
+}
+
+// MakeConcurrentFrom returns a concurrent set of some element type with the
+// specified number of shards, initialized with the provided values. See
+// MakeConcurrent for details on the shards and hash arguments.
+func MakeConcurrentFrom /*[T algo.Comparable]*/ (shards int, hash func(T) uint64, vs ...T) *ConcurrentSet /*[T]*/ {
+	s := MakeConcurrent /*[T]*/ (shards, hash)
+	s.Add(vs...)
+	return s
+}
+
+func (s *ConcurrentSet /*[T]*/) shard(v T) *concurrentShard /*[T]*/ {
+	if len(s.shards) == 1 {
+		return &s.shards[0]
+	}
+	return &s.shards[s.hash(v)%uint64(len(s.shards))]
+}
+
+// Add adds value(s) to the set s. If v is already in s this has no effect.
+// Each value is added atomically, but not all values at once, so other
+// goroutines may observe some of the values before the others.
+//
+// It runs in O(1) (amortized) time complexity (O(n) with respect to the
+// number of values to add).
+func (s *ConcurrentSet /*[T]*/) Add(vs ...T) {
+	for _, v := range vs {
+		sh := s.shard(v)
+		sh.Lock()
+		sh.set.Add(v)
+		sh.Unlock()
+	}
+}
+
+// Delete removes v from the set s. If v is not in s this has no effect. Each
+// value is removed atomically, but not all values at once, so other
+// goroutines may observe the removal of some of the values before the
+// others.
+//
+// It runs in O(1) time complexity (O(n) with respect to the number of values
+// to delete).
+func (s *ConcurrentSet /*[T]*/) Delete(vs ...T) {
+	for _, v := range vs {
+		sh := s.shard(v)
+		sh.Lock()
+		sh.set.Delete(v)
+		sh.Unlock()
+	}
+}
+
+// AddIfAbsent atomically adds v to the set s if it is not already present,
+// and returns true if it was added. If multiple goroutines add the same
+// value concurrently, only one of them gets true, similar to the
+// LoadOrStore method of sync.Map.
+//
+// It runs in O(1) (amortized) time complexity.
+func (s *ConcurrentSet /*[T]*/) AddIfAbsent(v T) bool {
+	sh := s.shard(v)
+	sh.Lock()
+	defer sh.Unlock()
+
+	if sh.set.Contains(v) {
+		return false
+	}
+	sh.set.Add(v)
+	return true
+}
+
+// DeleteIfPresent atomically removes v from the set s if it is present, and
+// returns true if it was removed. If multiple goroutines delete the same
+// value concurrently, only one of them gets true, similar to the
+// LoadAndDelete method of sync.Map.
+//
+// It runs in O(1) time complexity.
+func (s *ConcurrentSet /*[T]*/) DeleteIfPresent(v T) bool {
+	sh := s.shard(v)
+	sh.Lock()
+	defer sh.Unlock()
+
+	if !sh.set.Contains(v) {
+		return false
+	}
+	sh.set.Delete(v)
+	return true
+}
+
+// Contains reports whether v is in s.
+//
+// It runs in O(1) time complexity.
+func (s *ConcurrentSet /*[T]*/) Contains(v T) bool {
+	sh := s.shard(v)
+	sh.RLock()
+	defer sh.RUnlock()
+	return sh.set.Contains(v)
+}
+
+// Len reports the number of elements in s. The shards are locked one at a
+// time, so if the set is modified concurrently, the result may not match
+// the number of elements of the set at any single point in time.
+//
+// It runs in O(m) time complexity where m is the number of shards.
+func (s *ConcurrentSet /*[T]*/) Len() int {
+	var n int
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.RLock()
+		n += sh.set.Len()
+		sh.RUnlock()
+	}
+	return n
+}
+
+// Values returns a slice of all values present in the set. The order is
+// undefined. All shards are locked while the values are collected, so the
+// result is a consistent snapshot of the set.
+//
+// It runs in O(n) time complexity where n is the number of values in the set.
+func (s *ConcurrentSet /*[T]*/) Values() []T {
+	unlock := lockConcurrent(nil, s)
+	defer unlock()
+	return s.values()
+}
+
+// Set returns a new Set initialized with the values of s. Like Values, the
+// result is a consistent snapshot of the set.
+//
+// It runs in O(n) time complexity where n is the number of values in the set.
+func (s *ConcurrentSet /*[T]*/) Set() Set /*[T]*/ {
+	unlock := lockConcurrent(nil, s)
+	defer unlock()
+
+	res := MakeCap /*[T]*/ (s.len())
+	for i := range s.shards {
+		UnionInto(res, s.shards[i].set)
+	}
+	return res
+}
+
+// the following methods must be called with the shards locked.
+
+func (s *ConcurrentSet /*[T]*/) len() int {
+	var n int
+	for i := range s.shards {
+		n += s.shards[i].set.Len()
+	}
+	return n
+}
+
+func (s *ConcurrentSet /*[T]*/) values() []T {
+	var vals []T
+	if n := s.len(); n > 0 {
+		vals = make([]T, 0, n)
+		for i := range s.shards {
+			for k := range s.shards[i].set {
+				vals = append(vals, k)
+			}
+		}
+	}
+	return vals
+}
+
+func (s *ConcurrentSet /*[T]*/) contains(v T) bool {
+	return s.shard(v).set.Contains(v)
+}
+
+// locks all shards of dst for writing and all shards of sets for reading,
+// and returns a function that unlocks them. The sets are locked in the order
+// of their identifiers so that concurrent calls with the same sets in a
+// different order cannot deadlock. The dst set may be nil, and it may be one
+// of sets, and the same set may be provided multiple times.
+func lockConcurrent /*[T algo.Comparable]*/ (dst *ConcurrentSet /*[T]*/, sets ...*ConcurrentSet /*[T]*/) func() {
+	all := make([]*ConcurrentSet /*[T]*/, 0, len(sets)+1)
+	if dst != nil {
+		all = append(all, dst)
+	}
+	all = append(all, sets...)
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].id < all[j].id
+	})
+
+	// remove duplicates, which are adjacent once sorted.
+	uniq := all[:0]
+	for _, set := range all {
+		if len(uniq) == 0 || set != uniq[len(uniq)-1] {
+			uniq = append(uniq, set)
+		}
+	}
+
+	for _, set := range uniq {
+		for i := range set.shards {
+			if set == dst {
+				set.shards[i].Lock()
+			} else {
+				set.shards[i].RLock()
+			}
+		}
+	}
+
+	return func() {
+		for _, set := range uniq {
+			for i := range set.shards {
+				if set == dst {
+					set.shards[i].Unlock()
+				} else {
+					set.shards[i].RUnlock()
+				}
+			}
+		}
+	}
+}
+
+// IntersectConcurrent returns a new ConcurrentSet that contains the
+// intersection of all sets. It uses the same number of shards and hash
+// function as the first set. If no set is provided, it returns nil. If a
+// single set is provided, it returns a copy of that set (that is, it always
+// creates a new set if at least one set is provided).
+//
+// All sets are locked for the duration of the operation, so the result is
+// consistent. It runs in O(n*m) time complexity where n is the smallest
+// number of values in any set and m is the number of sets.
+func IntersectConcurrent /*[T algo.Comparable]*/ (sets ...*ConcurrentSet /*[T]*/) *ConcurrentSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeConcurrent /*[T]*/ (len(sets[0].shards), sets[0].hash)
+	IntersectConcurrentInto(s, sets...)
+	return s
+}
+
+// IntersectConcurrentInto is like IntersectConcurrent, but the intersection
+// of the sets is stored in dst. The dst set's values are not used to find
+// the intersection of values, only as destination storage. If no set is
+// provided for the intersection, then dst is untouched.
+//
+// Its time complexity is the same as for IntersectConcurrent.
+func IntersectConcurrentInto /*[T algo.Comparable]*/ (dst *ConcurrentSet /*[T]*/, sets ...*ConcurrentSet /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+	unlock := lockConcurrent(dst, sets...)
+	defer unlock()
+
+	smallest := sets[0]
+	for _, set := range sets[1:] {
+		if set.len() < smallest.len() {
+			smallest = set
+		}
+	}
+
+	// the values are collected before being added to dst, as dst may be one
+	// of the sets.
+	var vals []T
+	for _, v := range smallest.values() {
+		in := true
+		for _, set := range sets {
+			if in = set.contains(v); !in {
+				break
+			}
+		}
+		if in {
+			vals = append(vals, v)
+		}
+	}
+	dst.addLocked(vals)
+}
+
+// UnionConcurrent returns a new ConcurrentSet that is the union of all sets.
+// It uses the same number of shards and hash function as the first set. If
+// no set is provided, it returns nil. If a single set is provided, it
+// returns a copy of that set (that is, it always creates a new set if at
+// least one set is provided).
+//
+// All sets are locked for the duration of the operation, so the result is
+// consistent. It runs in O(n) time complexity where n is the total number of
+// values in all sets.
+func UnionConcurrent /*[T algo.Comparable]*/ (sets ...*ConcurrentSet /*[T]*/) *ConcurrentSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeConcurrent /*[T]*/ (len(sets[0].shards), sets[0].hash)
+	UnionConcurrentInto(s, sets...)
+	return s
+}
+
+// UnionConcurrentInto is like UnionConcurrent, but the union of the sets is
+// stored in dst. If no set is provided for the union, then dst is untouched.
+//
+// Its time complexity is the same as UnionConcurrent.
+func UnionConcurrentInto /*[T algo.Comparable]*/ (dst *ConcurrentSet /*[T]*/, sets ...*ConcurrentSet /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+	unlock := lockConcurrent(dst, sets...)
+	defer unlock()
+
+	for _, set := range sets {
+		if set != dst {
+			dst.addLocked(set.values())
+		}
+	}
+}
+
+// DiffConcurrent returns a new ConcurrentSet that is the difference of all
+// sets, that is, the values in the first set that are not in any of the
+// other sets. It uses the same number of shards and hash function as the
+// first set. If no set is provided, it returns nil. If a single set is
+// provided, it returns a copy of that set (it always creates a new set if at
+// least one set is provided).
+//
+// All sets are locked for the duration of the operation, so the result is
+// consistent. It runs in O(n*m) time complexity where n is the number of
+// values of the first set and m is the number of sets.
+func DiffConcurrent /*[T algo.Comparable]*/ (sets ...*ConcurrentSet /*[T]*/) *ConcurrentSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeConcurrent /*[T]*/ (len(sets[0].shards), sets[0].hash)
+	DiffConcurrentInto(s, sets...)
+	return s
+}
+
+// DiffConcurrentInto is like DiffConcurrent, but the difference of the sets
+// is stored in dst. The dst set's values are not used to find the difference
+// of values, only as destination storage. If no set is provided for the
+// difference, then dst is untouched.
+//
+// Its time complexity is the same as DiffConcurrent.
+func DiffConcurrentInto /*[T algo.Comparable]*/ (dst *ConcurrentSet /*[T]*/, sets ...*ConcurrentSet /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+	unlock := lockConcurrent(dst, sets...)
+	defer unlock()
+
+	var vals []T
+	for _, v := range sets[0].values() {
+		in := false
+		for _, set := range sets[1:] {
+			if in = set.contains(v); in {
+				break
+			}
+		}
+		if !in {
+			vals = append(vals, v)
+		}
+	}
+	dst.addLocked(vals)
+}
+
+// SymmetricDiffConcurrent returns a new ConcurrentSet that contains values
+// that are in either of the sets but not in any other. It uses the same
+// number of shards and hash function as the first set. If no set is
+// provided, it returns nil. If a single set is provided, it returns a copy
+// of that set (it always creates a new set if at least one set is provided).
+//
+// All sets are locked for the duration of the operation, so the result is
+// consistent. It runs in O(n*m) time complexity where n is the total number
+// of values in all sets and m is the number of sets.
+func SymmetricDiffConcurrent /*[T algo.Comparable]*/ (sets ...*ConcurrentSet /*[T]*/) *ConcurrentSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeConcurrent /*[T]*/ (len(sets[0].shards), sets[0].hash)
+	SymmetricDiffConcurrentInto(s, sets...)
+	return s
+}
+
+// SymmetricDiffConcurrentInto is like SymmetricDiffConcurrent, but the
+// resulting values are stored in dst. The dst set's values are not used to
+// find the symmetric difference, only as destination storage. If no set is
+// provided for the symmetric difference, then dst is untouched.
+//
+// Its time complexity is the same as SymmetricDiffConcurrent.
+func SymmetricDiffConcurrentInto /*[T algo.Comparable]*/ (dst *ConcurrentSet /*[T]*/, sets ...*ConcurrentSet /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+	unlock := lockConcurrent(dst, sets...)
+	defer unlock()
+
+	var vals []T
+	for i, set := range sets {
+		for _, v := range set.values() {
+			in := false
+			for j, other := range sets {
+				if i != j && other.contains(v) {
+					in = true
+					break
+				}
+			}
+			if !in {
+				vals = append(vals, v)
+			}
+		}
+	}
+	dst.addLocked(vals)
+}
+
+// adds vals to s, which must be locked for writing.
+func (s *ConcurrentSet /*[T]*/) addLocked(vals []T) {
+	for _, v := range vals {
+		s.shard(v).set.Add(v)
+	}
+}
@@ -0,0 +1,143 @@
+package sets
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func BenchmarkConcurrentSet_Add(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeConcurrentFrom(runtime.GOMAXPROCS(0), hashInt, vals...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Add(n + 1 + i)
+			}
+		})
+	}
+}
+
+func BenchmarkConcurrentSet_Contains(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeConcurrentFrom(runtime.GOMAXPROCS(0), hashInt, vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s.Contains(vals[indices[i]]) {
+					b.Fatal("Contains returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkConcurrentSet_AddIfAbsentParallel(b *testing.B) {
+	for _, shards := range []int{1, 4, 16, 64} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			s := MakeConcurrent(shards, hashInt)
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				var i int
+				for pb.Next() {
+					s.AddIfAbsent(i % 10000)
+					i++
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkConcurrentSet_MixedParallel(b *testing.B) {
+	for _, shards := range []int{1, 4, 16, 64} {
+		// 90% reads, 10% writes
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			s := MakeConcurrentFrom(shards, hashInt, sortedSlice(10000, 1)...)
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				var i int
+				for pb.Next() {
+					if i%10 == 0 {
+						s.Add(i % 20000)
+					} else {
+						s.Contains(i % 20000)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+// for comparison, a Set protected by a single mutex.
+func BenchmarkMutexSet_MixedParallel(b *testing.B) {
+	var mu sync.RWMutex
+	s := MakeFrom(sortedSlice(10000, 1)...)
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		var i int
+		for pb.Next() {
+			if i%10 == 0 {
+				mu.Lock()
+				s.Add(i % 20000)
+				mu.Unlock()
+			} else {
+				mu.RLock()
+				s.Contains(i % 20000)
+				mu.RUnlock()
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentSet_Union(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*ConcurrentSet, nsets)
+				for i := range sets {
+					sets[i] = MakeConcurrentFrom(8, hashInt, sortedSlice(n, 1, (i+1)*n)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := UnionConcurrent(sets...)
+					if s.Len() != nsets*n {
+						b.Fatalf("want len %d, got %d", nsets*n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkConcurrentSet_Intersect(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*ConcurrentSet, nsets)
+				for i := range sets {
+					sets[i] = MakeConcurrentFrom(8, hashInt, sortedSlice(n, 1)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := IntersectConcurrent(sets...)
+					if s.Len() != n {
+						b.Fatalf("want len %d, got %d", n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package sets
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestConcurrentSet(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		for _, shards := range []int{0, 1, 4} {
+			t.Run(fmt.Sprintf("shards=%d", shards), func(t *testing.T) {
+				s := MakeConcurrentFrom(shards, hashInt, 1, 2, 3)
+				if s.Len() != 3 || !s.Contains(2) || s.Contains(4) {
+					t.Fatalf("want [1 2 3], got %v", s.Values())
+				}
+				if !s.AddIfAbsent(4) || s.AddIfAbsent(4) {
+					t.Fatal("want 4 added once")
+				}
+				if !s.DeleteIfPresent(1) || s.DeleteIfPresent(1) {
+					t.Fatal("want 1 deleted once")
+				}
+				s.Delete(2)
+				s.Add(5, 6)
+
+				want := []T{3, 4, 5, 6}
+				if got := s.Values(); !cmp.Equal(want, got, cmpopts.SortSlices(sortCmpSlice)) {
+					t.Fatalf("want %v, got %v", want, got)
+				}
+				if got := s.Set(); !got.IsEqual(MakeFrom(want...)) {
+					t.Fatalf("want %v, got %v", want, got.Values())
+				}
+			})
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		s := MakeConcurrent(4, hashInt)
+		if s.Len() != 0 || s.Values() != nil || s.Set().Len() != 0 {
+			t.Fatal("want empty set")
+		}
+	})
+
+	t.Run("AddIfAbsentConcurrent", func(t *testing.T) {
+		const goroutines, n = 8, 1000
+
+		s := MakeConcurrent(16, hashInt)
+		added := make([]int, goroutines)
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < n; i++ {
+					if s.AddIfAbsent(i) {
+						added[g]++
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		var total int
+		for _, n := range added {
+			total += n
+		}
+		if total != n || s.Len() != n {
+			t.Fatalf("want %d values added, got %d (len %d)", n, total, s.Len())
+		}
+
+		deleted := make([]int, goroutines)
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < n; i++ {
+					if s.DeleteIfPresent(i) {
+						deleted[g]++
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		total = 0
+		for _, n := range deleted {
+			total += n
+		}
+		if total != n || s.Len() != 0 {
+			t.Fatalf("want %d values deleted, got %d (len %d)", n, total, s.Len())
+		}
+	})
+
+	t.Run("MixedConcurrent", func(t *testing.T) {
+		const goroutines, n = 8, 1000
+
+		s1, s2 := MakeConcurrent(8, hashInt), MakeConcurrent(4, hashInt)
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < n; i++ {
+					v := g*n + i
+					switch i % 4 {
+					case 0:
+						s1.Add(v)
+						s2.Add(v)
+					case 1:
+						s1.Delete(v - 1)
+						_ = s2.Contains(v - 1)
+					case 2:
+						// operands in a different order must not deadlock
+						if g%2 == 0 {
+							UnionConcurrentInto(s1, s1, s2)
+						} else {
+							DiffConcurrentInto(s2, s2, s1)
+						}
+					case 3:
+						_ = s1.Values()
+						_ = IntersectConcurrent(s2, s1).Len()
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		// every value added to s2 is eventually added back to s1 by a union, but
+		// it may have been deleted afterwards.
+		if got := s1.Len(); got > goroutines*n/4 {
+			t.Fatalf("want at most %d values, got %d", goroutines*n/4, got)
+		}
+	})
+
+	setOps := []struct {
+		name string
+		fn   func(...*ConcurrentSet) *ConcurrentSet
+		into func(*ConcurrentSet, ...*ConcurrentSet)
+		ref  func(...Set) Set
+	}{
+		{"Union", UnionConcurrent, UnionConcurrentInto, Union},
+		{"Intersect", IntersectConcurrent, IntersectConcurrentInto, Intersect},
+		{"Diff", DiffConcurrent, DiffConcurrentInto, Diff},
+		{"SymmetricDiff", SymmetricDiffConcurrent, SymmetricDiffConcurrentInto, SymmetricDiff},
+	}
+	setsValues := [][][]T{
+		nil,
+		{{}},
+		{sortedSlice(5, 1)},
+		{sortedSlice(5, 1), sortedSlice(3, 10)},
+		{sortedSlice(5, 1), sortedSlice(3, 2)},
+		{sortedSlice(3, 1), sortedSlice(2, 1), sortedSlice(1, 1)},
+		{{1, 3, 4, 6}, {3, 4}, {3, 4, 5}, {2, 3, 6}},
+	}
+	for _, op := range setOps {
+		t.Run(op.name, func(t *testing.T) {
+			for _, vals := range setsValues {
+				for _, dstValues := range [][]T{nil, {}, {55}} {
+					t.Run(fmt.Sprintf("%v into %v", vals, dstValues), func(t *testing.T) {
+						sets := make([]*ConcurrentSet, len(vals))
+						refs := make([]Set, len(vals))
+						for i, vs := range vals {
+							sets[i] = MakeConcurrentFrom(i+1, hashInt, vs...)
+							refs[i] = MakeFrom(vs...)
+						}
+
+						var got *ConcurrentSet
+						var want Set
+						if dstValues != nil {
+							got = MakeConcurrentFrom(3, hashInt, dstValues...)
+							op.into(got, sets...)
+							want = MakeFrom(dstValues...)
+							UnionInto(want, op.ref(refs...))
+						} else {
+							got = op.fn(sets...)
+							want = op.ref(refs...)
+						}
+
+						wantVals := want.Values()
+						sort.Ints(wantVals)
+						var gotVals []T
+						if got != nil {
+							gotVals = got.Values()
+						}
+						if !cmp.Equal(wantVals, gotVals, cmpopts.EquateEmpty(), cmpopts.SortSlices(sortCmpSlice)) {
+							t.Fatalf("want %v, got %v", wantVals, gotVals)
+						}
+					})
+				}
+			}
+		})
+	}
+
+	t.Run("IntoOperand", func(t *testing.T) {
+		s1, s2 := MakeConcurrentFrom(2, hashInt, 1, 2, 3), MakeConcurrentFrom(3, hashInt, 2, 3, 4)
+		IntersectConcurrentInto(s1, s1, s2, s1)
+		want := []T{1, 2, 3}
+		if got := s1.Values(); !cmp.Equal(want, got, cmpopts.SortSlices(sortCmpSlice)) {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+		UnionConcurrentInto(s2, s1, s2)
+		want = []T{1, 2, 3, 4}
+		if got := s2.Values(); !cmp.Equal(want, got, cmpopts.SortSlices(sortCmpSlice)) {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+	})
+}
+
+func hashInt(v int) uint64 {
+	// multiplicative hashing with the golden ratio
+	return uint64(v) * 0x9E3779B97F4A7C15
+}
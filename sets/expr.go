@@ -0,0 +1,213 @@
+package sets
+
+// Expr is a set expression, that is a set of values that may not be listed
+// in full, such as "all values except those in a Set" or "all values that
+// match a predicate". Expressions are lazily evaluated: the operands of
+// UnionExpr, IntersectExpr and DiffExpr are only used when a value is tested
+// for membership or when the expression is materialized, so the expression
+// reflects any change made to the underlying sets.
+//
+// For example, an access-control rule such as "all admins and all users of
+// the ops group, except the suspended ones" can be expressed as:
+//
+//	rule := sets.DiffExpr(
+//		sets.UnionExpr(sets.PredicateExpr(isAdmin), sets.SetExpr(opsGroup)),
+//		sets.SetExpr(suspended),
+//	)
+//	if rule.Contains(userID) {
+//		...
+//	}
+type Expr /*[T algo.Comparable]*/ interface {
+	// Contains reports whether v is in the set defined by the expression.
+	Contains(v T) bool
+
+	// Materialize returns a new Set with all values of the expression and
+	// true if the expression defines a finite set, otherwise it returns nil
+	// and false.
+	Materialize() (Set /*[T]*/, bool)
+}
+
+// SetExpr returns an expression that defines the same set as s. The set is
+// not copied, so changes to s are reflected in the expression. It is finite.
+func SetExpr /*[T algo.Comparable]*/ (s Set /*[T]*/) Expr /*[T]*/ {
+	return setExpr /*[T]*/ {s}
+}
+
+// PredicateExpr returns an expression that defines the set of all values for
+// which fn returns true. It is not finite (it cannot be materialized), as
+// its values cannot be listed.
+func PredicateExpr /*[T algo.Comparable]*/ (fn func(T) bool) Expr /*[T]*/ {
+	return predicateExpr /*[T]*/ (fn)
+}
+
+// ComplementExpr returns an expression that defines the set of all values
+// that are not in e. It is not finite, unless e is itself a complement
+// expression, in which case the complement of the complement is e's operand.
+func ComplementExpr /*[T algo.Comparable]*/ (e Expr /*[T]*/) Expr /*[T]*/ {
+	if c, ok := e.(complementExpr /*[T]*/); ok {
+		return c.e
+	}
+	return complementExpr /*[T]*/ {e}
+}
+
+// UnionExpr returns an expression that defines the union of all es. It is
+// finite if all es are finite. If no expression is provided, it defines the
+// empty set.
+func UnionExpr /*[T algo.Comparable]*/ (es ...Expr /*[T]*/) Expr /*[T]*/ {
+	return unionExpr /*[T]*/ (es)
+}
+
+// IntersectExpr returns an expression that defines the intersection of all
+// es. It is finite if any of es is finite. If no expression is provided, it
+// defines the set of all values (i.e. the complement of the empty set).
+func IntersectExpr /*[T algo.Comparable]*/ (es ...Expr /*[T]*/) Expr /*[T]*/ {
+	return intersectExpr /*[T]*/ (es)
+}
+
+// DiffExpr returns an expression that defines the difference of all es, that
+// is, the values of the first expression that are not in any of the other
+// expressions. It is finite if the first expression is finite. If no
+// expression is provided, it defines the empty set.
+func DiffExpr /*[T algo.Comparable]*/ (es ...Expr /*[T]*/) Expr /*[T]*/ {
+	return diffExpr /*[T]*/ (es)
+}
+
+type setExpr /*[T algo.Comparable]*/ struct {
+	s Set /*[T]*/
+}
+
+// Contains runs in O(1) time complexity.
+func (e setExpr /*[T]*/) Contains(v T) bool {
+	return e.s.Contains(v)
+}
+
+// Materialize runs in O(n) time complexity where n is the number of values
+// in the set.
+func (e setExpr /*[T]*/) Materialize() (Set /*[T]*/, bool) {
+	res := MakeCap /*[T]*/ (len(e.s))
+	UnionInto(res, e.s)
+	return res, true
+}
+
+type predicateExpr /*[T algo.Comparable]*/ func(T) bool
+
+// Contains has the time complexity of the predicate function.
+func (e predicateExpr /*[T]*/) Contains(v T) bool {
+	return e(v)
+}
+
+func (e predicateExpr /*[T]*/) Materialize() (Set /*[T]*/, bool) {
+	return nil, false
+}
+
+type complementExpr /*[T algo.Comparable]*/ struct {
+	e Expr /*[T]*/
+}
+
+// Contains has the time complexity of the Contains method of its operand.
+func (e complementExpr /*[T]*/) Contains(v T) bool {
+	return !e.e.Contains(v)
+}
+
+func (e complementExpr /*[T]*/) Materialize() (Set /*[T]*/, bool) {
+	return nil, false
+}
+
+type unionExpr /*[T algo.Comparable]*/ []Expr /*[T]*/
+
+// Contains has the time complexity of the Contains method of all its
+// operands, it stops at the first one that contains v.
+func (e unionExpr /*[T]*/) Contains(v T) bool {
+	for _, ee := range e {
+		if ee.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Materialize materializes all operands and returns their union.
+func (e unionExpr /*[T]*/) Materialize() (Set /*[T]*/, bool) {
+	res := Make /*[T]*/ ()
+	for _, ee := range e {
+		s, ok := ee.Materialize()
+		if !ok {
+			return nil, false
+		}
+		UnionInto(res, s)
+	}
+	return res, true
+}
+
+type intersectExpr /*[T algo.Comparable]*/ []Expr /*[T]*/
+
+// Contains has the time complexity of the Contains method of all its
+// operands, it stops at the first one that does not contain v.
+func (e intersectExpr /*[T]*/) Contains(v T) bool {
+	for _, ee := range e {
+		if !ee.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Materialize materializes the first finite operand and keeps only its
+// values that are contained in all other operands.
+func (e intersectExpr /*[T]*/) Materialize() (Set /*[T]*/, bool) {
+	for i, ee := range e {
+		s, ok := ee.Materialize()
+		if !ok {
+			continue
+		}
+
+		for k := range s {
+			for j, other := range e {
+				if j != i && !other.Contains(k) {
+					s.Delete(k)
+					break
+				}
+			}
+		}
+		return s, true
+	}
+	return nil, false
+}
+
+type diffExpr /*[T algo.Comparable]*/ []Expr /*[T]*/
+
+// Contains has the time complexity of the Contains method of all its
+// operands.
+func (e diffExpr /*[T]*/) Contains(v T) bool {
+	if len(e) == 0 || !e[0].Contains(v) {
+		return false
+	}
+	for _, ee := range e[1:] {
+		if ee.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Materialize materializes the first operand and removes its values that
+// are contained in any of the other operands.
+func (e diffExpr /*[T]*/) Materialize() (Set /*[T]*/, bool) {
+	if len(e) == 0 {
+		return Make /*[T]*/ (), true
+	}
+
+	s, ok := e[0].Materialize()
+	if !ok {
+		return nil, false
+	}
+	for k := range s {
+		for _, other := range e[1:] {
+			if other.Contains(k) {
+				s.Delete(k)
+				break
+			}
+		}
+	}
+	return s, true
+}
@@ -0,0 +1,43 @@
+package sets
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkExpr_Contains(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		// (even values or s1) except s2, with s2 being the second half of s1
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s1, s2 := MakeFrom(vals...), MakeFrom(vals[n/2:]...)
+			e := DiffExpr(
+				UnionExpr(PredicateExpr(func(v T) bool { return v%2 == 0 }), SetExpr(s1)),
+				SetExpr(s2),
+			)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				e.Contains(vals[indices[i]])
+			}
+		})
+	}
+}
+
+func BenchmarkExpr_Materialize(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		// s1 except the complement of s2, i.e. the intersection of s1 and s2
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s1, s2 := MakeFrom(sortedSlice(n, 1)...), MakeFrom(sortedSlice(n, 1, n/2+1)...)
+			e := DiffExpr(SetExpr(s1), ComplementExpr(SetExpr(s2)))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if s, ok := e.Materialize(); !ok || s.Len() != n-n/2 {
+					b.Fatalf("want %d values, got %d", n-n/2, s.Len())
+				}
+			}
+		})
+	}
+}
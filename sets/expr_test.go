@@ -0,0 +1,129 @@
+package sets
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestExpr(t *testing.T) {
+	isEven := func(v T) bool { return v%2 == 0 }
+	small := MakeFrom(sortedSlice(5)...)       // 1-5
+	large := MakeFrom(sortedSlice(5, 1, 4)...) // 4-8
+
+	cases := []struct {
+		name string
+		expr Expr
+		want []T // values of the expression in the range [0, 10)
+		fin  bool
+	}{
+		{"Set", SetExpr(small), []T{1, 2, 3, 4, 5}, true},
+		{"EmptySet", SetExpr(nil), nil, true},
+		{"Predicate", PredicateExpr(isEven), []T{0, 2, 4, 6, 8}, false},
+		{"Complement", ComplementExpr(SetExpr(small)), []T{0, 6, 7, 8, 9}, false},
+		{"ComplementComplement", ComplementExpr(ComplementExpr(SetExpr(small))), []T{1, 2, 3, 4, 5}, true},
+		{"Union", UnionExpr(SetExpr(small), SetExpr(large)), sortedSlice(8), true},
+		{"UnionEmpty", UnionExpr(), nil, true},
+		{"UnionPredicate", UnionExpr(SetExpr(small), PredicateExpr(isEven)), []T{0, 1, 2, 3, 4, 5, 6, 8}, false},
+		{"Intersect", IntersectExpr(SetExpr(small), SetExpr(large)), []T{4, 5}, true},
+		{"IntersectEmpty", IntersectExpr(), sortedSlice(10, 1, 0), false},
+		{"IntersectPredicate", IntersectExpr(PredicateExpr(isEven), SetExpr(large)), []T{4, 6, 8}, true},
+		{"IntersectComplement", IntersectExpr(ComplementExpr(SetExpr(small)), SetExpr(large)), []T{6, 7, 8}, true},
+		{"IntersectInfinite", IntersectExpr(ComplementExpr(SetExpr(small)), PredicateExpr(isEven)), []T{0, 6, 8}, false},
+		{"Diff", DiffExpr(SetExpr(small), SetExpr(large)), []T{1, 2, 3}, true},
+		{"DiffEmpty", DiffExpr(), nil, true},
+		{"DiffPredicate", DiffExpr(SetExpr(large), PredicateExpr(isEven)), []T{5, 7}, true},
+		{"DiffComplement", DiffExpr(SetExpr(large), ComplementExpr(SetExpr(small))), []T{4, 5}, true},
+		{"DiffInfinite", DiffExpr(PredicateExpr(isEven), SetExpr(small)), []T{0, 6, 8}, false},
+		{"Nested", DiffExpr(
+			UnionExpr(PredicateExpr(isEven), SetExpr(small)),
+			ComplementExpr(SetExpr(large)),
+		), []T{4, 5, 6, 8}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []T
+			for i := 0; i < 10; i++ {
+				if c.expr.Contains(i) {
+					got = append(got, i)
+				}
+			}
+			if !cmp.Equal(c.want, got, cmpopts.EquateEmpty()) {
+				t.Fatalf("want %v, got %v", c.want, got)
+			}
+
+			set, ok := c.expr.Materialize()
+			if ok != c.fin {
+				t.Fatalf("want finite %t, got %t", c.fin, ok)
+			}
+			if !ok {
+				if set != nil {
+					t.Fatalf("want nil set, got %v", set.Values())
+				}
+				return
+			}
+			vals := set.Values()
+			sort.Ints(vals)
+			if !cmp.Equal(c.want, vals, cmpopts.EquateEmpty()) {
+				t.Fatalf("want materialized %v, got %v", c.want, vals)
+			}
+		})
+	}
+
+	t.Run("Lazy", func(t *testing.T) {
+		s := MakeFrom(1, 2)
+		e := UnionExpr(SetExpr(s), SetExpr(MakeFrom(3)))
+		s.Add(4)
+		if !e.Contains(4) {
+			t.Fatal("want expression to reflect changes to the set")
+		}
+
+		// materializing returns a copy
+		m, _ := e.Materialize()
+		m.Add(5)
+		if e.Contains(5) || s.Contains(5) {
+			t.Fatal("want materialized set to be independent")
+		}
+		m, _ = SetExpr(s).Materialize()
+		m.Delete(1)
+		if !s.Contains(1) {
+			t.Fatal("want materialized set to be independent")
+		}
+	})
+
+	t.Run("AccessControl", func(t *testing.T) {
+		// all admins (IDs < 10) and all users of the ops group, except the
+		// suspended ones.
+		isAdmin := func(id T) bool { return id < 10 }
+		ops := MakeFrom(20, 21, 22)
+		suspended := MakeFrom(5, 21)
+
+		rule := DiffExpr(
+			UnionExpr(PredicateExpr(isAdmin), SetExpr(ops)),
+			SetExpr(suspended),
+		)
+		for _, id := range []T{1, 9, 20, 22} {
+			if !rule.Contains(id) {
+				t.Fatalf("want %d to be allowed", id)
+			}
+		}
+		for _, id := range []T{5, 10, 21, 23} {
+			if rule.Contains(id) {
+				t.Fatalf("want %d to be denied", id)
+			}
+		}
+
+		// restricted to a finite set of candidates, the rule can be materialized
+		candidates := MakeFrom(sortedSlice(30, 1, 0)...)
+		set, ok := IntersectExpr(rule, SetExpr(candidates)).Materialize()
+		if !ok {
+			t.Fatal("want finite set")
+		}
+		want := []T{0, 1, 2, 3, 4, 6, 7, 8, 9, 20, 22}
+		if got := set.Values(); !cmp.Equal(want, got, cmpopts.SortSlices(sortCmpSlice)) {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+	})
+}
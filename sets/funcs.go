@@ -0,0 +1,239 @@
+package sets
+
+type U = int // NOTE: generic type placeholder
+
+// Iterator is an iterator over values of some element type. Next advances
+// the iterator to the next value, which is then available via Value, and
+// returns false when there are no more values.
+type Iterator /*[T algo.Any]*/ interface {
+	Next() bool
+	Value() T
+}
+
+// Pair is a pair of values, as generated by Cartesian.
+type Pair /*[T, U algo.Comparable]*/ struct {
+	First  T
+	Second U
+}
+
+// Pop removes an arbitrary value from s and returns it, and true, or false if
+// s is empty.
+//
+// It does not allocate. It iterates over the map to find a value, so it
+// is not O(1): as a map never shrinks, it may have to skip many empty
+// buckets when s had many more values, e.g. when it is emptied by repeated
+// calls to Pop.
+func (s Set /*[T]*/) Pop() (T, bool) {
+	for k := range s {
+		delete(s, k)
+		return k, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Clone returns a copy of s. If s is nil, it returns nil.
+//
+// It runs in O(n) time complexity where n is the number of values in s.
+func (s Set /*[T]*/) Clone() Set /*[T]*/ {
+	if s == nil {
+		return nil
+	}
+	res := MakeCap /*[T]*/ (len(s))
+	for k := range s {
+		res[k] = struct{}{}
+	}
+	return res
+}
+
+// Clear removes all values from s.
+//
+// It runs in O(n) time complexity where n is the number of values in s.
+func (s Set /*[T]*/) Clear() {
+	for k := range s {
+		delete(s, k)
+	}
+}
+
+// AddAll adds all values generated by the iterator it to s, until its Next
+// method returns false.
+//
+// It runs in O(n) time complexity where n is the number of values generated
+// by the iterator.
+func (s Set /*[T]*/) AddAll(it Iterator /*[T]*/) {
+	for it.Next() {
+		s.Add(it.Value())
+	}
+}
+
+// Filter returns a new Set with the values of s for which fn returns true.
+//
+// It runs in O(n) time complexity where n is the number of values in s.
+func Filter /*[T algo.Comparable]*/ (s Set /*[T]*/, fn func(T) bool) Set /*[T]*/ {
+	res := Make /*[T]*/ ()
+	for k := range s {
+		if fn(k) {
+			res[k] = struct{}{}
+		}
+	}
+	return res
+}
+
+// Map returns a new Set with the result of calling fn for each value of s.
+// The resulting set may have fewer values than s if fn returns the same
+// value for different values of s.
+//
+// It runs in O(n) time complexity where n is the number of values in s.
+func Map /*[T, U algo.Comparable]*/ (s Set /*[T]*/, fn func(T) U) Set /*[U]*/ {
+	res := MakeCap /*[U]*/ (len(s))
+	for k := range s {
+		res[fn(k)] = struct{}{}
+	}
+	return res
+}
+
+// EqualFunc returns true if a and b contain equivalent values according to
+// the eq function, that is if each value of a has an equivalent value in b
+// and each value of b has an equivalent value in a. The eq function should
+// define an equivalence relation under which the values of each set are
+// distinct, e.g. a case-insensitive comparison of strings where each set
+// does not contain multiple spellings of the same string.
+//
+// It runs in O(n*m) time complexity where n and m are the number of values
+// in a and b, as each value of a may be compared with each value of b.
+func EqualFunc /*[T, U algo.Comparable]*/ (a Set /*[T]*/, b Set /*[U]*/, eq func(T, U) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	// with distinct values under eq and the same number of values, it is
+	// enough to check that each value of b has an equivalent in a.
+	for w := range b {
+		found := false
+		for v := range a {
+			if found = eq(v, w); found {
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Cartesian returns the Cartesian product of a and b, that is all pairs
+// where the first value comes from a and the second value from b. The order
+// of the pairs is undefined.
+//
+// It runs in O(n*m) time complexity where n and m are the number of values
+// in a and b.
+func Cartesian /*[T, U algo.Comparable]*/ (a Set /*[T]*/, b Set /*[U]*/) []Pair /*[T, U]*/ {
+	var pairs []Pair /*[T, U]*/
+	if len(a) > 0 && len(b) > 0 {
+		pairs = make([]Pair /*[T, U]*/, 0, len(a)*len(b))
+		for v := range a {
+			for w := range b {
+				pairs = append(pairs, Pair /*[T, U]*/ {First: v, Second: w})
+			}
+		}
+	}
+	return pairs
+}
+
+// PowerSet returns all subsets of s, including the empty set and a copy of
+// s. The order of the subsets is undefined. There are 2^n subsets where n is
+// the number of values in s, and it panics if s has more than 20 values, as
+// the result would require an unreasonable amount of memory. For larger
+// sets, use slices.MakePowerSet on the values of s, which iterates over the
+// subsets without holding them all in memory.
+//
+// It runs in O(2^n * n) time complexity.
+func PowerSet /*[T algo.Comparable]*/ (s Set /*[T]*/) []Set /*[T]*/ {
+	if len(s) > maxPowerSetLen {
+		panic("sets: too many values for PowerSet")
+	}
+
+	vals := s.Values()
+	res := make([]Set /*[T]*/, 1<<uint(len(vals)))
+
+	// the bits of the index of each subset indicate which values it contains
+	for i := range res {
+		sub := Make /*[T]*/ ()
+		for j, v := range vals {
+			if i&(1<<uint(j)) != 0 {
+				sub[v] = struct{}{}
+			}
+		}
+		res[i] = sub
+	}
+	return res
+}
+
+const maxPowerSetLen = 20
+
+// Jaccard returns the Jaccard similarity coefficient of a and b, that is the
+// number of values in their intersection divided by the number of values in
+// their union. It is a value between 0 (no common value) and 1 (same
+// values). If both sets are empty, it returns 1.
+//
+// It runs in O(n) time complexity where n is the number of values in the
+// smallest set. It does not allocate.
+func Jaccard /*[T algo.Comparable]*/ (a, b Set /*[T]*/) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	n := intersectLen(a, b)
+	return float64(n) / float64(len(a)+len(b)-n)
+}
+
+// Dice returns the Sørensen-Dice similarity coefficient of a and b, that is
+// twice the number of values in their intersection divided by the sum of
+// the number of values in each set. It is a value between 0 (no common
+// value) and 1 (same values). If both sets are empty, it returns 1.
+//
+// It runs in O(n) time complexity where n is the number of values in the
+// smallest set. It does not allocate.
+func Dice /*[T algo.Comparable]*/ (a, b Set /*[T]*/) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	return 2 * float64(intersectLen(a, b)) / float64(len(a)+len(b))
+}
+
+// Overlap returns the overlap coefficient (or Szymkiewicz-Simpson
+// coefficient) of a and b, that is the number of values in their
+// intersection divided by the number of values in the smallest set. It is a
+// value between 0 (no common value) and 1 (one set is a subset of the
+// other). If both sets are empty, it returns 1, and if only one of them is
+// empty, it returns 0.
+//
+// It runs in O(n) time complexity where n is the number of values in the
+// smallest set. It does not allocate.
+func Overlap /*[T algo.Comparable]*/ (a, b Set /*[T]*/) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	smallest := len(a)
+	if len(b) < smallest {
+		smallest = len(b)
+	}
+	if smallest == 0 {
+		return 0
+	}
+	return float64(intersectLen(a, b)) / float64(smallest)
+}
+
+// returns the number of values in the intersection of a and b.
+func intersectLen /*[T algo.Comparable]*/ (a, b Set /*[T]*/) int {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var n int
+	for k := range a {
+		if b.Contains(k) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,98 @@
+package sets
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkSet_Pop(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		// pop a value and add it back, so that the set never gets empty
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeFrom(sortedSlice(n, 1)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				v, ok := s.Pop()
+				if !ok {
+					b.Fatal("Pop returned false")
+				}
+				s.Add(v)
+			}
+		})
+	}
+}
+
+func BenchmarkSet_Clone(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeFrom(sortedSlice(n, 1)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if c := s.Clone(); c.Len() != n {
+					b.Fatalf("want len %d, got %d", n, c.Len())
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeFrom(sortedSlice(n, 1)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				Filter(s, func(v T) bool { return v%2 == 0 })
+			}
+		})
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeFrom(sortedSlice(n, 1)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := Map(s, func(v T) U { return v * 2 }); got.Len() != n {
+					b.Fatalf("want len %d, got %d", n, got.Len())
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkJaccard(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		// the sets share half of their values
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s1, s2 := MakeFrom(sortedSlice(n, 1)...), MakeFrom(sortedSlice(n, 1, n/2+1)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if Jaccard(s1, s2) == 1 && n > 1 {
+					b.Fatal("want sets to be different")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPowerSet(b *testing.B) {
+	for _, n := range []int{1, 5, 10, 15, 20} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeFrom(sortedSlice(n, 1)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := PowerSet(s); len(got) != 1<<uint(n) {
+					b.Fatalf("want %d subsets, got %d", 1<<uint(n), len(got))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package sets
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestPopCloneClear(t *testing.T) {
+	var nilSet Set
+	if _, ok := nilSet.Pop(); ok {
+		t.Fatal("want no value popped")
+	}
+	if nilSet.Clone() != nil {
+		t.Fatal("want nil clone")
+	}
+	nilSet.Clear()
+
+	s := MakeFrom(1, 2, 3)
+	c := s.Clone()
+	var popped []T
+	for {
+		v, ok := s.Pop()
+		if !ok {
+			break
+		}
+		popped = append(popped, v)
+	}
+	if s.Len() != 0 {
+		t.Fatalf("want empty set, got %v", s.Values())
+	}
+	if want := []T{1, 2, 3}; !cmp.Equal(want, popped, cmpopts.SortSlices(sortCmpSlice)) {
+		t.Fatalf("want %v, got %v", want, popped)
+	}
+	if want := []T{1, 2, 3}; !cmp.Equal(want, c.Values(), cmpopts.SortSlices(sortCmpSlice)) {
+		t.Fatalf("want clone to be unchanged, got %v", c.Values())
+	}
+
+	c.Clear()
+	if c.Len() != 0 {
+		t.Fatalf("want empty set, got %v", c.Values())
+	}
+	c.Add(4)
+	if !c.Contains(4) {
+		t.Fatal("want cleared set to be usable")
+	}
+}
+
+type sliceIterator struct {
+	vals []T
+	cur  T
+}
+
+func (it *sliceIterator) Next() bool {
+	if len(it.vals) == 0 {
+		return false
+	}
+	it.cur, it.vals = it.vals[0], it.vals[1:]
+	return true
+}
+
+func (it *sliceIterator) Value() T {
+	return it.cur
+}
+
+func TestAddAll(t *testing.T) {
+	s := MakeFrom(1)
+	s.AddAll(&sliceIterator{vals: []T{2, 3, 2, 1}})
+	if want := []T{1, 2, 3}; !cmp.Equal(want, s.Values(), cmpopts.SortSlices(sortCmpSlice)) {
+		t.Fatalf("want %v, got %v", want, s.Values())
+	}
+	s.AddAll(&sliceIterator{})
+	if s.Len() != 3 {
+		t.Fatalf("want 3 values, got %v", s.Values())
+	}
+}
+
+func TestFilterMap(t *testing.T) {
+	s := MakeFrom(sortedSlice(10)...)
+
+	got := Filter(s, func(v T) bool { return v%3 == 0 })
+	if want := []T{3, 6, 9}; !cmp.Equal(want, got.Values(), cmpopts.SortSlices(sortCmpSlice)) {
+		t.Fatalf("want %v, got %v", want, got.Values())
+	}
+	if got := Filter(nil, func(v T) bool { return true }); got == nil || got.Len() != 0 {
+		t.Fatalf("want empty set, got %v", got)
+	}
+
+	got = Map(s, func(v T) U { return v / 3 })
+	if want := []U{0, 1, 2, 3}; !cmp.Equal(want, got.Values(), cmpopts.SortSlices(sortCmpSlice)) {
+		t.Fatalf("want %v, got %v", want, got.Values())
+	}
+	if s.Len() != 10 {
+		t.Fatal("want source set unchanged")
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	// values are equivalent modulo 10
+	eq := func(v T, w U) bool { return v%10 == w%10 }
+	cases := []struct {
+		a, b []T
+		want bool
+	}{
+		{nil, nil, true},
+		{nil, []T{1}, false},
+		{[]T{1}, []T{11}, true},
+		{[]T{1, 2}, []T{11, 3}, false},
+		{[]T{1, 2, 13}, []T{23, 11, 2}, true},
+		{[]T{1, 2}, []T{11, 12, 13}, false},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v<=>%v", c.a, c.b), func(t *testing.T) {
+			a, b := MakeFrom(c.a...), MakeFrom(c.b...)
+			if got := EqualFunc(a, b, eq); got != c.want {
+				t.Fatalf("want %t, got %t", c.want, got)
+			}
+		})
+	}
+}
+
+func TestCartesian(t *testing.T) {
+	lessPair := func(a, b Pair) bool {
+		if a.First != b.First {
+			return a.First < b.First
+		}
+		return a.Second < b.Second
+	}
+	cases := []struct {
+		a, b []T
+		want []Pair
+	}{
+		{nil, nil, nil},
+		{[]T{1}, nil, nil},
+		{nil, []T{1}, nil},
+		{[]T{1}, []T{2}, []Pair{{1, 2}}},
+		{[]T{1, 2}, []T{3, 4, 5}, []Pair{{1, 3}, {1, 4}, {1, 5}, {2, 3}, {2, 4}, {2, 5}}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v*%v", c.a, c.b), func(t *testing.T) {
+			got := Cartesian(MakeFrom(c.a...), MakeFrom(c.b...))
+			if !cmp.Equal(c.want, got, cmpopts.SortSlices(lessPair)) {
+				t.Fatalf("want %v, got %v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestPowerSet(t *testing.T) {
+	// each subset is represented as a sorted, comma-separated string
+	subsetString := func(s Set) string {
+		vals := s.Values()
+		sort.Ints(vals)
+		strs := make([]string, len(vals))
+		for i, v := range vals {
+			strs[i] = fmt.Sprint(v)
+		}
+		return strings.Join(strs, ",")
+	}
+
+	cases := []struct {
+		vals []T
+		want []string
+	}{
+		{nil, []string{""}},
+		{[]T{1}, []string{"", "1"}},
+		{[]T{1, 2, 3}, []string{"", "1", "2", "3", "1,2", "1,3", "2,3", "1,2,3"}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.vals), func(t *testing.T) {
+			subsets := PowerSet(MakeFrom(c.vals...))
+			got := make([]string, len(subsets))
+			for i, sub := range subsets {
+				got[i] = subsetString(sub)
+			}
+			if !cmp.Equal(c.want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })) {
+				t.Fatalf("want %q, got %q", c.want, got)
+			}
+		})
+	}
+
+	t.Run("TooLarge", func(t *testing.T) {
+		defer func() {
+			if e := recover(); e == nil {
+				t.Fatal("want panic")
+			}
+		}()
+		PowerSet(MakeFrom(sortedSlice(maxPowerSetLen + 1)...))
+	})
+}
+
+func TestSimilarity(t *testing.T) {
+	cases := []struct {
+		a, b                   []T
+		jaccard, dice, overlap float64
+	}{
+		{nil, nil, 1, 1, 1},
+		{[]T{1}, nil, 0, 0, 0},
+		{[]T{1, 2}, []T{3, 4}, 0, 0, 0},
+		{[]T{1, 2}, []T{1, 2}, 1, 1, 1},
+		{[]T{1, 2, 3}, []T{2, 3, 4}, 2.0 / 4, 4.0 / 6, 2.0 / 3},
+		{[]T{1, 2}, []T{1, 2, 3, 4}, 2.0 / 4, 4.0 / 6, 1},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v<=>%v", c.a, c.b), func(t *testing.T) {
+			a, b := MakeFrom(c.a...), MakeFrom(c.b...)
+			checks := []struct {
+				name string
+				fn   func(a, b Set) float64
+				want float64
+			}{
+				{"Jaccard", Jaccard, c.jaccard},
+				{"Dice", Dice, c.dice},
+				{"Overlap", Overlap, c.overlap},
+			}
+			for _, check := range checks {
+				got1, got2 := check.fn(a, b), check.fn(b, a)
+				if math.Abs(got1-check.want) > 1e-9 || got1 != got2 {
+					t.Fatalf("%s: want %f, got %f and %f", check.name, check.want, got1, got2)
+				}
+			}
+		})
+	}
+}
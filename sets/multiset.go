@@ -0,0 +1,329 @@
+package sets
+
+import "sort"
+
+// Multiset is a set of values that tracks how many times each value occurs,
+// also known as a bag. Its zero-value is ready to use.
+type Multiset /*[T algo.Comparable]*/ struct {
+	counts map[T]int
+	total  int
+}
+
+// ValueCount is a value of a Multiset with its number of occurrences.
+type ValueCount /*[T algo.Comparable]*/ struct {
+	Value T
+	Count int
+}
+
+// MakeMulti returns a multiset of some element type.
+func MakeMulti /*[T algo.Comparable]*/ () *Multiset /*[T]*/ {
+	return &Multiset{
+		counts: make(map[T]int),
+	}
+}
+
+// MakeMultiCap returns a multiset of some element type with an initial
+// capacity of distinct values.
+func MakeMultiCap /*[T algo.Comparable]*/ (capacity int) *Multiset /*[T]*/ {
+	return &Multiset{
+		counts: make(map[T]int, capacity),
+	}
+}
+
+// MakeMultiFrom returns a multiset of some element type initialized with the
+// provided values, each value being added once for each time it is
+// provided.
+func MakeMultiFrom /*[T algo.Comparable]*/ (vs ...T) *Multiset /*[T]*/ {
+	s := MakeMulti /*[T]*/ ()
+	for _, v := range vs {
+		s.Add(v, 1)
+	}
+	return s
+}
+
+// Add adds n occurrences of v to the multiset s. It panics if n is
+// negative.
+//
+// It runs in O(1) (amortized) time complexity.
+func (s *Multiset /*[T]*/) Add(v T, n int) {
+	if n < 0 {
+		panic("sets: negative Multiset count")
+	}
+	if n == 0 {
+		return
+	}
+	if s.counts == nil {
+		s.counts = make(map[T]int)
+	}
+	s.counts[v] += n
+	s.total += n
+}
+
+// Remove removes n occurrences of v from the multiset s. If v has fewer than
+// n occurrences, all its occurrences are removed. It panics if n is
+// negative.
+//
+// It runs in O(1) time complexity.
+func (s *Multiset /*[T]*/) Remove(v T, n int) {
+	if n < 0 {
+		panic("sets: negative Multiset count")
+	}
+	c := s.counts[v]
+	if c == 0 {
+		return
+	}
+	if n >= c {
+		delete(s.counts, v)
+		s.total -= c
+		return
+	}
+	s.counts[v] = c - n
+	s.total -= n
+}
+
+// Count returns the number of occurrences of v in s, which is 0 if v is not
+// in s.
+//
+// It runs in O(1) time complexity.
+func (s *Multiset /*[T]*/) Count(v T) int {
+	return s.counts[v]
+}
+
+// Distinct returns the number of distinct values in s.
+func (s *Multiset /*[T]*/) Distinct() int {
+	return len(s.counts)
+}
+
+// TotalLen returns the total number of occurrences of all values in s, that
+// is the sum of the counts of all distinct values.
+func (s *Multiset /*[T]*/) TotalLen() int {
+	return s.total
+}
+
+// Values returns a slice of all distinct values present in the multiset. The
+// order is undefined.
+//
+// It runs in O(n) time complexity where n is the number of distinct values.
+func (s *Multiset /*[T]*/) Values() []T {
+	var vals []T
+	if len(s.counts) > 0 {
+		vals = make([]T, 0, len(s.counts))
+		for k := range s.counts {
+			vals = append(vals, k)
+		}
+	}
+	return vals
+}
+
+// MostCommon returns the k distinct values of s with the largest number of
+// occurrences, with their count, in descending order of count. The order of
+// values with the same count is undefined. If k is negative or greater than
+// the number of distinct values, all values are returned.
+//
+// It runs in O(n log n) time complexity where n is the number of distinct
+// values.
+func (s *Multiset /*[T]*/) MostCommon(k int) []ValueCount /*[T]*/ {
+	if k < 0 || k > len(s.counts) {
+		k = len(s.counts)
+	}
+	if k == 0 {
+		return nil
+	}
+
+	vcs := make([]ValueCount /*[T]*/, 0, len(s.counts))
+	for v, c := range s.counts {
+		vcs = append(vcs, ValueCount /*[T]*/ {Value: v, Count: c})
+	}
+	sort.Slice(vcs, func(i, j int) bool {
+		return vcs[i].Count > vcs[j].Count
+	})
+	return vcs[:k:k]
+}
+
+// IsSubset returns true if every value of s occurs at most as many times in
+// other, false otherwise.
+//
+// It runs in O(n) time complexity where n is the number of distinct values
+// in s.
+func (s *Multiset /*[T]*/) IsSubset(other *Multiset /*[T]*/) bool {
+	if s.total > other.total || len(s.counts) > len(other.counts) {
+		return false
+	}
+	for v, c := range s.counts {
+		if other.counts[v] < c {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEqual returns true if s contains the same values as other with the same
+// number of occurrences, false otherwise.
+//
+// It runs in O(n) time complexity where n is the number of distinct values
+// in s.
+func (s *Multiset /*[T]*/) IsEqual(other *Multiset /*[T]*/) bool {
+	return s.total == other.total && len(s.counts) == len(other.counts) && s.IsSubset(other)
+}
+
+// UnionMulti returns a new Multiset that is the union of all sets, where the
+// number of occurrences of each value is the maximum of its counts in all
+// sets. If no set is provided, it returns nil. If a single set is provided,
+// it returns a copy of that set (that is, it always creates a new set if at
+// least one set is provided).
+//
+// It runs in O(n) time complexity where n is the total number of distinct
+// values in all sets.
+func UnionMulti /*[T algo.Comparable]*/ (sets ...*Multiset /*[T]*/) *Multiset /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeMulti /*[T]*/ ()
+	UnionMultiInto(s, sets...)
+	return s
+}
+
+// UnionMultiInto is like UnionMulti, but the union of the sets is stored in
+// dst, which is part of the union (that is, the count of each value in dst
+// is the maximum of its count in dst and in all sets). If no set is provided
+// for the union, then dst is untouched.
+//
+// Its time complexity is the same as UnionMulti.
+func UnionMultiInto /*[T algo.Comparable]*/ (dst *Multiset /*[T]*/, sets ...*Multiset /*[T]*/) {
+	for _, set := range sets {
+		for v, c := range set.counts {
+			if cur := dst.Count(v); c > cur {
+				dst.Add(v, c-cur)
+			}
+		}
+	}
+}
+
+// SumMulti returns a new Multiset that is the sum of all sets, where the
+// number of occurrences of each value is the sum of its counts in all sets.
+// If no set is provided, it returns nil. If a single set is provided, it
+// returns a copy of that set (that is, it always creates a new set if at
+// least one set is provided).
+//
+// It runs in O(n) time complexity where n is the total number of distinct
+// values in all sets.
+func SumMulti /*[T algo.Comparable]*/ (sets ...*Multiset /*[T]*/) *Multiset /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeMulti /*[T]*/ ()
+	SumMultiInto(s, sets...)
+	return s
+}
+
+// SumMultiInto is like SumMulti, but the sum of the sets is stored in dst,
+// which is part of the sum (that is, the counts of all sets are added to
+// dst). If no set is provided for the sum, then dst is untouched.
+//
+// Its time complexity is the same as SumMulti.
+func SumMultiInto /*[T algo.Comparable]*/ (dst *Multiset /*[T]*/, sets ...*Multiset /*[T]*/) {
+	for _, set := range sets {
+		for v, c := range set.counts {
+			dst.Add(v, c)
+		}
+	}
+}
+
+// IntersectMulti returns a new Multiset that contains the intersection of
+// all sets, where the number of occurrences of each value is the minimum of
+// its counts in all sets. If no set is provided, it returns nil. If a single
+// set is provided, it returns a copy of that set (that is, it always creates
+// a new set if at least one set is provided).
+//
+// It runs in O(n*m) time complexity where n is the smallest number of
+// distinct values in any set and m is the number of sets.
+func IntersectMulti /*[T algo.Comparable]*/ (sets ...*Multiset /*[T]*/) *Multiset /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeMulti /*[T]*/ ()
+	IntersectMultiInto(s, sets...)
+	return s
+}
+
+// IntersectMultiInto is like IntersectMulti, but the counts of the
+// intersection of the sets are added to dst. The dst set's values are not
+// used to find the intersection of values, only as destination storage. If
+// no set is provided for the intersection, then dst is untouched.
+//
+// Its time complexity is the same as for IntersectMulti.
+func IntersectMultiInto /*[T algo.Comparable]*/ (dst *Multiset /*[T]*/, sets ...*Multiset /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+
+	// start with the set that has the fewest values, as this is the maximum
+	// number of elements that the intersection may generate.
+	smallest := sets[0]
+	for _, set := range sets[1:] {
+		if set.Distinct() < smallest.Distinct() {
+			smallest = set
+		}
+	}
+
+	// the counts are collected before being added to dst, as dst may be one
+	// of the sets.
+	var vcs []ValueCount /*[T]*/
+	for v, c := range smallest.counts {
+		for _, set := range sets {
+			if sc := set.Count(v); sc < c {
+				c = sc
+			}
+		}
+		if c > 0 {
+			vcs = append(vcs, ValueCount /*[T]*/ {Value: v, Count: c})
+		}
+	}
+	for _, vc := range vcs {
+		dst.Add(vc.Value, vc.Count)
+	}
+}
+
+// DiffMulti returns a new Multiset that is the difference of all sets, where
+// the number of occurrences of each value is its count in the first set
+// minus its counts in all other sets, if that is positive. If no set is
+// provided, it returns nil. If a single set is provided, it returns a copy of
+// that set (it always creates a new set if at least one set is provided).
+//
+// It runs in O(n*m) time complexity where n is the number of distinct values
+// of the first set and m is the number of sets.
+func DiffMulti /*[T algo.Comparable]*/ (sets ...*Multiset /*[T]*/) *Multiset /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeMulti /*[T]*/ ()
+	DiffMultiInto(s, sets...)
+	return s
+}
+
+// DiffMultiInto is like DiffMulti, but the counts of the difference of the
+// sets are added to dst. The dst set's values are not used to find the
+// difference of values, only as destination storage. If no set is provided
+// for the difference, then dst is untouched.
+//
+// Its time complexity is the same as DiffMulti.
+func DiffMultiInto /*[T algo.Comparable]*/ (dst *Multiset /*[T]*/, sets ...*Multiset /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+
+	// the counts are collected before being added to dst, as dst may be one
+	// of the sets.
+	var vcs []ValueCount /*[T]*/
+	for v, c := range sets[0].counts {
+		for _, set := range sets[1:] {
+			c -= set.Count(v)
+		}
+		if c > 0 {
+			vcs = append(vcs, ValueCount /*[T]*/ {Value: v, Count: c})
+		}
+	}
+	for _, vc := range vcs {
+		dst.Add(vc.Value, vc.Count)
+	}
+}
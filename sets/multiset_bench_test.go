@@ -0,0 +1,106 @@
+package sets
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkMultiset_Add(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeMultiFrom(vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Add(vals[indices[i]], 2)
+			}
+		})
+	}
+}
+
+func BenchmarkMultiset_Remove(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeMultiFrom(vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Remove(vals[indices[i]], 1)
+			}
+		})
+	}
+}
+
+func BenchmarkMultiset_Count(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeMultiFrom(vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if s.Count(vals[indices[i]]) != 1 {
+					b.Fatal("Count returned the wrong count")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMultiset_MostCommon(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeMulti()
+			for i, v := range sortedSlice(n, 1) {
+				s.Add(v, i%100+1)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if vcs := s.MostCommon(10); len(vcs) == 0 {
+					b.Fatal("MostCommon returned no value")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMultiset_Union(b *testing.B) {
+	benchmarkMultisetOp(b, UnionMulti)
+}
+
+func BenchmarkMultiset_Sum(b *testing.B) {
+	benchmarkMultisetOp(b, SumMulti)
+}
+
+func BenchmarkMultiset_Intersect(b *testing.B) {
+	benchmarkMultisetOp(b, IntersectMulti)
+}
+
+func BenchmarkMultiset_Diff(b *testing.B) {
+	benchmarkMultisetOp(b, DiffMulti)
+}
+
+func benchmarkMultisetOp(b *testing.B, fn func(...*Multiset) *Multiset) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			// each set overlaps half of the values of the previous one
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*Multiset, nsets)
+				for i := range sets {
+					sets[i] = MakeMultiFrom(sortedSlice(n, 1, i*n/2)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					fn(sets...)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package sets
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestMultiset(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var s Multiset
+		if s.Distinct() != 0 || s.TotalLen() != 0 || s.Values() != nil || s.MostCommon(1) != nil {
+			t.Fatal("want empty set")
+		}
+		s.Remove(1, 1)
+		s.Add(1, 2)
+		if s.Count(1) != 2 || s.Distinct() != 1 || s.TotalLen() != 2 {
+			t.Fatal("want single value")
+		}
+	})
+
+	t.Run("AddRemove", func(t *testing.T) {
+		s := MakeMultiFrom(1, 2, 2, 3, 3, 3)
+		if s.Distinct() != 3 || s.TotalLen() != 6 {
+			t.Fatalf("want 3 distinct and 6 total, got %d and %d", s.Distinct(), s.TotalLen())
+		}
+
+		s.Add(4, 0)
+		if s.Count(4) != 0 || s.Distinct() != 3 {
+			t.Fatal("want 4 not added")
+		}
+		s.Remove(3, 2)
+		if s.Count(3) != 1 || s.TotalLen() != 4 {
+			t.Fatalf("want count 1 and total 4, got %d and %d", s.Count(3), s.TotalLen())
+		}
+		s.Remove(2, 10)
+		if s.Count(2) != 0 || s.Distinct() != 2 || s.TotalLen() != 2 {
+			t.Fatalf("want 2 removed, got count %d, %d distinct and %d total", s.Count(2), s.Distinct(), s.TotalLen())
+		}
+		s.Remove(5, 1)
+		if s.TotalLen() != 2 {
+			t.Fatalf("want total 2, got %d", s.TotalLen())
+		}
+		if got := s.Values(); !cmp.Equal([]T{1, 3}, got, cmpopts.SortSlices(sortCmpSlice)) {
+			t.Fatalf("want %v, got %v", []T{1, 3}, got)
+		}
+	})
+
+	t.Run("NegativeCount", func(t *testing.T) {
+		for _, fn := range []func(*Multiset){
+			func(s *Multiset) { s.Add(1, -1) },
+			func(s *Multiset) { s.Remove(1, -1) },
+		} {
+			func() {
+				defer func() {
+					if e := recover(); e == nil {
+						t.Fatal("want panic")
+					}
+				}()
+				fn(MakeMultiFrom(1))
+			}()
+		}
+	})
+
+	t.Run("MostCommon", func(t *testing.T) {
+		s := MakeMultiFrom(1, 2, 2, 3, 3, 3, 4, 4, 4, 4)
+		cases := []struct {
+			k    int
+			want []ValueCount
+		}{
+			{0, nil},
+			{1, []ValueCount{{4, 4}}},
+			{2, []ValueCount{{4, 4}, {3, 3}}},
+			{4, []ValueCount{{4, 4}, {3, 3}, {2, 2}, {1, 1}}},
+			{5, []ValueCount{{4, 4}, {3, 3}, {2, 2}, {1, 1}}},
+			{-1, []ValueCount{{4, 4}, {3, 3}, {2, 2}, {1, 1}}},
+		}
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%d", c.k), func(t *testing.T) {
+				got := s.MostCommon(c.k)
+				if !cmp.Equal(c.want, got) {
+					t.Fatalf("want %v, got %v", c.want, got)
+				}
+			})
+		}
+	})
+
+	t.Run("IsSubsetEqual", func(t *testing.T) {
+		cases := []struct {
+			vals1, vals2 []T
+			subset       bool
+			equal        bool
+		}{
+			{nil, nil, true, true},
+			{nil, []T{1}, true, false},
+			{[]T{1}, []T{1}, true, true},
+			{[]T{1, 1}, []T{1}, false, false},
+			{[]T{1}, []T{1, 1}, true, false},
+			{[]T{1, 2}, []T{1, 1, 3}, false, false},
+			{[]T{2, 1, 2}, []T{2, 2, 1}, true, true},
+		}
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%v<=>%v", c.vals1, c.vals2), func(t *testing.T) {
+				s1, s2 := MakeMultiFrom(c.vals1...), MakeMultiFrom(c.vals2...)
+				if got := s1.IsSubset(s2); got != c.subset {
+					t.Fatalf("want subset %t, got %t", c.subset, got)
+				}
+				if eq1, eq2 := s1.IsEqual(s2), s2.IsEqual(s1); eq1 != c.equal || eq2 != c.equal {
+					t.Fatalf("want equal %t, got %t and %t", c.equal, eq1, eq2)
+				}
+			})
+		}
+	})
+
+	setOps := []struct {
+		name string
+		fn   func(...*Multiset) *Multiset
+		into func(*Multiset, ...*Multiset)
+	}{
+		{"Union", UnionMulti, UnionMultiInto},
+		{"Sum", SumMulti, SumMultiInto},
+		{"Intersect", IntersectMulti, IntersectMultiInto},
+		{"Diff", DiffMulti, DiffMultiInto},
+	}
+	cases := []struct {
+		sets [][]T
+		dst  []T
+		want map[string][]T // per set operation
+	}{
+		{
+			sets: nil,
+			want: map[string][]T{},
+		},
+		{
+			sets: [][]T{{1, 1, 2}},
+			want: map[string][]T{
+				"Union":     {1, 1, 2},
+				"Sum":       {1, 1, 2},
+				"Intersect": {1, 1, 2},
+				"Diff":      {1, 1, 2},
+			},
+		},
+		{
+			sets: [][]T{{1, 1, 2, 3, 3, 3}, {1, 2, 2, 3}},
+			want: map[string][]T{
+				"Union":     {1, 1, 2, 2, 3, 3, 3},
+				"Sum":       {1, 1, 1, 2, 2, 2, 3, 3, 3, 3},
+				"Intersect": {1, 2, 3},
+				"Diff":      {1, 3, 3},
+			},
+		},
+		{
+			sets: [][]T{{1, 1, 1, 2, 2, 4}, {1, 2, 2, 3}, {1, 3}},
+			want: map[string][]T{
+				"Union":     {1, 1, 1, 2, 2, 3, 4},
+				"Sum":       {1, 1, 1, 1, 1, 2, 2, 2, 2, 3, 3, 4},
+				"Intersect": {1},
+				"Diff":      {1, 4},
+			},
+		},
+		{
+			sets: [][]T{{1, 1, 2}, {1, 3}},
+			dst:  []T{1, 1, 1, 5},
+			want: map[string][]T{
+				"Union":     {1, 1, 1, 2, 3, 5},
+				"Sum":       {1, 1, 1, 1, 1, 1, 2, 3, 5},
+				"Intersect": {1, 1, 1, 1, 5},
+				"Diff":      {1, 1, 1, 1, 2, 5},
+			},
+		},
+	}
+	for _, op := range setOps {
+		t.Run(op.name, func(t *testing.T) {
+			for _, c := range cases {
+				t.Run(fmt.Sprintf("%v into %v", c.sets, c.dst), func(t *testing.T) {
+					sets := make([]*Multiset, len(c.sets))
+					for i, vs := range c.sets {
+						sets[i] = MakeMultiFrom(vs...)
+					}
+
+					var got *Multiset
+					if c.dst != nil {
+						got = MakeMultiFrom(c.dst...)
+						op.into(got, sets...)
+					} else {
+						got = op.fn(sets...)
+					}
+
+					want := c.want[op.name]
+					if got == nil {
+						if want != nil {
+							t.Fatalf("want %v, got nil", want)
+						}
+						return
+					}
+					if !got.IsEqual(MakeMultiFrom(want...)) {
+						t.Fatalf("want %v, got %v", want, got.counts)
+					}
+				})
+			}
+		})
+	}
+
+	t.Run("IntoSelf", func(t *testing.T) {
+		want := map[string][]T{
+			"Union":     {1, 1, 2, 3},
+			"Sum":       {1, 1, 1, 1, 1, 2, 2, 3},
+			"Intersect": {1, 1, 1, 2},
+			"Diff":      {1, 1, 1, 2, 2},
+		}
+		for _, op := range setOps {
+			t.Run(op.name, func(t *testing.T) {
+				s1, s2 := MakeMultiFrom(1, 1, 2), MakeMultiFrom(1, 3)
+				op.into(s1, s1, s2)
+				if want := want[op.name]; !s1.IsEqual(MakeMultiFrom(want...)) {
+					t.Fatalf("want %v, got %v", want, s1.counts)
+				}
+			})
+		}
+	})
+}
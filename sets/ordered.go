@@ -0,0 +1,552 @@
+package sets
+
+// OrderedSet is a set of ordered values, backed by a balanced binary search
+// tree (an AVL tree). Unlike Set, its values are kept in ascending order as
+// defined by the standard <, <=, >, >= operators, so that they can be
+// iterated in order and queried by range. Its zero-value is ready to use.
+type OrderedSet /*[T algo.OrderedComparable]*/ struct {
+	root *avlNode /*[T]*/
+	len  int
+}
+
+type avlNode /*[T algo.OrderedComparable]*/ struct {
+	val         T
+	left, right *avlNode /*[T]*/
+	height      int
+}
+
+// MakeOrdered returns an ordered set of some element type.
+func MakeOrdered /*[T algo.OrderedComparable]*/ () *OrderedSet /*[T]*/ {
+	return new(OrderedSet)
+}
+
+// MakeOrderedFrom returns an ordered set of some element type initialized
+// with the provided values.
+func MakeOrderedFrom /*[T algo.OrderedComparable]*/ (vs ...T) *OrderedSet /*[T]*/ {
+	s := MakeOrdered /*[T]*/ ()
+	s.Add(vs...)
+	return s
+}
+
+// Add adds value(s) to the set s. If v is already in s this has no effect.
+//
+// It runs in O(log n) time complexity (O(m log n) with respect to the number
+// m of values to add).
+func (s *OrderedSet /*[T]*/) Add(vs ...T) {
+	for _, v := range vs {
+		var added bool
+		s.root, added = s.root.insert(v)
+		if added {
+			s.len++
+		}
+	}
+}
+
+// Delete removes v from the set s. If v is not in s this has no effect.
+//
+// It runs in O(log n) time complexity (O(m log n) with respect to the number
+// m of values to delete).
+func (s *OrderedSet /*[T]*/) Delete(vs ...T) {
+	for _, v := range vs {
+		var removed bool
+		s.root, removed = s.root.remove(v)
+		if removed {
+			s.len--
+		}
+	}
+}
+
+// Contains reports whether v is in s.
+//
+// It runs in O(log n) time complexity.
+func (s *OrderedSet /*[T]*/) Contains(v T) bool {
+	n := s.root
+	for n != nil {
+		switch {
+		case v < n.val:
+			n = n.left
+		case v > n.val:
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Len reports the number of elements in s.
+func (s *OrderedSet /*[T]*/) Len() int {
+	return s.len
+}
+
+// Values returns a slice of all values present in the set, in ascending
+// order.
+//
+// It runs in O(n) time complexity where n is the number of values in the set.
+func (s *OrderedSet /*[T]*/) Values() []T {
+	var vals []T
+	if s.len > 0 {
+		vals = make([]T, 0, s.len)
+		s.Ascend(func(v T) bool {
+			vals = append(vals, v)
+			return true
+		})
+	}
+	return vals
+}
+
+// Ascend calls fn for each value of the set, in ascending order, until all
+// values have been visited or fn returns false. The set must not be modified
+// during the iteration.
+//
+// It runs in O(n) time complexity where n is the number of values in the set.
+func (s *OrderedSet /*[T]*/) Ascend(fn func(T) bool) {
+	s.root.ascend(fn)
+}
+
+// Min returns the smallest value of the set and true, or the zero value of T
+// and false if the set is empty.
+//
+// It runs in O(log n) time complexity.
+func (s *OrderedSet /*[T]*/) Min() (T, bool) {
+	var v T
+	n := s.root
+	if n == nil {
+		return v, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.val, true
+}
+
+// Max returns the largest value of the set and true, or the zero value of T
+// and false if the set is empty.
+//
+// It runs in O(log n) time complexity.
+func (s *OrderedSet /*[T]*/) Max() (T, bool) {
+	var v T
+	n := s.root
+	if n == nil {
+		return v, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.val, true
+}
+
+// Floor returns the largest value of the set that is smaller than or equal
+// to v and true, or the zero value of T and false if there is no such value.
+//
+// It runs in O(log n) time complexity.
+func (s *OrderedSet /*[T]*/) Floor(v T) (T, bool) {
+	var floor T
+	var found bool
+	for n := s.root; n != nil; {
+		switch {
+		case v < n.val:
+			n = n.left
+		case v > n.val:
+			// this is a candidate, but there may be a larger one on the right
+			floor, found = n.val, true
+			n = n.right
+		default:
+			return n.val, true
+		}
+	}
+	return floor, found
+}
+
+// Ceiling returns the smallest value of the set that is larger than or equal
+// to v and true, or the zero value of T and false if there is no such value.
+//
+// It runs in O(log n) time complexity.
+func (s *OrderedSet /*[T]*/) Ceiling(v T) (T, bool) {
+	var ceil T
+	var found bool
+	for n := s.root; n != nil; {
+		switch {
+		case v > n.val:
+			n = n.right
+		case v < n.val:
+			// this is a candidate, but there may be a smaller one on the left
+			ceil, found = n.val, true
+			n = n.left
+		default:
+			return n.val, true
+		}
+	}
+	return ceil, found
+}
+
+// Range returns a slice of the values of the set that are in the half-open
+// range [lo, hi), in ascending order. It returns nil if there is no such
+// value.
+//
+// It runs in O(log n + m) time complexity where m is the number of values
+// returned.
+func (s *OrderedSet /*[T]*/) Range(lo, hi T) []T {
+	var vals []T
+	s.root.ascendRange(lo, hi, func(v T) bool {
+		vals = append(vals, v)
+		return true
+	})
+	return vals
+}
+
+// IsDisjoint returns true if the intersection of s and other is an empty
+// set, false otherwise.
+//
+// It runs in O(n log m) time complexity where n is the number of values in
+// the smallest set between s and other, and m the number of values in the
+// largest.
+func (s *OrderedSet /*[T]*/) IsDisjoint(other *OrderedSet /*[T]*/) bool {
+	iter, cmp := s, other
+	if other.Len() < s.Len() {
+		iter, cmp = other, s
+	}
+	disjoint := true
+	iter.Ascend(func(v T) bool {
+		disjoint = !cmp.Contains(v)
+		return disjoint
+	})
+	return disjoint
+}
+
+// IsSubset returns true if every value of s is in other, false otherwise. If
+// strict is true and s contains the same values as other, then it returns
+// false.
+//
+// It runs in O(n log m) time complexity where n is the number of values in s
+// and m the number of values in other.
+func (s *OrderedSet /*[T]*/) IsSubset(other *OrderedSet /*[T]*/, strict bool) bool {
+	if (!strict && s.Len() <= other.Len()) || (strict && s.Len() < other.Len()) {
+		subset := true
+		s.Ascend(func(v T) bool {
+			subset = other.Contains(v)
+			return subset
+		})
+		return subset
+	}
+	return false
+}
+
+// IsSuperset returns true if every value of other is in s, false otherwise.
+// If strict is true and other contains the same values as s, then it returns
+// false.
+//
+// It runs in O(n log m) time complexity where n is the number of values in
+// other and m the number of values in s.
+func (s *OrderedSet /*[T]*/) IsSuperset(other *OrderedSet /*[T]*/, strict bool) bool {
+	return other.IsSubset(s, strict)
+}
+
+// IsEqual returns true if s contains the same values as other, false
+// otherwise.
+//
+// It runs in O(n log n) time complexity where n is the number of values in s.
+func (s *OrderedSet /*[T]*/) IsEqual(other *OrderedSet /*[T]*/) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	return s.IsSubset(other, false)
+}
+
+// IntersectOrdered returns a new OrderedSet that contains the intersection of
+// all sets. If no set is provided, it returns nil. If a single set is
+// provided, it returns a copy of that set (that is, it always creates a new
+// set if at least one set is provided).
+//
+// It runs in O(n*m log k) time complexity where n is the smallest number of
+// values in any set, m is the number of sets to intersect and k is the
+// largest number of values in any set.
+func IntersectOrdered /*[T algo.OrderedComparable]*/ (sets ...*OrderedSet /*[T]*/) *OrderedSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeOrdered /*[T]*/ ()
+	IntersectOrderedInto(s, sets...)
+	return s
+}
+
+// IntersectOrderedInto is like IntersectOrdered, but the intersection of the
+// sets is stored in dst. The dst set's values are not used to find the
+// intersection of values, only as destination storage. If no set is
+// provided, then dst is untouched.
+//
+// Its time complexity is the same as for IntersectOrdered.
+func IntersectOrderedInto /*[T algo.OrderedComparable]*/ (dst *OrderedSet /*[T]*/, sets ...*OrderedSet /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+
+	// iterate over the values of the smallest set and add those that are in
+	// all other sets. As the sets are not modified, dst can be filled directly
+	// even if it is one of the sets, and no temporary set is required.
+	smallest := sets[0]
+	for _, set := range sets[1:] {
+		if set.Len() < smallest.Len() {
+			smallest = set
+		}
+	}
+	for _, v := range smallest.Values() {
+		inAll := true
+		for _, set := range sets {
+			if set != smallest && !set.Contains(v) {
+				inAll = false
+				break
+			}
+		}
+		if inAll {
+			dst.Add(v)
+		}
+	}
+}
+
+// UnionOrdered returns a new OrderedSet that is the union of all sets. If no
+// set is provided, it returns nil. If a single set is provided, it returns a
+// copy of that set (that is, it always creates a new set if at least one set
+// is provided).
+//
+// It runs in O(n log n) time complexity where n is the total number of values
+// in all sets.
+func UnionOrdered /*[T algo.OrderedComparable]*/ (sets ...*OrderedSet /*[T]*/) *OrderedSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeOrdered /*[T]*/ ()
+	UnionOrderedInto(s, sets...)
+	return s
+}
+
+// UnionOrderedInto is like UnionOrdered, but the union of the sets is stored
+// in dst. If no set is provided for the union, then dst is untouched.
+//
+// Its time complexity is the same as UnionOrdered.
+func UnionOrderedInto /*[T algo.OrderedComparable]*/ (dst *OrderedSet /*[T]*/, sets ...*OrderedSet /*[T]*/) {
+	for _, set := range sets {
+		if set == dst {
+			continue
+		}
+		set.Ascend(func(v T) bool {
+			dst.Add(v)
+			return true
+		})
+	}
+}
+
+// DiffOrdered returns a new OrderedSet that is the difference of all sets,
+// that is, the values in the first set that are not in any of the other
+// sets. If no set is provided, it returns nil. If a single set is provided,
+// it returns a copy of that set (it always creates a new set if at least one
+// set is provided).
+//
+// It runs in O(n*m log k) time complexity where n is the number of values of
+// the first set, m is the number of sets and k is the largest number of
+// values in any set.
+func DiffOrdered /*[T algo.OrderedComparable]*/ (sets ...*OrderedSet /*[T]*/) *OrderedSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeOrdered /*[T]*/ ()
+	DiffOrderedInto(s, sets...)
+	return s
+}
+
+// DiffOrderedInto is like DiffOrdered, but the difference of the sets is
+// stored in dst. The dst set's values are not used to find the difference of
+// values, only as destination storage. If no set is provided for the
+// difference, then dst is untouched.
+//
+// Its time complexity is the same as DiffOrdered.
+func DiffOrderedInto /*[T algo.OrderedComparable]*/ (dst *OrderedSet /*[T]*/, sets ...*OrderedSet /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+
+	for _, v := range sets[0].Values() {
+		inOther := false
+		for _, set := range sets[1:] {
+			if set.Contains(v) {
+				inOther = true
+				break
+			}
+		}
+		if !inOther {
+			dst.Add(v)
+		}
+	}
+}
+
+// SymmetricDiffOrdered returns a new OrderedSet that contains values that are
+// in either of the sets but not in any other. If no set is provided, it
+// returns nil. If a single set is provided, it returns a copy of that set (it
+// always creates a new set if at least one set is provided).
+//
+// It runs in O(n*m log k) time complexity where n is the total number of
+// values in all sets, m is the number of sets and k is the largest number of
+// values in any set.
+func SymmetricDiffOrdered /*[T algo.OrderedComparable]*/ (sets ...*OrderedSet /*[T]*/) *OrderedSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeOrdered /*[T]*/ ()
+	SymmetricDiffOrderedInto(s, sets...)
+	return s
+}
+
+// SymmetricDiffOrderedInto is like SymmetricDiffOrdered, but the resulting
+// values are stored in dst. The dst set's values are not used to find the
+// symmetric difference, only as destination storage. If no set is provided
+// for the symmetric difference, then dst is untouched.
+//
+// Its time complexity is the same as SymmetricDiffOrdered.
+func SymmetricDiffOrderedInto /*[T algo.OrderedComparable]*/ (dst *OrderedSet /*[T]*/, sets ...*OrderedSet /*[T]*/) {
+	// collect the values first, so that dst can be one of the sets.
+	var vals []T
+	for i, set := range sets {
+		set.Ascend(func(v T) bool {
+			for j, other := range sets {
+				if i != j && other.Contains(v) {
+					return true
+				}
+			}
+			vals = append(vals, v)
+			return true
+		})
+	}
+	dst.Add(vals...)
+}
+
+func (n *avlNode /*[T]*/) ascend(fn func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.ascend(fn) && fn(n.val) && n.right.ascend(fn)
+}
+
+func (n *avlNode /*[T]*/) ascendRange(lo, hi T, fn func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	// only visit the subtrees that may contain values in the range
+	if lo < n.val && !n.left.ascendRange(lo, hi, fn) {
+		return false
+	}
+	if lo <= n.val && n.val < hi && !fn(n.val) {
+		return false
+	}
+	if n.val < hi {
+		return n.right.ascendRange(lo, hi, fn)
+	}
+	return true
+}
+
+// inserts v in the subtree rooted at n and returns the new root of the
+// subtree, and whether v was added.
+func (n *avlNode /*[T]*/) insert(v T) (*avlNode /*[T]*/, bool) {
+	if n == nil {
+		return &avlNode{val: v, height: 1}, true
+	}
+
+	var added bool
+	switch {
+	case v < n.val:
+		n.left, added = n.left.insert(v)
+	case v > n.val:
+		n.right, added = n.right.insert(v)
+	default:
+		return n, false
+	}
+	return n.rebalance(), added
+}
+
+// removes v from the subtree rooted at n and returns the new root of the
+// subtree, and whether v was removed.
+func (n *avlNode /*[T]*/) remove(v T) (*avlNode /*[T]*/, bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var removed bool
+	switch {
+	case v < n.val:
+		n.left, removed = n.left.remove(v)
+	case v > n.val:
+		n.right, removed = n.right.remove(v)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+
+		// replace the value with its successor, the smallest value of the right
+		// subtree, and remove that successor.
+		succ := n.right
+		for succ.left != nil {
+			succ = succ.left
+		}
+		n.val = succ.val
+		n.right, _ = n.right.remove(succ.val)
+		removed = true
+	}
+	return n.rebalance(), removed
+}
+
+func (n *avlNode /*[T]*/) h() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *avlNode /*[T]*/) updateHeight() {
+	n.height = n.left.h()
+	if rh := n.right.h(); rh > n.height {
+		n.height = rh
+	}
+	n.height++
+}
+
+// restores the AVL property of n (the heights of its subtrees differ by at
+// most one), assuming its subtrees are valid AVL trees, and returns the new
+// root of the subtree.
+func (n *avlNode /*[T]*/) rebalance() *avlNode /*[T]*/ {
+	n.updateHeight()
+	switch balance := n.left.h() - n.right.h(); {
+	case balance > 1:
+		// left-heavy, if the left child is right-heavy, it is a left-right case
+		// that requires a double rotation.
+		if n.left.left.h() < n.left.right.h() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case balance < -1:
+		if n.right.right.h() < n.right.left.h() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (n *avlNode /*[T]*/) rotateLeft() *avlNode /*[T]*/ {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.updateHeight()
+	r.updateHeight()
+	return r
+}
+
+func (n *avlNode /*[T]*/) rotateRight() *avlNode /*[T]*/ {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.updateHeight()
+	l.updateHeight()
+	return l
+}
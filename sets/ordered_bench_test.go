@@ -0,0 +1,186 @@
+package sets
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkOrderedSet_Add(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeOrderedFrom(vals...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Add(n + 1 + i)
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedSet_Delete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeOrderedFrom(vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Delete(vals[indices[i]])
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedSet_Contains(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeOrderedFrom(vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s.Contains(vals[indices[i]]) {
+					b.Fatal("Contains returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedSet_Floor(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 2)
+			s := MakeOrderedFrom(vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, ok := s.Floor(vals[indices[i]] + 1); !ok {
+					b.Fatal("Floor returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedSet_Range(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		// the number of values in the range is constant, so this is O(log n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeOrderedFrom(vals...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := s.Range(n/2, n/2+10); len(got) == 0 {
+					b.Fatal("Range returned no value")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedSet_Union(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*OrderedSet, nsets)
+				for i := range sets {
+					sets[i] = MakeOrderedFrom(sortedSlice(n, 1, (i+1)*n)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := UnionOrdered(sets...)
+					if s.Len() != nsets*n {
+						b.Fatalf("want len %d, got %d", nsets*n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkOrderedSet_Intersect(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*OrderedSet, nsets)
+				for i := range sets {
+					sets[i] = MakeOrderedFrom(sortedSlice(n, 1)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := IntersectOrdered(sets...)
+					if s.Len() != n {
+						b.Fatalf("want len %d, got %d", n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkOrderedSet_Diff(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*OrderedSet, nsets)
+				for i := range sets {
+					sets[i] = MakeOrderedFrom(sortedSlice(n, 1, (i+1)*n)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := DiffOrdered(sets...)
+					if s.Len() != n {
+						b.Fatalf("want len %d, got %d", n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkOrderedSet_SymmetricDiff(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*OrderedSet, nsets)
+				for i := range sets {
+					sets[i] = MakeOrderedFrom(sortedSlice(n, 1, (i+1)*n)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := SymmetricDiffOrdered(sets...)
+					if s.Len() != nsets*n {
+						b.Fatalf("want len %d, got %d", nsets*n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkOrderedSet_IsSubset(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s1, s2 := MakeOrderedFrom(vals...), MakeOrderedFrom(vals...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsSubset(s2, false) {
+					b.Fatal("want is subset to return true")
+				}
+			}
+		})
+	}
+}
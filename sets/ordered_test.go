@@ -0,0 +1,266 @@
+package sets
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestOrderedSet(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var s OrderedSet
+		if s.Len() != 0 || s.Values() != nil {
+			t.Fatal("want empty set")
+		}
+		if _, ok := s.Min(); ok {
+			t.Fatal("want no min")
+		}
+		if _, ok := s.Max(); ok {
+			t.Fatal("want no max")
+		}
+		if _, ok := s.Floor(1); ok {
+			t.Fatal("want no floor")
+		}
+		if _, ok := s.Ceiling(1); ok {
+			t.Fatal("want no ceiling")
+		}
+		s.Add(1)
+		if !s.Contains(1) || s.Len() != 1 {
+			t.Fatal("want single value")
+		}
+	})
+
+	t.Run("AddDeleteRandom", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		s := MakeOrdered()
+		ref := Make()
+		for i := 0; i < 10000; i++ {
+			v := r.Intn(1000)
+			if r.Intn(3) == 0 {
+				s.Delete(v)
+				ref.Delete(v)
+			} else {
+				s.Add(v)
+				ref.Add(v)
+			}
+		}
+
+		want := ref.Values()
+		sort.Ints(want)
+		if got := s.Values(); !cmp.Equal(want, got) {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+		if s.Len() != ref.Len() {
+			t.Fatalf("want len %d, got %d", ref.Len(), s.Len())
+		}
+		checkAVL(t, s.root)
+	})
+
+	t.Run("MinMaxFloorCeiling", func(t *testing.T) {
+		s := MakeOrderedFrom(50, 10, 40, 20, 30)
+		if v, ok := s.Min(); !ok || v != 10 {
+			t.Fatalf("want min %d, got %d", 10, v)
+		}
+		if v, ok := s.Max(); !ok || v != 50 {
+			t.Fatalf("want max %d, got %d", 50, v)
+		}
+
+		cases := []struct {
+			v           int
+			floor, ceil int // -1 if none
+		}{
+			{5, -1, 10},
+			{10, 10, 10},
+			{15, 10, 20},
+			{30, 30, 30},
+			{49, 40, 50},
+			{50, 50, 50},
+			{51, 50, -1},
+		}
+		for _, c := range cases {
+			floor, ok := s.Floor(c.v)
+			if !ok {
+				floor = -1
+			}
+			if floor != c.floor {
+				t.Fatalf("Floor(%d): want %d, got %d", c.v, c.floor, floor)
+			}
+			ceil, ok := s.Ceiling(c.v)
+			if !ok {
+				ceil = -1
+			}
+			if ceil != c.ceil {
+				t.Fatalf("Ceiling(%d): want %d, got %d", c.v, c.ceil, ceil)
+			}
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		s := MakeOrderedFrom(sortedSlice(10, 10)...)
+		cases := []struct {
+			lo, hi int
+			want   []T
+		}{
+			{0, 10, nil},
+			{0, 11, []T{10}},
+			{10, 30, []T{10, 20}},
+			{15, 45, []T{20, 30, 40}},
+			{100, 200, []T{100}},
+			{101, 200, nil},
+			{50, 40, nil},
+			{0, 1000, sortedSlice(10, 10)},
+		}
+		for _, c := range cases {
+			if got := s.Range(c.lo, c.hi); !cmp.Equal(c.want, got) {
+				t.Fatalf("Range(%d, %d): want %v, got %v", c.lo, c.hi, c.want, got)
+			}
+		}
+	})
+
+	t.Run("Ascend", func(t *testing.T) {
+		s := MakeOrderedFrom(sortedSlice(10, 1)...)
+		var got []T
+		s.Ascend(func(v T) bool {
+			got = append(got, v)
+			return v < 3
+		})
+		if !cmp.Equal([]T{1, 2, 3}, got) {
+			t.Fatalf("want %v, got %v", []T{1, 2, 3}, got)
+		}
+	})
+
+	setOps := []struct {
+		name string
+		fn   func(...*OrderedSet) *OrderedSet
+		into func(*OrderedSet, ...*OrderedSet)
+		ref  func(...Set) Set
+	}{
+		{"Union", UnionOrdered, UnionOrderedInto, Union},
+		{"Intersect", IntersectOrdered, IntersectOrderedInto, Intersect},
+		{"Diff", DiffOrdered, DiffOrderedInto, Diff},
+		{"SymmetricDiff", SymmetricDiffOrdered, SymmetricDiffOrderedInto, SymmetricDiff},
+	}
+	setsValues := [][][]T{
+		nil,
+		{{}},
+		{sortedSlice(5, 1)},
+		{sortedSlice(5, 1), sortedSlice(3, 10)},
+		{sortedSlice(5, 1), sortedSlice(3, 2)},
+		{sortedSlice(3, 1), sortedSlice(2, 1), sortedSlice(1, 1)},
+		{{1, 3, 4, 6}, {3, 4}, {3, 4, 5}, {2, 3, 6}},
+	}
+	for _, op := range setOps {
+		t.Run(op.name, func(t *testing.T) {
+			for _, vals := range setsValues {
+				for _, dstValues := range [][]T{nil, {}, {55}} {
+					t.Run(fmt.Sprintf("%v into %v", vals, dstValues), func(t *testing.T) {
+						sets := make([]*OrderedSet, len(vals))
+						refs := make([]Set, len(vals))
+						for i, vs := range vals {
+							sets[i] = MakeOrderedFrom(vs...)
+							refs[i] = MakeFrom(vs...)
+						}
+
+						var got *OrderedSet
+						var want Set
+						if dstValues != nil {
+							got = MakeOrderedFrom(dstValues...)
+							op.into(got, sets...)
+							want = MakeFrom(dstValues...)
+							UnionInto(want, op.ref(refs...))
+						} else {
+							got = op.fn(sets...)
+							want = op.ref(refs...)
+						}
+
+						wantVals := want.Values()
+						sort.Ints(wantVals)
+						var gotVals []T
+						if got != nil {
+							gotVals = got.Values()
+						}
+						if !cmp.Equal(wantVals, gotVals, cmpopts.EquateEmpty()) {
+							t.Fatalf("want %v, got %v", wantVals, gotVals)
+						}
+					})
+				}
+			}
+		})
+	}
+
+	t.Run("IsDisjointSubsetSupersetEqual", func(t *testing.T) {
+		cases := []struct {
+			vals1    []T
+			vals2    []T
+			disjoint bool
+			subset   bool
+			strict   bool
+		}{
+			{nil, nil, true, true, false},
+			{nil, []T{1}, true, true, true},
+			{[]T{1}, []T{1}, false, true, false},
+			{[]T{1}, []T{1, 2}, false, true, true},
+			{[]T{3, 4}, []T{1, 2}, true, false, false},
+			{[]T{1, 2, 3, 4}, []T{1, 2, 4, 5}, false, false, false},
+		}
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%v<=>%v", c.vals1, c.vals2), func(t *testing.T) {
+				s1, s2 := MakeOrderedFrom(c.vals1...), MakeOrderedFrom(c.vals2...)
+
+				if dis1, dis2 := s1.IsDisjoint(s2), s2.IsDisjoint(s1); dis1 != c.disjoint || dis2 != c.disjoint {
+					t.Fatalf("want disjoint %t, got %t and %t", c.disjoint, dis1, dis2)
+				}
+				if sub := s1.IsSubset(s2, false); sub != c.subset {
+					t.Fatalf("want subset %t, got %t", c.subset, sub)
+				}
+				if substr := s1.IsSubset(s2, true); substr != (c.subset && c.strict) {
+					t.Fatalf("want strict subset %t, got %t", c.subset && c.strict, substr)
+				}
+				if sup := s2.IsSuperset(s1, false); sup != c.subset {
+					t.Fatalf("want superset %t, got %t", c.subset, sup)
+				}
+				if supstr := s2.IsSuperset(s1, true); supstr != (c.subset && c.strict) {
+					t.Fatalf("want strict superset %t, got %t", c.subset && c.strict, supstr)
+				}
+				wantEq := c.subset && !c.strict
+				if eq1, eq2 := s1.IsEqual(s2), s2.IsEqual(s1); eq1 != wantEq || eq2 != wantEq {
+					t.Fatalf("want equal %t, got %t and %t", wantEq, eq1, eq2)
+				}
+			})
+		}
+	})
+}
+
+// checks the AVL invariants of the tree rooted at n and returns its height.
+func checkAVL(t *testing.T, n *avlNode) int {
+	t.Helper()
+	if n == nil {
+		return 0
+	}
+	if n.left != nil && n.left.val >= n.val {
+		t.Fatalf("left child %d is not smaller than %d", n.left.val, n.val)
+	}
+	if n.right != nil && n.right.val <= n.val {
+		t.Fatalf("right child %d is not larger than %d", n.right.val, n.val)
+	}
+	lh, rh := checkAVL(t, n.left), checkAVL(t, n.right)
+	if lh-rh > 1 || rh-lh > 1 {
+		t.Fatalf("node %d is unbalanced: %d vs %d", n.val, lh, rh)
+	}
+	h := lh
+	if rh > h {
+		h = rh
+	}
+	if n.height != h+1 {
+		t.Fatalf("node %d: want height %d, got %d", n.val, h+1, n.height)
+	}
+	return h + 1
+}
@@ -0,0 +1,367 @@
+package sets
+
+import "sort"
+
+// Range is a half-open interval of values [Lo, Hi), that is it contains all
+// values v such that Lo <= v < Hi. It is empty if Lo >= Hi.
+type Range /*[T algo.Ordered]*/ struct {
+	Lo, Hi T
+}
+
+// IsEmpty returns true if r contains no value.
+func (r Range /*[T]*/) IsEmpty() bool {
+	return r.Lo >= r.Hi
+}
+
+// Contains reports whether v is in r.
+func (r Range /*[T]*/) Contains(v T) bool {
+	return r.Lo <= v && v < r.Hi
+}
+
+// RangeSet is a set of values represented as a sorted list of disjoint,
+// normalized ranges, such as port ranges, byte ranges or time windows. The
+// ranges are normalized so that no two ranges overlap or are adjacent (e.g.
+// [1, 3) and [3, 5) are merged as [1, 5)), so that two sets with the same
+// values have the same ranges. Its zero-value is ready to use.
+type RangeSet /*[T algo.Ordered]*/ struct {
+	ranges []Range /*[T]*/
+}
+
+// MakeRanges returns a range set of some element type.
+func MakeRanges /*[T algo.Ordered]*/ () *RangeSet /*[T]*/ {
+	return new(RangeSet)
+}
+
+// MakeRangesFrom returns a range set of some element type initialized with
+// the provided ranges.
+func MakeRangesFrom /*[T algo.Ordered]*/ (rs ...Range /*[T]*/) *RangeSet /*[T]*/ {
+	s := MakeRanges /*[T]*/ ()
+	s.Add(rs...)
+	return s
+}
+
+// Add adds the values of the range(s) to the set s, merging them with the
+// existing ranges that overlap or are adjacent. Empty ranges are ignored.
+//
+// It runs in O(n) time complexity where n is the number of ranges in s, as
+// the ranges may need to be moved to make room for the new one (O(n*m) with
+// respect to the number of ranges to add). Finding where the range goes is
+// O(log n).
+func (s *RangeSet /*[T]*/) Add(rs ...Range /*[T]*/) {
+	for _, r := range rs {
+		if r.IsEmpty() {
+			continue
+		}
+
+		// ranges [i, j) overlap or are adjacent to r
+		i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi >= r.Lo })
+		j := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Lo > r.Hi })
+		if i < j {
+			if s.ranges[i].Lo < r.Lo {
+				r.Lo = s.ranges[i].Lo
+			}
+			if s.ranges[j-1].Hi > r.Hi {
+				r.Hi = s.ranges[j-1].Hi
+			}
+		}
+		s.replace(i, j, r)
+	}
+}
+
+// Remove removes the values of the range(s) from the set s, splitting the
+// existing ranges that partially overlap. Empty ranges are ignored.
+//
+// Its time complexity is the same as Add.
+func (s *RangeSet /*[T]*/) Remove(rs ...Range /*[T]*/) {
+	for _, r := range rs {
+		if r.IsEmpty() {
+			continue
+		}
+
+		// ranges [i, j) overlap r
+		i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi > r.Lo })
+		j := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Lo >= r.Hi })
+		if i >= j {
+			continue
+		}
+
+		// keep the parts of the first and last ranges that are outside r
+		var pieces [2]Range /*[T]*/
+		n := 0
+		if first := s.ranges[i]; first.Lo < r.Lo {
+			pieces[n] = Range /*[T]*/ {Lo: first.Lo, Hi: r.Lo}
+			n++
+		}
+		if last := s.ranges[j-1]; last.Hi > r.Hi {
+			pieces[n] = Range /*[T]*/ {Lo: r.Hi, Hi: last.Hi}
+			n++
+		}
+		s.replace(i, j, pieces[:n]...)
+	}
+}
+
+// replaces the ranges [i, j) with rs.
+func (s *RangeSet /*[T]*/) replace(i, j int, rs ...Range /*[T]*/) {
+	n := len(s.ranges) - (j - i) + len(rs)
+	if n > len(s.ranges) {
+		s.ranges = append(s.ranges, make([]Range /*[T]*/, n-len(s.ranges))...)
+	}
+	copy(s.ranges[i+len(rs):], s.ranges[j:])
+	copy(s.ranges[i:], rs)
+	s.ranges = s.ranges[:n]
+}
+
+// Contains reports whether v is in s.
+//
+// It runs in O(log n) time complexity where n is the number of ranges in s.
+func (s *RangeSet /*[T]*/) Contains(v T) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi > v })
+	return i < len(s.ranges) && s.ranges[i].Lo <= v
+}
+
+// Overlaps reports whether any value of r is in s. It returns false if r is
+// empty.
+//
+// It runs in O(log n) time complexity where n is the number of ranges in s.
+func (s *RangeSet /*[T]*/) Overlaps(r Range /*[T]*/) bool {
+	if r.IsEmpty() {
+		return false
+	}
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi > r.Lo })
+	return i < len(s.ranges) && s.ranges[i].Lo < r.Hi
+}
+
+// Len reports the number of disjoint ranges in s. Note that this is not the
+// number of values in s.
+func (s *RangeSet /*[T]*/) Len() int {
+	return len(s.ranges)
+}
+
+// Ranges returns a slice of the disjoint ranges of s, in ascending order.
+//
+// It runs in O(n) time complexity where n is the number of ranges in s.
+func (s *RangeSet /*[T]*/) Ranges() []Range /*[T]*/ {
+	var rs []Range /*[T]*/
+	if len(s.ranges) > 0 {
+		rs = make([]Range /*[T]*/, len(s.ranges))
+		copy(rs, s.ranges)
+	}
+	return rs
+}
+
+// Gaps returns a slice of the ranges of values that are between the ranges
+// of s, in ascending order, that is the complement of s between its
+// smallest and largest values.
+//
+// It runs in O(n) time complexity where n is the number of ranges in s.
+func (s *RangeSet /*[T]*/) Gaps() []Range /*[T]*/ {
+	var gaps []Range /*[T]*/
+	if len(s.ranges) > 1 {
+		gaps = make([]Range /*[T]*/, 0, len(s.ranges)-1)
+		for i := 1; i < len(s.ranges); i++ {
+			gaps = append(gaps, Range /*[T]*/ {Lo: s.ranges[i-1].Hi, Hi: s.ranges[i].Lo})
+		}
+	}
+	return gaps
+}
+
+// Complement returns a new RangeSet with the values of bounds that are not
+// in s.
+//
+// It runs in O(n) time complexity where n is the number of ranges in s.
+func (s *RangeSet /*[T]*/) Complement(bounds Range /*[T]*/) *RangeSet /*[T]*/ {
+	var b RangeSet /*[T]*/
+	b.Add(bounds)
+	return &RangeSet /*[T]*/ {ranges: diffRanges(b.ranges, s.ranges)}
+}
+
+// IsEqual returns true if s contains the same values as other, false
+// otherwise.
+//
+// It runs in O(n) time complexity where n is the number of ranges in s.
+func (s *RangeSet /*[T]*/) IsEqual(other *RangeSet /*[T]*/) bool {
+	if len(s.ranges) != len(other.ranges) {
+		return false
+	}
+	for i, r := range s.ranges {
+		if other.ranges[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectRanges returns a new RangeSet that contains the intersection of
+// all sets. If no set is provided, it returns nil. If a single set is
+// provided, it returns a copy of that set (that is, it always creates a new
+// set if at least one set is provided).
+//
+// It runs in O(n) time complexity where n is the total number of ranges in
+// all sets.
+func IntersectRanges /*[T algo.Ordered]*/ (sets ...*RangeSet /*[T]*/) *RangeSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeRanges /*[T]*/ ()
+	IntersectRangesInto(s, sets...)
+	return s
+}
+
+// IntersectRangesInto is like IntersectRanges, but the intersection of the
+// sets is stored in dst. The dst set's values are not used to find the
+// intersection of values, only as destination storage. If no set is
+// provided, then dst is untouched.
+//
+// Its time complexity is the same as for IntersectRanges.
+func IntersectRangesInto /*[T algo.Ordered]*/ (dst *RangeSet /*[T]*/, sets ...*RangeSet /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+
+	res := sets[0].ranges
+	for _, set := range sets[1:] {
+		res = intersectRanges(res, set.ranges)
+	}
+	dst.ranges = unionRanges(dst.ranges, res)
+}
+
+// UnionRanges returns a new RangeSet that is the union of all sets. If no
+// set is provided, it returns nil. If a single set is provided, it returns a
+// copy of that set (that is, it always creates a new set if at least one set
+// is provided).
+//
+// It runs in O(n * m) time complexity where n is the total number of ranges
+// in all sets and m is the number of sets (i.e. for all practical purposes
+// where a handful of sets are provided, it runs in O(n)).
+func UnionRanges /*[T algo.Ordered]*/ (sets ...*RangeSet /*[T]*/) *RangeSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeRanges /*[T]*/ ()
+	UnionRangesInto(s, sets...)
+	return s
+}
+
+// UnionRangesInto is like UnionRanges, but the union of the sets is stored
+// in dst. If no set is provided for the union, then dst is untouched.
+//
+// Its time complexity is the same as UnionRanges.
+func UnionRangesInto /*[T algo.Ordered]*/ (dst *RangeSet /*[T]*/, sets ...*RangeSet /*[T]*/) {
+	for _, set := range sets {
+		dst.ranges = unionRanges(dst.ranges, set.ranges)
+	}
+}
+
+// DiffRanges returns a new RangeSet that is the difference of all sets, that
+// is, the values in the first set that are not in any of the other sets. If
+// no set is provided, it returns nil. If a single set is provided, it
+// returns a copy of that set (it always creates a new set if at least one
+// set is provided).
+//
+// It runs in O(n) time complexity where n is the total number of ranges in
+// all sets.
+func DiffRanges /*[T algo.Ordered]*/ (sets ...*RangeSet /*[T]*/) *RangeSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeRanges /*[T]*/ ()
+	DiffRangesInto(s, sets...)
+	return s
+}
+
+// DiffRangesInto is like DiffRanges, but the difference of the sets is
+// stored in dst. The dst set's values are not used to find the difference of
+// values, only as destination storage. If no set is provided for the
+// difference, then dst is untouched.
+//
+// Its time complexity is the same as DiffRanges.
+func DiffRangesInto /*[T algo.Ordered]*/ (dst *RangeSet /*[T]*/, sets ...*RangeSet /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+
+	res := sets[0].ranges
+	for _, set := range sets[1:] {
+		res = diffRanges(res, set.ranges)
+	}
+	dst.ranges = unionRanges(dst.ranges, res)
+}
+
+// returns a new slice with the union of the normalized ranges a and b.
+func unionRanges /*[T algo.Ordered]*/ (a, b []Range /*[T]*/) []Range /*[T]*/ {
+	res := make([]Range /*[T]*/, 0, len(a)+len(b))
+	push := func(r Range /*[T]*/) {
+		// merge with the last range if they overlap or are adjacent
+		if n := len(res); n > 0 && res[n-1].Hi >= r.Lo {
+			if r.Hi > res[n-1].Hi {
+				res[n-1].Hi = r.Hi
+			}
+			return
+		}
+		res = append(res, r)
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		if j == len(b) || (i < len(a) && a[i].Lo < b[j].Lo) {
+			push(a[i])
+			i++
+		} else {
+			push(b[j])
+			j++
+		}
+	}
+	return res
+}
+
+// returns a new slice with the intersection of the normalized ranges a and
+// b.
+func intersectRanges /*[T algo.Ordered]*/ (a, b []Range /*[T]*/) []Range /*[T]*/ {
+	var res []Range /*[T]*/
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		lo, hi := a[i].Lo, a[i].Hi
+		if b[j].Lo > lo {
+			lo = b[j].Lo
+		}
+		if b[j].Hi < hi {
+			hi = b[j].Hi
+		}
+		if lo < hi {
+			res = append(res, Range /*[T]*/ {Lo: lo, Hi: hi})
+		}
+
+		// advance the range that ends first, the other may overlap the next one
+		if a[i].Hi < b[j].Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return res
+}
+
+// returns a new slice with the values of the normalized ranges a that are
+// not in the normalized ranges b.
+func diffRanges /*[T algo.Ordered]*/ (a, b []Range /*[T]*/) []Range /*[T]*/ {
+	var res []Range /*[T]*/
+	j := 0
+	for _, r := range a {
+		// skip the ranges of b that end before r
+		for j < len(b) && b[j].Hi <= r.Lo {
+			j++
+		}
+
+		// cut the ranges of b that overlap r from it, the last one may also
+		// overlap the next range of a so j is not advanced past it.
+		for k := j; k < len(b) && b[k].Lo < r.Hi; k++ {
+			if b[k].Lo > r.Lo {
+				res = append(res, Range /*[T]*/ {Lo: r.Lo, Hi: b[k].Lo})
+			}
+			r.Lo = b[k].Hi
+		}
+		if r.Lo < r.Hi {
+			res = append(res, r)
+		}
+	}
+	return res
+}
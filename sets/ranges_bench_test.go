@@ -0,0 +1,141 @@
+package sets
+
+import (
+	"fmt"
+	"testing"
+)
+
+// returns n disjoint ranges of 2 values separated by a gap of 1 value,
+// starting at start.
+func spacedRanges(n, start int) []Range {
+	rs := make([]Range, n)
+	for i := range rs {
+		lo := start + i*3
+		rs[i] = Range{Lo: lo, Hi: lo + 2}
+	}
+	return rs
+}
+
+func BenchmarkRangeSet_Add(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeRangesFrom(spacedRanges(n, 0)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				lo := (n + i) * 3
+				s.Add(Range{Lo: lo, Hi: lo + 2})
+			}
+		})
+	}
+}
+
+func BenchmarkRangeSet_AddMerge(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			rs := spacedRanges(n, 0)
+			s := MakeRangesFrom(rs...)
+			indices := indicesSlice(make([]int, n), b.N)
+			b.ResetTimer()
+
+			// fill the gap after a range, which merges it with the next one
+			for i := 0; i < b.N; i++ {
+				r := rs[indices[i]]
+				s.Add(Range{Lo: r.Hi, Hi: r.Hi + 1})
+			}
+		})
+	}
+}
+
+func BenchmarkRangeSet_Remove(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			rs := spacedRanges(n, 0)
+			s := MakeRangesFrom(rs...)
+			indices := indicesSlice(make([]int, n), b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Remove(rs[indices[i]])
+			}
+		})
+	}
+}
+
+func BenchmarkRangeSet_Contains(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := MakeRangesFrom(spacedRanges(n, 0)...)
+			vals := sortedSlice(3*n, 1, 0)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = s.Contains(vals[indices[i]])
+			}
+		})
+	}
+}
+
+func BenchmarkRangeSet_Union(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*RangeSet, nsets)
+				for i := range sets {
+					sets[i] = MakeRangesFrom(spacedRanges(n, i*3*n)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := UnionRanges(sets...)
+					if s.Len() != nsets*n {
+						b.Fatalf("want len %d, got %d", nsets*n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkRangeSet_Intersect(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*RangeSet, nsets)
+				for i := range sets {
+					sets[i] = MakeRangesFrom(spacedRanges(n, 0)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := IntersectRanges(sets...)
+					if s.Len() != n {
+						b.Fatalf("want len %d, got %d", n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkRangeSet_Diff(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*RangeSet, nsets)
+				for i := range sets {
+					sets[i] = MakeRangesFrom(spacedRanges(n, i*3*n)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := DiffRanges(sets...)
+					if s.Len() != n {
+						b.Fatalf("want len %d, got %d", n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
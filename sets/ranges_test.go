@@ -0,0 +1,258 @@
+package sets
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// the values of the random range sets are in [0, rangesDomain).
+const rangesDomain = 200
+
+func randomRanges(r *rand.Rand, n int) []Range {
+	rs := make([]Range, n)
+	for i := range rs {
+		lo := r.Intn(rangesDomain)
+		rs[i] = Range{Lo: lo, Hi: lo + r.Intn(20)}
+	}
+	return rs
+}
+
+func rangesToSet(rs ...Range) Set {
+	s := Make()
+	for _, r := range rs {
+		for v := r.Lo; v < r.Hi; v++ {
+			s.Add(v)
+		}
+	}
+	return s
+}
+
+// checks that s is normalized and contains the same values as ref.
+func checkRanges(t *testing.T, s *RangeSet, ref Set) {
+	t.Helper()
+
+	rs := s.Ranges()
+	for i, r := range rs {
+		if r.IsEmpty() {
+			t.Fatalf("empty range %v at %d", r, i)
+		}
+		if i > 0 && rs[i-1].Hi >= r.Lo {
+			t.Fatalf("ranges %v and %v are not disjoint and non-adjacent", rs[i-1], r)
+		}
+	}
+	if got := rangesToSet(rs...); !cmp.Equal(ref, got, cmpopts.EquateEmpty()) {
+		t.Fatalf("want %v, got %v", ref, got)
+	}
+	for v := -1; v <= rangesDomain+20; v++ {
+		if s.Contains(v) != ref.Contains(v) {
+			t.Fatalf("%d: want contains %t", v, ref.Contains(v))
+		}
+	}
+}
+
+func TestRangeSet(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var s RangeSet
+		if s.Len() != 0 || s.Ranges() != nil || s.Gaps() != nil {
+			t.Fatal("want empty set")
+		}
+		s.Add(Range{Lo: 1, Hi: 2})
+		if !s.Contains(1) || s.Len() != 1 {
+			t.Fatal("want single range")
+		}
+	})
+
+	t.Run("AddRemove", func(t *testing.T) {
+		cases := []struct {
+			desc   string
+			add    []Range
+			remove []Range
+			want   []Range
+		}{
+			{"empty", nil, nil, nil},
+			{"empty range", []Range{{1, 1}, {3, 2}}, nil, nil},
+			{"single", []Range{{1, 3}}, nil, []Range{{1, 3}}},
+			{"disjoint", []Range{{5, 7}, {1, 3}}, nil, []Range{{1, 3}, {5, 7}}},
+			{"adjacent", []Range{{1, 3}, {3, 5}}, nil, []Range{{1, 5}}},
+			{"overlapping", []Range{{1, 4}, {3, 5}}, nil, []Range{{1, 5}}},
+			{"contained", []Range{{1, 10}, {3, 5}}, nil, []Range{{1, 10}}},
+			{"containing", []Range{{3, 5}, {1, 10}}, nil, []Range{{1, 10}}},
+			{"bridge", []Range{{1, 3}, {5, 7}, {9, 11}, {3, 9}}, nil, []Range{{1, 11}}},
+			{"remove all", []Range{{1, 3}}, []Range{{0, 5}}, nil},
+			{"remove exact", []Range{{1, 3}, {5, 7}}, []Range{{1, 3}}, []Range{{5, 7}}},
+			{"remove outside", []Range{{1, 3}}, []Range{{3, 5}, {-2, 1}}, []Range{{1, 3}}},
+			{"split", []Range{{1, 10}}, []Range{{3, 5}}, []Range{{1, 3}, {5, 10}}},
+			{"trim", []Range{{1, 5}, {7, 10}}, []Range{{3, 8}}, []Range{{1, 3}, {8, 10}}},
+			{"remove many", []Range{{1, 3}, {4, 6}, {7, 9}}, []Range{{2, 8}}, []Range{{1, 2}, {8, 9}}},
+			{"remove empty", []Range{{1, 5}}, []Range{{3, 3}}, []Range{{1, 5}}},
+		}
+		for _, c := range cases {
+			t.Run(c.desc, func(t *testing.T) {
+				s := MakeRangesFrom(c.add...)
+				s.Remove(c.remove...)
+				got := s.Ranges()
+				if !cmp.Equal(c.want, got, cmpopts.EquateEmpty()) {
+					t.Fatalf("want %v, got %v", c.want, got)
+				}
+			})
+		}
+	})
+
+	t.Run("AddRemoveRandom", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		var s RangeSet
+		ref := Make()
+		for i := 0; i < 1000; i++ {
+			rs := randomRanges(r, r.Intn(5))
+			if r.Intn(3) == 0 {
+				s.Remove(rs...)
+				ref.Delete(rangesToSet(rs...).Values()...)
+			} else {
+				s.Add(rs...)
+				ref.Add(rangesToSet(rs...).Values()...)
+			}
+			checkRanges(t, &s, ref)
+		}
+	})
+
+	t.Run("Overlaps", func(t *testing.T) {
+		s := MakeRangesFrom(Range{1, 3}, Range{5, 7})
+		cases := []struct {
+			r    Range
+			want bool
+		}{
+			{Range{0, 1}, false},
+			{Range{0, 2}, true},
+			{Range{2, 2}, false},
+			{Range{2, 3}, true},
+			{Range{3, 5}, false},
+			{Range{3, 6}, true},
+			{Range{0, 10}, true},
+			{Range{6, 10}, true},
+			{Range{7, 10}, false},
+		}
+		for _, c := range cases {
+			if got := s.Overlaps(c.r); got != c.want {
+				t.Errorf("%v: want %t, got %t", c.r, c.want, got)
+			}
+		}
+	})
+
+	t.Run("Gaps", func(t *testing.T) {
+		cases := []struct {
+			in   []Range
+			want []Range
+		}{
+			{nil, nil},
+			{[]Range{{1, 3}}, nil},
+			{[]Range{{1, 3}, {5, 7}}, []Range{{3, 5}}},
+			{[]Range{{1, 3}, {5, 7}, {8, 10}}, []Range{{3, 5}, {7, 8}}},
+		}
+		for _, c := range cases {
+			got := MakeRangesFrom(c.in...).Gaps()
+			if !cmp.Equal(c.want, got) {
+				t.Errorf("%v: want %v, got %v", c.in, c.want, got)
+			}
+		}
+	})
+
+	t.Run("Complement", func(t *testing.T) {
+		s := MakeRangesFrom(Range{1, 3}, Range{5, 7})
+		cases := []struct {
+			bounds Range
+			want   []Range
+		}{
+			{Range{0, 0}, nil},
+			{Range{0, 10}, []Range{{0, 1}, {3, 5}, {7, 10}}},
+			{Range{1, 7}, []Range{{3, 5}}},
+			{Range{2, 6}, []Range{{3, 5}}},
+			{Range{1, 3}, nil},
+			{Range{8, 10}, []Range{{8, 10}}},
+		}
+		for _, c := range cases {
+			got := s.Complement(c.bounds).Ranges()
+			if !cmp.Equal(c.want, got, cmpopts.EquateEmpty()) {
+				t.Errorf("%v: want %v, got %v", c.bounds, c.want, got)
+			}
+		}
+	})
+
+	t.Run("IsEqual", func(t *testing.T) {
+		a := MakeRangesFrom(Range{1, 3}, Range{3, 5})
+		b := MakeRangesFrom(Range{1, 5})
+		c := MakeRangesFrom(Range{1, 4})
+		if !a.IsEqual(b) || !b.IsEqual(a) {
+			t.Fatal("want equal")
+		}
+		if a.IsEqual(c) || c.IsEqual(MakeRanges()) {
+			t.Fatal("want not equal")
+		}
+	})
+}
+
+func TestRangeSetOps(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	ops := []struct {
+		desc string
+		fn   func(...*RangeSet) *RangeSet
+		into func(*RangeSet, ...*RangeSet)
+		ref  func(...Set) Set
+	}{
+		{"Union", UnionRanges, UnionRangesInto, Union},
+		{"Intersect", IntersectRanges, IntersectRangesInto, Intersect},
+		{"Diff", DiffRanges, DiffRangesInto, Diff},
+	}
+	for _, op := range ops {
+		t.Run(op.desc, func(t *testing.T) {
+			if op.fn() != nil {
+				t.Fatal("want nil for no set")
+			}
+
+			for i := 0; i < 100; i++ {
+				n := 1 + r.Intn(4)
+				sets := make([]*RangeSet, n)
+				refs := make([]Set, n)
+				before := make([][]Range, n)
+				for j := range sets {
+					rs := randomRanges(r, r.Intn(10))
+					sets[j] = MakeRangesFrom(rs...)
+					refs[j] = rangesToSet(rs...)
+					before[j] = sets[j].Ranges()
+				}
+
+				got := op.fn(sets...)
+				checkRanges(t, got, op.ref(refs...))
+
+				// Into adds the result to the values of dst
+				dstRanges := randomRanges(r, r.Intn(3))
+				dst := MakeRangesFrom(dstRanges...)
+				op.into(dst, sets...)
+				checkRanges(t, dst, Union(rangesToSet(dstRanges...), op.ref(refs...)))
+
+				// the source sets are left untouched
+				for j, s := range sets {
+					if got := s.Ranges(); !cmp.Equal(before[j], got, cmpopts.EquateEmpty()) {
+						t.Fatalf("source set %d modified: want %v, got %v", j, before[j], got)
+					}
+				}
+			}
+
+			// dst untouched when no set is provided
+			dst := MakeRangesFrom(Range{1, 3})
+			op.into(dst)
+			if !cmp.Equal([]Range{{1, 3}}, dst.Ranges()) {
+				t.Fatalf("want dst untouched, got %v", dst.Ranges())
+			}
+		})
+	}
+}
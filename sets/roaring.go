@@ -0,0 +1,641 @@
+package sets
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Roaring is a compressed bitmap set of 32-bit unsigned integers, following
+// the design of Roaring bitmaps (https://roaringbitmap.org/). The values are
+// partitioned in chunks by their 16 most significant bits, and the 16 least
+// significant bits of the values of each chunk are stored in a container
+// that is either a sorted array, when the chunk has few values, or a bitmap
+// of 65536 bits, when it has many. This makes it compact for both sparse and
+// dense values, and set operations work on whole containers at a time. The
+// run-length encoded containers of the original design are not implemented.
+//
+// It has the same method names as Set so that callers can switch between
+// them. As the values must be integers, Roaring is not generic. Its
+// zero-value is ready to use.
+type Roaring struct {
+	keys       []uint16 // sorted 16 most significant bits of each chunk
+	containers []*container
+	n          int
+}
+
+const (
+	// maximum number of values stored in an array container, above that it is
+	// more compact to use a bitmap (4096 * 16 bits == 1024 * 64 bits).
+	arrayMaxLen = 4096
+	bitmapWords = 1 << 16 / wordBits
+)
+
+// container stores the 16 least significant bits of the values of a chunk,
+// either in a sorted array if it has at most arrayMaxLen values, or in a
+// bitmap otherwise.
+type container struct {
+	array  []uint16
+	bitmap []uint64 // nil if the container is an array
+	n      int
+}
+
+// MakeRoaring returns a compressed bitmap set.
+func MakeRoaring() *Roaring {
+	return new(Roaring)
+}
+
+// MakeRoaringFrom returns a compressed bitmap set initialized with the
+// provided values.
+func MakeRoaringFrom(vs ...uint32) *Roaring {
+	s := MakeRoaring()
+	s.Add(vs...)
+	return s
+}
+
+// Add adds value(s) to the set s. If v is already in s this has no effect.
+//
+// It runs in O(log c + a) time complexity where c is the number of chunks
+// and a the maximum size of an array container (4096), which is O(1) with
+// respect to the number of values in the set. Of course it is O(n) with
+// respect to the number of values to add.
+func (s *Roaring) Add(vs ...uint32) {
+	for _, v := range vs {
+		hi, lo := uint16(v>>16), uint16(v)
+		i, ok := s.find(hi)
+		if !ok {
+			s.keys = append(s.keys, 0)
+			copy(s.keys[i+1:], s.keys[i:])
+			s.keys[i] = hi
+			s.containers = append(s.containers, nil)
+			copy(s.containers[i+1:], s.containers[i:])
+			s.containers[i] = new(container)
+		}
+		if s.containers[i].add(lo) {
+			s.n++
+		}
+	}
+}
+
+// Delete removes v from the set s. If v is not in s this has no effect.
+//
+// Its time complexity is the same as for Add.
+func (s *Roaring) Delete(vs ...uint32) {
+	for _, v := range vs {
+		hi, lo := uint16(v>>16), uint16(v)
+		i, ok := s.find(hi)
+		if !ok || !s.containers[i].remove(lo) {
+			continue
+		}
+		s.n--
+
+		if s.containers[i].n == 0 {
+			s.keys = append(s.keys[:i], s.keys[i+1:]...)
+			copy(s.containers[i:], s.containers[i+1:])
+			s.containers[len(s.containers)-1] = nil
+			s.containers = s.containers[:len(s.containers)-1]
+		}
+	}
+}
+
+// Contains reports whether v is in s.
+//
+// It runs in O(log c + log a) time complexity where c is the number of
+// chunks and a the maximum size of an array container, which is O(1) with
+// respect to the number of values in the set.
+func (s *Roaring) Contains(v uint32) bool {
+	i, ok := s.find(uint16(v >> 16))
+	return ok && s.containers[i].contains(uint16(v))
+}
+
+// Len reports the number of elements in s.
+func (s *Roaring) Len() int {
+	return s.n
+}
+
+// Values returns a slice of all values present in the set, in ascending
+// order.
+//
+// It runs in O(n) time complexity where n is the number of values in the set.
+func (s *Roaring) Values() []uint32 {
+	var vals []uint32
+	if s.n > 0 {
+		vals = make([]uint32, 0, s.n)
+		for i, c := range s.containers {
+			vals = c.appendValues(vals, uint32(s.keys[i])<<16)
+		}
+	}
+	return vals
+}
+
+// IsDisjoint returns true if the intersection of s and other is an empty
+// set, false otherwise.
+//
+// It runs in O(n) time complexity where n is the number of values in the
+// smallest set between s and other. It does not allocate.
+func (s *Roaring) IsDisjoint(other *Roaring) bool {
+	for i, j := 0, 0; i < len(s.keys) && j < len(other.keys); {
+		switch {
+		case s.keys[i] < other.keys[j]:
+			i++
+		case s.keys[i] > other.keys[j]:
+			j++
+		default:
+			if s.containers[i].intersects(other.containers[j]) {
+				return false
+			}
+			i++
+			j++
+		}
+	}
+	return true
+}
+
+// IsSubset returns true if every value of s is in other, false otherwise. If
+// strict is true and s contains the same values as other, then it returns
+// false.
+//
+// It runs in O(n) time complexity where n is the number of values in s. It
+// does not allocate.
+func (s *Roaring) IsSubset(other *Roaring, strict bool) bool {
+	if (!strict && s.n <= other.n) || (strict && s.n < other.n) {
+		for i, hi := range s.keys {
+			j, ok := other.find(hi)
+			if !ok || !s.containers[i].isSubset(other.containers[j]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// IsSuperset returns true if every value of other is in s, false otherwise.
+// If strict is true and other contains the same values as s, then it returns
+// false.
+//
+// It runs in O(n) time complexity where n is the number of values in other.
+// It does not allocate.
+func (s *Roaring) IsSuperset(other *Roaring, strict bool) bool {
+	return other.IsSubset(s, strict)
+}
+
+// IsEqual returns true if s contains the same values as other, false
+// otherwise.
+//
+// It runs in O(n) time complexity where n is the number of values in s. It
+// does not allocate.
+func (s *Roaring) IsEqual(other *Roaring) bool {
+	return s.n == other.n && s.IsSubset(other, false)
+}
+
+// returns the index of the chunk with key hi and true if it exists,
+// otherwise the index where it should be inserted and false.
+func (s *Roaring) find(hi uint16) (int, bool) {
+	i := sort.Search(len(s.keys), func(i int) bool { return s.keys[i] >= hi })
+	return i, i < len(s.keys) && s.keys[i] == hi
+}
+
+// IntersectRoaring returns a new Roaring that contains the intersection of
+// all sets. If no set is provided, it returns nil. If a single set is
+// provided, it returns a copy of that set (that is, it always creates a new
+// set if at least one set is provided).
+//
+// It runs in O(n * k) time complexity where n is the number of values of the
+// first set and k is the number of sets, but it processes 64 values at a
+// time when both containers are bitmaps.
+func IntersectRoaring(sets ...*Roaring) *Roaring {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeRoaring()
+	IntersectRoaringInto(s, sets...)
+	return s
+}
+
+// IntersectRoaringInto is like IntersectRoaring, but the intersection of the
+// sets is stored in dst. The dst set's values are not used to find the
+// intersection of values, only as destination storage. If no set is
+// provided, then dst is untouched.
+//
+// Its time complexity is the same as for IntersectRoaring.
+func IntersectRoaringInto(dst *Roaring, sets ...*Roaring) {
+	if len(sets) == 0 {
+		return
+	}
+
+	res := sets[0]
+	for _, set := range sets[1:] {
+		res = combineRoaring(res, set, opIntersect)
+	}
+	*dst = *combineRoaring(dst, res, opUnion)
+}
+
+// UnionRoaring returns a new Roaring that is the union of all sets. If no
+// set is provided, it returns nil. If a single set is provided, it returns a
+// copy of that set (that is, it always creates a new set if at least one set
+// is provided).
+//
+// It runs in O(n * k) time complexity where n is the total number of values
+// in all sets and k is the number of sets, but it processes 64 values at a
+// time when both containers are bitmaps.
+func UnionRoaring(sets ...*Roaring) *Roaring {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeRoaring()
+	UnionRoaringInto(s, sets...)
+	return s
+}
+
+// UnionRoaringInto is like UnionRoaring, but the union of the sets is stored
+// in dst. If no set is provided for the union, then dst is untouched.
+//
+// Its time complexity is the same as UnionRoaring.
+func UnionRoaringInto(dst *Roaring, sets ...*Roaring) {
+	if len(sets) == 0 {
+		return
+	}
+
+	res := dst
+	for _, set := range sets {
+		res = combineRoaring(res, set, opUnion)
+	}
+	*dst = *res
+}
+
+// DiffRoaring returns a new Roaring that is the difference of all sets, that
+// is, the values in the first set that are not in any of the other sets. If
+// no set is provided, it returns nil. If a single set is provided, it
+// returns a copy of that set (it always creates a new set if at least one set
+// is provided).
+//
+// It runs in O(n * k) time complexity where n is the number of values of the
+// first set and k is the number of sets, but it processes 64 values at a
+// time when both containers are bitmaps.
+func DiffRoaring(sets ...*Roaring) *Roaring {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeRoaring()
+	DiffRoaringInto(s, sets...)
+	return s
+}
+
+// DiffRoaringInto is like DiffRoaring, but the difference of the sets is
+// stored in dst. The dst set's values are not used to find the difference of
+// values, only as destination storage. If no set is provided for the
+// difference, then dst is untouched.
+//
+// Its time complexity is the same as DiffRoaring.
+func DiffRoaringInto(dst *Roaring, sets ...*Roaring) {
+	if len(sets) == 0 {
+		return
+	}
+
+	res := sets[0]
+	for _, set := range sets[1:] {
+		res = combineRoaring(res, set, opDiff)
+	}
+	*dst = *combineRoaring(dst, res, opUnion)
+}
+
+// SymmetricDiffRoaring returns a new Roaring that contains values that are
+// in either of the sets but not in any other. If no set is provided, it
+// returns nil. If a single set is provided, it returns a copy of that set (it
+// always creates a new set if at least one set is provided).
+//
+// It runs in O(n * k) time complexity where n is the total number of values
+// in all sets and k is the number of sets, but it processes 64 values at a
+// time when both containers are bitmaps.
+func SymmetricDiffRoaring(sets ...*Roaring) *Roaring {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeRoaring()
+	SymmetricDiffRoaringInto(s, sets...)
+	return s
+}
+
+// SymmetricDiffRoaringInto is like SymmetricDiffRoaring, but the resulting
+// values are stored in dst. The dst set's values are not used to find the
+// symmetric difference, only as destination storage. If no set is provided
+// for the symmetric difference, then dst is untouched.
+//
+// Its time complexity is the same as SymmetricDiffRoaring.
+func SymmetricDiffRoaringInto(dst *Roaring, sets ...*Roaring) {
+	if len(sets) == 0 {
+		return
+	}
+
+	// keep track of the values seen in exactly one set so far (once) and of
+	// all values seen so far (seen).
+	once, seen := sets[0], sets[0]
+	for _, set := range sets[1:] {
+		once = combineRoaring(
+			combineRoaring(once, set, opDiff),
+			combineRoaring(set, seen, opDiff),
+			opUnion)
+		seen = combineRoaring(seen, set, opUnion)
+	}
+	*dst = *combineRoaring(dst, once, opUnion)
+}
+
+type setOp int
+
+const (
+	opUnion setOp = iota
+	opIntersect
+	opDiff
+)
+
+// returns a new set that is the result of the set operation op on a and b.
+// The new set does not share any container with a or b.
+func combineRoaring(a, b *Roaring, op setOp) *Roaring {
+	var res Roaring
+	push := func(hi uint16, c *container) {
+		if c != nil && c.n > 0 {
+			res.keys = append(res.keys, hi)
+			res.containers = append(res.containers, c)
+			res.n += c.n
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a.keys) && j < len(b.keys) {
+		switch {
+		case a.keys[i] < b.keys[j]:
+			if op != opIntersect {
+				push(a.keys[i], a.containers[i].clone())
+			}
+			i++
+		case a.keys[i] > b.keys[j]:
+			if op == opUnion {
+				push(b.keys[j], b.containers[j].clone())
+			}
+			j++
+		default:
+			push(a.keys[i], combineContainers(a.containers[i], b.containers[j], op))
+			i++
+			j++
+		}
+	}
+	if op != opIntersect {
+		for ; i < len(a.keys); i++ {
+			push(a.keys[i], a.containers[i].clone())
+		}
+	}
+	if op == opUnion {
+		for ; j < len(b.keys); j++ {
+			push(b.keys[j], b.containers[j].clone())
+		}
+	}
+	return &res
+}
+
+// returns a new container that is the result of the set operation op on a
+// and b.
+func combineContainers(a, b *container, op setOp) *container {
+	if a.bitmap == nil && b.bitmap == nil {
+		return newArrayContainer(mergeArrays(a.array, b.array, op))
+	}
+
+	if a.bitmap == nil && op != opUnion {
+		// keep the values of the array based on their presence in b
+		var array []uint16
+		for _, v := range a.array {
+			if b.contains(v) == (op == opIntersect) {
+				array = append(array, v)
+			}
+		}
+		return newArrayContainer(array)
+	}
+	if b.bitmap == nil && op == opIntersect {
+		return combineContainers(b, a, op)
+	}
+
+	wa, wb := a.words(), b.words()
+	res := &container{bitmap: make([]uint64, bitmapWords)}
+	for i := range res.bitmap {
+		switch op {
+		case opUnion:
+			res.bitmap[i] = wa[i] | wb[i]
+		case opIntersect:
+			res.bitmap[i] = wa[i] & wb[i]
+		case opDiff:
+			res.bitmap[i] = wa[i] &^ wb[i]
+		}
+		res.n += bits.OnesCount64(res.bitmap[i])
+	}
+	if res.n <= arrayMaxLen {
+		res.toArray()
+	}
+	return res
+}
+
+// returns the result of the set operation op on the sorted arrays a and b.
+func mergeArrays(a, b []uint16, op setOp) []uint16 {
+	var dst []uint16
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			if op != opIntersect {
+				dst = append(dst, a[i])
+			}
+			i++
+		case a[i] > b[j]:
+			if op == opUnion {
+				dst = append(dst, b[j])
+			}
+			j++
+		default:
+			if op != opDiff {
+				dst = append(dst, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	if op != opIntersect {
+		dst = append(dst, a[i:]...)
+	}
+	if op == opUnion {
+		dst = append(dst, b[j:]...)
+	}
+	return dst
+}
+
+// returns a new container for the sorted values array, which it takes
+// ownership of. The container is converted to a bitmap if it has too many
+// values.
+func newArrayContainer(array []uint16) *container {
+	c := &container{array: array, n: len(array)}
+	if c.n > arrayMaxLen {
+		c.toBitmap()
+	}
+	return c
+}
+
+func (c *container) clone() *container {
+	res := &container{n: c.n}
+	if c.bitmap != nil {
+		res.bitmap = append([]uint64(nil), c.bitmap...)
+	} else {
+		res.array = append([]uint16(nil), c.array...)
+	}
+	return res
+}
+
+// returns the index of lo in the array container and true if it exists,
+// otherwise the index where it should be inserted and false.
+func (c *container) search(lo uint16) (int, bool) {
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= lo })
+	return i, i < len(c.array) && c.array[i] == lo
+}
+
+func (c *container) contains(lo uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[lo/wordBits]&(1<<(lo%wordBits)) != 0
+	}
+	_, ok := c.search(lo)
+	return ok
+}
+
+// adds lo to the container and returns true if it was not already present.
+func (c *container) add(lo uint16) bool {
+	if c.bitmap != nil {
+		w, mask := &c.bitmap[lo/wordBits], uint64(1)<<(lo%wordBits)
+		if *w&mask != 0 {
+			return false
+		}
+		*w |= mask
+		c.n++
+		return true
+	}
+
+	i, ok := c.search(lo)
+	if ok {
+		return false
+	}
+	c.array = append(c.array, 0)
+	copy(c.array[i+1:], c.array[i:])
+	c.array[i] = lo
+	c.n++
+	if c.n > arrayMaxLen {
+		c.toBitmap()
+	}
+	return true
+}
+
+// removes lo from the container and returns true if it was present.
+func (c *container) remove(lo uint16) bool {
+	if c.bitmap != nil {
+		w, mask := &c.bitmap[lo/wordBits], uint64(1)<<(lo%wordBits)
+		if *w&mask == 0 {
+			return false
+		}
+		*w &^= mask
+		c.n--
+		if c.n <= arrayMaxLen {
+			c.toArray()
+		}
+		return true
+	}
+
+	i, ok := c.search(lo)
+	if !ok {
+		return false
+	}
+	c.array = append(c.array[:i], c.array[i+1:]...)
+	c.n--
+	return true
+}
+
+// appends the values of the container to dst, combined with the 16 most
+// significant bits hi, and returns it.
+func (c *container) appendValues(dst []uint32, hi uint32) []uint32 {
+	if c.bitmap == nil {
+		for _, v := range c.array {
+			dst = append(dst, hi|uint32(v))
+		}
+		return dst
+	}
+
+	for i, w := range c.bitmap {
+		for w != 0 {
+			dst = append(dst, hi|uint32(i*wordBits+bits.TrailingZeros64(w)))
+			w &= w - 1 // clear the lowest set bit
+		}
+	}
+	return dst
+}
+
+// returns the bitmap of the container, creating it if it is an array.
+func (c *container) words() []uint64 {
+	if c.bitmap != nil {
+		return c.bitmap
+	}
+	words := make([]uint64, bitmapWords)
+	for _, v := range c.array {
+		words[v/wordBits] |= 1 << (v % wordBits)
+	}
+	return words
+}
+
+func (c *container) toBitmap() {
+	c.bitmap = c.words()
+	c.array = nil
+}
+
+func (c *container) toArray() {
+	array := make([]uint16, 0, c.n)
+	for i, w := range c.bitmap {
+		for w != 0 {
+			array = append(array, uint16(i*wordBits+bits.TrailingZeros64(w)))
+			w &= w - 1
+		}
+	}
+	c.array = array
+	c.bitmap = nil
+}
+
+func (c *container) intersects(other *container) bool {
+	if c.bitmap != nil && other.bitmap != nil {
+		for i, w := range c.bitmap {
+			if w&other.bitmap[i] != 0 {
+				return true
+			}
+		}
+		return false
+	}
+	if c.bitmap != nil {
+		return other.intersects(c)
+	}
+	for _, v := range c.array {
+		if other.contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *container) isSubset(other *container) bool {
+	if c.n > other.n {
+		return false
+	}
+	if c.bitmap != nil {
+		// other has more values so it is a bitmap too
+		for i, w := range c.bitmap {
+			if w&^other.bitmap[i] != 0 {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range c.array {
+		if !other.contains(v) {
+			return false
+		}
+	}
+	return true
+}
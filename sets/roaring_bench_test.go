@@ -0,0 +1,220 @@
+package sets
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkRoaring_Add(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeRoaringFrom(toUint32s(vals)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Add(uint32(n + 1 + i))
+			}
+		})
+	}
+}
+
+func BenchmarkRoaring_Delete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeRoaringFrom(toUint32s(vals)...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Delete(uint32(vals[indices[i]]))
+			}
+		})
+	}
+}
+
+func BenchmarkRoaring_Contains(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeRoaringFrom(toUint32s(vals)...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s.Contains(uint32(vals[indices[i]])) {
+					b.Fatal("Contains returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRoaring_ContainsSparse(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		// one value every 1000, so that all containers are arrays
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1000)
+			s := MakeRoaringFrom(toUint32s(vals)...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s.Contains(uint32(vals[indices[i]])) {
+					b.Fatal("Contains returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRoaring_Union(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*Roaring, nsets)
+				for i := range sets {
+					sets[i] = MakeRoaringFrom(toUint32s(sortedSlice(n, 1, (i+1)*n))...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := UnionRoaring(sets...)
+					if s.Len() != nsets*n {
+						b.Fatalf("want len %d, got %d", nsets*n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkRoaring_Intersect(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*Roaring, nsets)
+				for i := range sets {
+					sets[i] = MakeRoaringFrom(toUint32s(sortedSlice(n, 1))...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := IntersectRoaring(sets...)
+					if s.Len() != n {
+						b.Fatalf("want len %d, got %d", n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkRoaring_Diff(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*Roaring, nsets)
+				for i := range sets {
+					sets[i] = MakeRoaringFrom(toUint32s(sortedSlice(n, 1, (i+1)*n))...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := DiffRoaring(sets...)
+					if s.Len() != n {
+						b.Fatalf("want len %d, got %d", n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkRoaring_SymmetricDiff(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*Roaring, nsets)
+				for i := range sets {
+					sets[i] = MakeRoaringFrom(toUint32s(sortedSlice(n, 1, (i+1)*n))...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := SymmetricDiffRoaring(sets...)
+					if s.Len() != nsets*n {
+						b.Fatalf("want len %d, got %d", nsets*n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkRoaring_IsSubset(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s1, s2 := MakeRoaringFrom(toUint32s(vals)...), MakeRoaringFrom(toUint32s(vals)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsSubset(s2, false) {
+					b.Fatal("want is subset to return true")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRoaring_IsSuperset(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s1, s2 := MakeRoaringFrom(toUint32s(vals)...), MakeRoaringFrom(toUint32s(vals)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsSuperset(s2, false) {
+					b.Fatal("want is superset to return true")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRoaring_IsDisjoint(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			v1, v2 := sortedSlice(n, 1), sortedSlice(n, 1, (2*n)+1)
+			s1, s2 := MakeRoaringFrom(toUint32s(v1)...), MakeRoaringFrom(toUint32s(v2)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsDisjoint(s2) {
+					b.Fatal("want is disjoint to return true")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRoaring_IsEqual(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s1, s2 := MakeRoaringFrom(toUint32s(vals)...), MakeRoaringFrom(toUint32s(vals)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsEqual(s2) {
+					b.Fatal("want is equal to return true")
+				}
+			}
+		})
+	}
+}
+
+// returns a slice of N valid indices into vals, to be used for benchmarks
@@ -0,0 +1,231 @@
+package sets
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRoaring(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var s Roaring
+		if s.Len() != 0 || s.Values() != nil {
+			t.Fatal("want empty set")
+		}
+		s.Add(1 << 31)
+		if !s.Contains(1<<31) || s.Len() != 1 {
+			t.Fatal("want single value")
+		}
+	})
+
+	t.Run("AddDeleteRandom", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		// values spread over 3 chunks, with enough values so that containers
+		// switch between arrays and bitmaps.
+		s := MakeRoaring()
+		ref := Make()
+		for round := 0; round < 3; round++ {
+			for i := 0; i < 30000; i++ {
+				v := r.Intn(3 << 16)
+				if (round == 1) == (r.Intn(4) != 0) {
+					s.Delete(uint32(v))
+					ref.Delete(v)
+				} else {
+					s.Add(uint32(v))
+					ref.Add(v)
+				}
+			}
+			checkRoaring(t, s, ref)
+		}
+	})
+
+	t.Run("Extremes", func(t *testing.T) {
+		s := MakeRoaringFrom(0, 1<<32-1, 1<<16-1, 1<<16)
+		want := []uint32{0, 1<<16 - 1, 1 << 16, 1<<32 - 1}
+		if got := s.Values(); !cmp.Equal(want, got) {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+		s.Delete(want...)
+		if s.Len() != 0 || len(s.keys) != 0 {
+			t.Fatalf("want empty set, got %v", s.Values())
+		}
+	})
+
+	// sets of values that exercise array and bitmap containers
+	dense := func(start, n int) []T {
+		return sortedSlice(n, 1, start)
+	}
+	setOps := []struct {
+		name string
+		fn   func(...*Roaring) *Roaring
+		into func(*Roaring, ...*Roaring)
+		ref  func(...Set) Set
+	}{
+		{"Union", UnionRoaring, UnionRoaringInto, Union},
+		{"Intersect", IntersectRoaring, IntersectRoaringInto, Intersect},
+		{"Diff", DiffRoaring, DiffRoaringInto, Diff},
+		{"SymmetricDiff", SymmetricDiffRoaring, SymmetricDiffRoaringInto, SymmetricDiff},
+	}
+	setsValues := [][][]T{
+		nil,
+		{{}},
+		{sortedSlice(5, 1)},
+		{sortedSlice(5, 1), sortedSlice(3, 10)},
+		{sortedSlice(5, 1), sortedSlice(3, 2)},
+		{sortedSlice(3, 1), sortedSlice(2, 1), sortedSlice(1, 1)},
+		{{1, 3, 4, 6}, {3, 4}, {3, 4, 5}, {2, 3, 6}},
+		{{1, 1 << 16, 1 << 20}, {1 << 16, 1 << 20}, {1 << 20, 1 << 24}},
+		{dense(0, 5000), dense(3000, 5000)},
+		{dense(0, 5000), sortedSlice(100, 3)},
+		{sortedSlice(100, 3), dense(0, 5000)},
+		{dense(0, 5000), dense(4900, 200), dense(1000, 10000)},
+		{dense(0, 1<<16), dense(2, 1<<16)},
+	}
+	for _, op := range setOps {
+		t.Run(op.name, func(t *testing.T) {
+			for _, vals := range setsValues {
+				for _, dstValues := range [][]T{nil, {}, {55}, dense(4000, 200)} {
+					name := fmt.Sprintf("%d sets into %d", len(vals), len(dstValues))
+					t.Run(name, func(t *testing.T) {
+						sets := make([]*Roaring, len(vals))
+						refs := make([]Set, len(vals))
+						for i, vs := range vals {
+							sets[i] = MakeRoaringFrom(toUint32s(vs)...)
+							refs[i] = MakeFrom(vs...)
+						}
+
+						var got *Roaring
+						var want Set
+						if dstValues != nil {
+							got = MakeRoaringFrom(toUint32s(dstValues)...)
+							op.into(got, sets...)
+							want = MakeFrom(dstValues...)
+							UnionInto(want, op.ref(refs...))
+						} else {
+							got = op.fn(sets...)
+							want = op.ref(refs...)
+						}
+
+						if got == nil {
+							if want.Len() != 0 {
+								t.Fatalf("want %d values, got nil", want.Len())
+							}
+							return
+						}
+						checkRoaring(t, got, want)
+
+						// source sets must not be modified
+						for i, vs := range vals {
+							checkRoaring(t, sets[i], MakeFrom(vs...))
+						}
+					})
+				}
+			}
+		})
+	}
+
+	t.Run("IsDisjointSubsetSupersetEqual", func(t *testing.T) {
+		cases := []struct {
+			vals1    []T
+			vals2    []T
+			disjoint bool
+			subset   bool
+			strict   bool
+		}{
+			{nil, nil, true, true, false},
+			{nil, []T{1}, true, true, true},
+			{[]T{1}, []T{1}, false, true, false},
+			{[]T{1}, []T{1, 2}, false, true, true},
+			{[]T{1}, []T{1, 1 << 20}, false, true, true},
+			{[]T{3, 4}, []T{1, 2}, true, false, false},
+			{[]T{3, 1 << 20}, []T{1, 2}, true, false, false},
+			{[]T{1, 2, 3, 4}, []T{1, 2, 4, 5}, false, false, false},
+			{dense(0, 5000), dense(0, 5000), false, true, false},
+			{dense(0, 5000), dense(0, 5001), false, true, true},
+			{dense(1, 5000), dense(0, 5001), false, true, true},
+			{dense(0, 5000), dense(5000, 5000), true, false, false},
+			{dense(100, 100), dense(0, 5000), false, true, true},
+			{dense(0, 5000), dense(1, 5000), false, false, false},
+		}
+		for i, c := range cases {
+			t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+				s1, s2 := MakeRoaringFrom(toUint32s(c.vals1)...), MakeRoaringFrom(toUint32s(c.vals2)...)
+
+				if dis1, dis2 := s1.IsDisjoint(s2), s2.IsDisjoint(s1); dis1 != c.disjoint || dis2 != c.disjoint {
+					t.Fatalf("want disjoint %t, got %t and %t", c.disjoint, dis1, dis2)
+				}
+				if sub := s1.IsSubset(s2, false); sub != c.subset {
+					t.Fatalf("want subset %t, got %t", c.subset, sub)
+				}
+				if substr := s1.IsSubset(s2, true); substr != (c.subset && c.strict) {
+					t.Fatalf("want strict subset %t, got %t", c.subset && c.strict, substr)
+				}
+				if sup := s2.IsSuperset(s1, false); sup != c.subset {
+					t.Fatalf("want superset %t, got %t", c.subset, sup)
+				}
+				if supstr := s2.IsSuperset(s1, true); supstr != (c.subset && c.strict) {
+					t.Fatalf("want strict superset %t, got %t", c.subset && c.strict, supstr)
+				}
+				wantEq := c.subset && !c.strict
+				if eq1, eq2 := s1.IsEqual(s2), s2.IsEqual(s1); eq1 != wantEq || eq2 != wantEq {
+					t.Fatalf("want equal %t, got %t and %t", wantEq, eq1, eq2)
+				}
+			})
+		}
+	})
+}
+
+// checks that s contains the same values as ref, and that its containers
+// respect the invariants.
+func checkRoaring(t *testing.T, s *Roaring, ref Set) {
+	t.Helper()
+
+	want := ref.Values()
+	sort.Ints(want)
+	// cmp.Equal is too slow for the large sets of values used in tests
+	got := s.Values()
+	if len(got) != len(want) {
+		t.Fatalf("want %d values, got %d", len(want), len(got))
+	}
+	for i, v := range want {
+		if got[i] != uint32(v) {
+			t.Fatalf("value %d: want %d, got %d", i, v, got[i])
+		}
+	}
+	if s.Len() != ref.Len() {
+		t.Fatalf("want len %d, got %d", ref.Len(), s.Len())
+	}
+
+	for i, c := range s.containers {
+		if i > 0 && s.keys[i-1] >= s.keys[i] {
+			t.Fatalf("keys are not sorted: %v", s.keys)
+		}
+		if c.n == 0 {
+			t.Fatalf("container %d is empty", s.keys[i])
+		}
+		if (c.bitmap != nil) != (c.n > arrayMaxLen) {
+			t.Fatalf("container %d: want bitmap %t with %d values", s.keys[i], c.n > arrayMaxLen, c.n)
+		}
+		if c.bitmap == nil && len(c.array) != c.n {
+			t.Fatalf("container %d: want %d values, got %d", s.keys[i], c.n, len(c.array))
+		}
+	}
+}
+
+func toUint32s(vals []T) []uint32 {
+	var res []uint32
+	if vals != nil {
+		res = make([]uint32, len(vals))
+		for i, v := range vals {
+			res[i] = uint32(v)
+		}
+	}
+	return res
+}
@@ -0,0 +1,409 @@
+package sets
+
+import (
+	"github.com/mna/algo/search"
+	"github.com/mna/algo/sort"
+)
+
+// SortedSet is a set of ordered values backed by a sorted slice. It is much
+// more compact and cache-friendly than Set, and its set operations run in
+// linear time by merging the sorted slices, but adding or deleting values one
+// at a time is O(n) as the slice must stay sorted. It is best suited for
+// read-mostly sets, and for values that are added in batches. Its zero-value
+// is ready to use.
+type SortedSet /*[T algo.OrderedComparable]*/ struct {
+	vals []T
+}
+
+// MakeSorted returns a sorted set of some element type.
+func MakeSorted /*[T algo.OrderedComparable]*/ () *SortedSet /*[T]*/ {
+	return new(SortedSet)
+}
+
+// MakeSortedCap returns a sorted set of some element type with an initial
+// capacity.
+func MakeSortedCap /*[T algo.OrderedComparable]*/ (capacity int) *SortedSet /*[T]*/ {
+	return &SortedSet{
+		vals: make([]T, 0, capacity),
+	}
+}
+
+// MakeSortedFrom returns a sorted set of some element type initialized with
+// the provided values.
+//
+// It runs in O(n log n) time complexity.
+func MakeSortedFrom /*[T algo.OrderedComparable]*/ (vs ...T) *SortedSet /*[T]*/ {
+	s := MakeSorted /*[T]*/ ()
+	s.Add(vs...)
+	return s
+}
+
+// MakeSortedFromSet returns a sorted set of some element type initialized
+// with the values of set.
+//
+// It runs in O(n log n) time complexity, as the values of set must be
+// sorted.
+func MakeSortedFromSet /*[T algo.OrderedComparable]*/ (set Set /*[T]*/) *SortedSet /*[T]*/ {
+	// the values of a Set are distinct, so there is no need to remove
+	// duplicates.
+	return &SortedSet{
+		vals: sort.Merge(set.Values()),
+	}
+}
+
+// Set returns a new Set initialized with the values of s.
+//
+// It runs in O(n) time complexity.
+func (s *SortedSet /*[T]*/) Set() Set /*[T]*/ {
+	return MakeFrom(s.vals...)
+}
+
+// Add adds value(s) to the set s. If v is already in s this has no effect.
+//
+// It runs in O(n + m log m) time complexity where n is the number of values
+// in s and m the number of values to add: the values to add are sorted and
+// merged with the existing values. It is therefore much more efficient to add
+// values in batches than one at a time.
+func (s *SortedSet /*[T]*/) Add(vs ...T) {
+	if len(vs) == 0 {
+		return
+	}
+	add := dedupSorted(sort.Merge(vs))
+
+	// fast path when the values are all larger than the existing ones, e.g.
+	// when the set is built from already sorted values.
+	if n := len(s.vals); n == 0 || s.vals[n-1] < add[0] {
+		s.vals = append(s.vals, add...)
+		return
+	}
+	s.vals = unionSorted(make([]T, 0, len(s.vals)+len(add)), s.vals, add)
+}
+
+// Delete removes v from the set s. If v is not in s this has no effect.
+//
+// It runs in O(n + m log m) time complexity where n is the number of values
+// in s and m the number of values to delete. It does not allocate if a single
+// value is deleted.
+func (s *SortedSet /*[T]*/) Delete(vs ...T) {
+	if len(vs) == 0 {
+		return
+	}
+	del := sort.Merge(vs)
+	s.vals = diffSorted(s.vals[:0], s.vals, del)
+}
+
+// Contains reports whether v is in s.
+//
+// It runs in O(log n) time complexity.
+func (s *SortedSet /*[T]*/) Contains(v T) bool {
+	return search.Binary(s.vals, v) >= 0
+}
+
+// Len reports the number of elements in s.
+func (s *SortedSet /*[T]*/) Len() int {
+	return len(s.vals)
+}
+
+// Values returns a slice of all values present in the set, in ascending
+// order.
+//
+// It runs in O(n) time complexity where n is the number of values in the set.
+func (s *SortedSet /*[T]*/) Values() []T {
+	var vals []T
+	if len(s.vals) > 0 {
+		vals = make([]T, len(s.vals))
+		copy(vals, s.vals)
+	}
+	return vals
+}
+
+// IsDisjoint returns true if the intersection of s and other is an empty
+// set, false otherwise.
+//
+// It runs in O(n + m) time complexity where n and m are the number of values
+// in s and other. It does not allocate.
+func (s *SortedSet /*[T]*/) IsDisjoint(other *SortedSet /*[T]*/) bool {
+	a, b := s.vals, other.vals
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubset returns true if every value of s is in other, false otherwise. If
+// strict is true and s contains the same values as other, then it returns
+// false.
+//
+// It runs in O(n + m) time complexity where n and m are the number of values
+// in s and other. It does not allocate.
+func (s *SortedSet /*[T]*/) IsSubset(other *SortedSet /*[T]*/, strict bool) bool {
+	if (!strict && s.Len() <= other.Len()) || (strict && s.Len() < other.Len()) {
+		a, b := s.vals, other.vals
+		j := 0
+		for _, v := range a {
+			for j < len(b) && b[j] < v {
+				j++
+			}
+			if j == len(b) || b[j] != v {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// IsSuperset returns true if every value of other is in s, false otherwise.
+// If strict is true and other contains the same values as s, then it returns
+// false.
+//
+// It runs in O(n + m) time complexity where n and m are the number of values
+// in s and other. It does not allocate.
+func (s *SortedSet /*[T]*/) IsSuperset(other *SortedSet /*[T]*/, strict bool) bool {
+	return other.IsSubset(s, strict)
+}
+
+// IsEqual returns true if s contains the same values as other, false
+// otherwise.
+//
+// It runs in O(n) time complexity where n is the number of values in s. It
+// does not allocate.
+func (s *SortedSet /*[T]*/) IsEqual(other *SortedSet /*[T]*/) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	for i, v := range s.vals {
+		if other.vals[i] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectSorted returns a new SortedSet that contains the intersection of
+// all sets. If no set is provided, it returns nil. If a single set is
+// provided, it returns a copy of that set (that is, it always creates a new
+// set if at least one set is provided).
+//
+// It runs in O(n) time complexity where n is the total number of values in
+// all sets.
+func IntersectSorted /*[T algo.OrderedComparable]*/ (sets ...*SortedSet /*[T]*/) *SortedSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeSorted /*[T]*/ ()
+	IntersectSortedInto(s, sets...)
+	return s
+}
+
+// IntersectSortedInto is like IntersectSorted, but the intersection of the
+// sets is stored in dst. The dst set's values are not used to find the
+// intersection of values, only as destination storage. If no set is
+// provided, then dst is untouched.
+//
+// Its time complexity is the same as for IntersectSorted.
+func IntersectSortedInto /*[T algo.OrderedComparable]*/ (dst *SortedSet /*[T]*/, sets ...*SortedSet /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+
+	res := sets[0].vals
+	for _, set := range sets[1:] {
+		res = intersectSorted(nil, res, set.vals)
+	}
+	dst.mergeFrom(res)
+}
+
+// UnionSorted returns a new SortedSet that is the union of all sets. If no
+// set is provided, it returns nil. If a single set is provided, it returns a
+// copy of that set (that is, it always creates a new set if at least one set
+// is provided).
+//
+// It runs in O(n * m) time complexity where n is the total number of values
+// in all sets and m is the number of sets (i.e. for all practical purposes
+// where a handful of sets are provided, it runs in O(n)).
+func UnionSorted /*[T algo.OrderedComparable]*/ (sets ...*SortedSet /*[T]*/) *SortedSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeSorted /*[T]*/ ()
+	UnionSortedInto(s, sets...)
+	return s
+}
+
+// UnionSortedInto is like UnionSorted, but the union of the sets is stored in
+// dst. If no set is provided for the union, then dst is untouched.
+//
+// Its time complexity is the same as UnionSorted.
+func UnionSortedInto /*[T algo.OrderedComparable]*/ (dst *SortedSet /*[T]*/, sets ...*SortedSet /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+
+	res := dst.vals
+	for _, set := range sets {
+		res = unionSorted(make([]T, 0, len(res)+len(set.vals)), res, set.vals)
+	}
+	dst.vals = res
+}
+
+// DiffSorted returns a new SortedSet that is the difference of all sets, that
+// is, the values in the first set that are not in any of the other sets. If
+// no set is provided, it returns nil. If a single set is provided, it returns
+// a copy of that set (it always creates a new set if at least one set is
+// provided).
+//
+// It runs in O(n) time complexity where n is the total number of values in
+// all sets.
+func DiffSorted /*[T algo.OrderedComparable]*/ (sets ...*SortedSet /*[T]*/) *SortedSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeSorted /*[T]*/ ()
+	DiffSortedInto(s, sets...)
+	return s
+}
+
+// DiffSortedInto is like DiffSorted, but the difference of the sets is stored
+// in dst. The dst set's values are not used to find the difference of
+// values, only as destination storage. If no set is provided for the
+// difference, then dst is untouched.
+//
+// Its time complexity is the same as DiffSorted.
+func DiffSortedInto /*[T algo.OrderedComparable]*/ (dst *SortedSet /*[T]*/, sets ...*SortedSet /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+
+	res := sets[0].vals
+	for _, set := range sets[1:] {
+		res = diffSorted(nil, res, set.vals)
+	}
+	dst.mergeFrom(res)
+}
+
+// SymmetricDiffSorted returns a new SortedSet that contains values that are
+// in either of the sets but not in any other. If no set is provided, it
+// returns nil. If a single set is provided, it returns a copy of that set (it
+// always creates a new set if at least one set is provided).
+//
+// It runs in O(n * m) time complexity where n is the total number of values
+// in all sets and m is the number of sets (i.e. for all practical purposes
+// where a handful of sets are provided, it runs in O(n)).
+func SymmetricDiffSorted /*[T algo.OrderedComparable]*/ (sets ...*SortedSet /*[T]*/) *SortedSet /*[T]*/ {
+	if len(sets) == 0 {
+		return nil
+	}
+	s := MakeSorted /*[T]*/ ()
+	SymmetricDiffSortedInto(s, sets...)
+	return s
+}
+
+// SymmetricDiffSortedInto is like SymmetricDiffSorted, but the resulting
+// values are stored in dst. The dst set's values are not used to find the
+// symmetric difference, only as destination storage. If no set is provided
+// for the symmetric difference, then dst is untouched.
+//
+// Its time complexity is the same as SymmetricDiffSorted.
+func SymmetricDiffSortedInto /*[T algo.OrderedComparable]*/ (dst *SortedSet /*[T]*/, sets ...*SortedSet /*[T]*/) {
+	if len(sets) == 0 {
+		return
+	}
+
+	// keep track of the values seen in exactly one set so far (once) and of
+	// all values seen so far (seen). For each set, the values seen once are
+	// those previously seen once and not in that set, plus those in that set
+	// and never seen before.
+	once, seen := sets[0].vals, sets[0].vals
+	for _, set := range sets[1:] {
+		once = unionSorted(nil, diffSorted(nil, once, set.vals), diffSorted(nil, set.vals, seen))
+		seen = unionSorted(nil, seen, set.vals)
+	}
+	dst.mergeFrom(once)
+}
+
+// adds the sorted and distinct values vals to s.
+func (s *SortedSet /*[T]*/) mergeFrom(vals []T) {
+	if len(s.vals) == 0 {
+		s.vals = append(s.vals, vals...)
+		return
+	}
+	s.vals = unionSorted(make([]T, 0, len(s.vals)+len(vals)), s.vals, vals)
+}
+
+// removes duplicates from the sorted values vals, in-place.
+func dedupSorted /*[T algo.OrderedComparable]*/ (vals []T) []T {
+	if len(vals) < 2 {
+		return vals
+	}
+	n := 1
+	for _, v := range vals[1:] {
+		if v != vals[n-1] {
+			vals[n] = v
+			n++
+		}
+	}
+	return vals[:n]
+}
+
+// appends the union of the sorted values a and b to dst and returns it.
+func unionSorted /*[T algo.OrderedComparable]*/ (dst, a, b []T) []T {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			dst = append(dst, a[i])
+			i++
+		case a[i] > b[j]:
+			dst = append(dst, b[j])
+			j++
+		default:
+			dst = append(dst, a[i])
+			i++
+			j++
+		}
+	}
+	dst = append(dst, a[i:]...)
+	return append(dst, b[j:]...)
+}
+
+// appends the intersection of the sorted values a and b to dst and returns
+// it.
+func intersectSorted /*[T algo.OrderedComparable]*/ (dst, a, b []T) []T {
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			dst = append(dst, a[i])
+			i++
+			j++
+		}
+	}
+	return dst
+}
+
+// appends the values of the sorted values a that are not in the sorted
+// values b to dst and returns it. The dst slice may share the same
+// underlying array as a, as long as it does not start after it.
+func diffSorted /*[T algo.OrderedComparable]*/ (dst, a, b []T) []T {
+	j := 0
+	for _, v := range a {
+		for j < len(b) && b[j] < v {
+			j++
+		}
+		if j == len(b) || b[j] != v {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,239 @@
+package sets
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkSortedSet_Add(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeSortedFrom(vals...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Add(n + 1 + i)
+			}
+		})
+	}
+}
+
+func BenchmarkSortedSet_Delete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeSortedFrom(vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.Delete(vals[indices[i]])
+			}
+		})
+	}
+}
+
+func BenchmarkSortedSet_Contains(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s := MakeSortedFrom(vals...)
+			indices := indicesSlice(vals, b.N)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s.Contains(vals[indices[i]]) {
+					b.Fatal("Contains returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSortedSet_AddBatch(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			// the batch interleaves with the values of the set, in reverse order
+			vals := sortedSlice(n, 2)
+			batch := make([]int, n)
+			for i, v := range vals {
+				batch[n-1-i] = v - 1
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s := MakeSortedFrom(vals...)
+				s.Add(batch...)
+				if s.Len() != 2*n {
+					b.Fatalf("want len %d, got %d", 2*n, s.Len())
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSortedSet_FromSet(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			set := MakeFrom(sortedSlice(n, 1)...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if s := MakeSortedFromSet(set); s.Len() != n {
+					b.Fatalf("want len %d, got %d", n, s.Len())
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSortedSet_Union(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*SortedSet, nsets)
+				for i := range sets {
+					sets[i] = MakeSortedFrom(sortedSlice(n, 1, (i+1)*n)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := UnionSorted(sets...)
+					if s.Len() != nsets*n {
+						b.Fatalf("want len %d, got %d", nsets*n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkSortedSet_Intersect(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*SortedSet, nsets)
+				for i := range sets {
+					sets[i] = MakeSortedFrom(sortedSlice(n, 1)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := IntersectSorted(sets...)
+					if s.Len() != n {
+						b.Fatalf("want len %d, got %d", n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkSortedSet_Diff(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*SortedSet, nsets)
+				for i := range sets {
+					sets[i] = MakeSortedFrom(sortedSlice(n, 1, (i+1)*n)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := DiffSorted(sets...)
+					if s.Len() != n {
+						b.Fatalf("want len %d, got %d", n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkSortedSet_SymmetricDiff(b *testing.B) {
+	for _, nsets := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sets=%d;n=%d", nsets, n), func(b *testing.B) {
+				sets := make([]*SortedSet, nsets)
+				for i := range sets {
+					sets[i] = MakeSortedFrom(sortedSlice(n, 1, (i+1)*n)...)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					s := SymmetricDiffSorted(sets...)
+					if s.Len() != nsets*n {
+						b.Fatalf("want len %d, got %d", nsets*n, s.Len())
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkSortedSet_IsSubset(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s1, s2 := MakeSortedFrom(vals...), MakeSortedFrom(vals...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsSubset(s2, false) {
+					b.Fatal("want is subset to return true")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSortedSet_IsSuperset(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s1, s2 := MakeSortedFrom(vals...), MakeSortedFrom(vals...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsSuperset(s2, false) {
+					b.Fatal("want is superset to return true")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSortedSet_IsDisjoint(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			v1, v2 := sortedSlice(n, 1), sortedSlice(n, 1, (2*n)+1)
+			s1, s2 := MakeSortedFrom(v1...), MakeSortedFrom(v2...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsDisjoint(s2) {
+					b.Fatal("want is disjoint to return true")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSortedSet_IsEqual(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			s1, s2 := MakeSortedFrom(vals...), MakeSortedFrom(vals...)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !s1.IsEqual(s2) {
+					b.Fatal("want is equal to return true")
+				}
+			}
+		})
+	}
+}
+
+// returns a slice of N valid indices into vals, to be used for benchmarks
@@ -0,0 +1,211 @@
+package sets
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestSortedSet(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var s SortedSet
+		if s.Len() != 0 || s.Values() != nil {
+			t.Fatal("want empty set")
+		}
+		s.Add(1)
+		if !s.Contains(1) || s.Len() != 1 {
+			t.Fatal("want single value")
+		}
+	})
+
+	t.Run("AddDeleteRandom", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		s := MakeSortedCap(100)
+		ref := Make()
+		for i := 0; i < 1000; i++ {
+			// add and delete in batches, including duplicates
+			vs := make([]T, r.Intn(20))
+			for j := range vs {
+				vs[j] = r.Intn(1000)
+			}
+			if r.Intn(3) == 0 {
+				s.Delete(vs...)
+				ref.Delete(vs...)
+			} else {
+				s.Add(vs...)
+				ref.Add(vs...)
+			}
+		}
+
+		want := ref.Values()
+		sort.Ints(want)
+		if got := s.Values(); !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+		if s.Len() != ref.Len() {
+			t.Fatalf("want len %d, got %d", ref.Len(), s.Len())
+		}
+		for i := 0; i < 1000; i++ {
+			if s.Contains(i) != ref.Contains(i) {
+				t.Fatalf("%d: want contains %t", i, ref.Contains(i))
+			}
+		}
+	})
+
+	t.Run("AddDoesNotModifyArgs", func(t *testing.T) {
+		vs := []T{3, 1, 2, 1}
+		s := MakeSortedFrom(vs...)
+		if want := []T{3, 1, 2, 1}; !cmp.Equal(want, vs) {
+			t.Fatalf("want %v, got %v", want, vs)
+		}
+		if want, got := []T{1, 2, 3}, s.Values(); !cmp.Equal(want, got) {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("ValuesIsCopy", func(t *testing.T) {
+		s := MakeSortedFrom(1, 2, 3)
+		vals := s.Values()
+		vals[0] = 10
+		if !s.Contains(1) {
+			t.Fatal("want set unchanged")
+		}
+	})
+
+	t.Run("ConvertSet", func(t *testing.T) {
+		ref := MakeFrom(5, 3, 1, 4, 2)
+		s := MakeSortedFromSet(ref)
+		if want, got := []T{1, 2, 3, 4, 5}, s.Values(); !cmp.Equal(want, got) {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+		if set := s.Set(); !set.IsEqual(ref) {
+			t.Fatalf("want %v, got %v", ref.Values(), set.Values())
+		}
+
+		var empty SortedSet
+		if set := empty.Set(); set.Len() != 0 {
+			t.Fatalf("want empty set, got %v", set.Values())
+		}
+		if s := MakeSortedFromSet(Make()); s.Len() != 0 {
+			t.Fatalf("want empty set, got %v", s.Values())
+		}
+	})
+
+	setOps := []struct {
+		name string
+		fn   func(...*SortedSet) *SortedSet
+		into func(*SortedSet, ...*SortedSet)
+		ref  func(...Set) Set
+	}{
+		{"Union", UnionSorted, UnionSortedInto, Union},
+		{"Intersect", IntersectSorted, IntersectSortedInto, Intersect},
+		{"Diff", DiffSorted, DiffSortedInto, Diff},
+		{"SymmetricDiff", SymmetricDiffSorted, SymmetricDiffSortedInto, SymmetricDiff},
+	}
+	setsValues := [][][]T{
+		nil,
+		{{}},
+		{sortedSlice(5, 1)},
+		{sortedSlice(5, 1), sortedSlice(3, 10)},
+		{sortedSlice(5, 1), sortedSlice(3, 2)},
+		{sortedSlice(3, 1), sortedSlice(2, 1), sortedSlice(1, 1)},
+		{{1, 3, 4, 6}, {3, 4}, {3, 4, 5}, {2, 3, 6}},
+		{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}},
+	}
+	for _, op := range setOps {
+		t.Run(op.name, func(t *testing.T) {
+			for _, vals := range setsValues {
+				for _, dstValues := range [][]T{nil, {}, {55}, {0, 3}} {
+					t.Run(fmt.Sprintf("%v into %v", vals, dstValues), func(t *testing.T) {
+						sets := make([]*SortedSet, len(vals))
+						refs := make([]Set, len(vals))
+						for i, vs := range vals {
+							sets[i] = MakeSortedFrom(vs...)
+							refs[i] = MakeFrom(vs...)
+						}
+
+						var got *SortedSet
+						var want Set
+						if dstValues != nil {
+							got = MakeSortedFrom(dstValues...)
+							op.into(got, sets...)
+							want = MakeFrom(dstValues...)
+							UnionInto(want, op.ref(refs...))
+						} else {
+							got = op.fn(sets...)
+							want = op.ref(refs...)
+						}
+
+						wantVals := want.Values()
+						sort.Ints(wantVals)
+						var gotVals []T
+						if got != nil {
+							gotVals = got.Values()
+						}
+						if !cmp.Equal(wantVals, gotVals, cmpopts.EquateEmpty()) {
+							t.Fatalf("want %v, got %v", wantVals, gotVals)
+						}
+
+						// source sets must not be modified
+						for i, vs := range vals {
+							if got := sets[i].Values(); !cmp.Equal(vs, got, cmpopts.EquateEmpty()) {
+								t.Fatalf("set %d modified: want %v, got %v", i, vs, got)
+							}
+						}
+					})
+				}
+			}
+		})
+	}
+
+	t.Run("IsDisjointSubsetSupersetEqual", func(t *testing.T) {
+		cases := []struct {
+			vals1    []T
+			vals2    []T
+			disjoint bool
+			subset   bool
+			strict   bool
+		}{
+			{nil, nil, true, true, false},
+			{nil, []T{1}, true, true, true},
+			{[]T{1}, []T{1}, false, true, false},
+			{[]T{1}, []T{1, 2}, false, true, true},
+			{[]T{2}, []T{1, 2, 3}, false, true, true},
+			{[]T{3, 4}, []T{1, 2}, true, false, false},
+			{[]T{1, 2, 3, 4}, []T{1, 2, 4, 5}, false, false, false},
+		}
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%v<=>%v", c.vals1, c.vals2), func(t *testing.T) {
+				s1, s2 := MakeSortedFrom(c.vals1...), MakeSortedFrom(c.vals2...)
+
+				if dis1, dis2 := s1.IsDisjoint(s2), s2.IsDisjoint(s1); dis1 != c.disjoint || dis2 != c.disjoint {
+					t.Fatalf("want disjoint %t, got %t and %t", c.disjoint, dis1, dis2)
+				}
+				if sub := s1.IsSubset(s2, false); sub != c.subset {
+					t.Fatalf("want subset %t, got %t", c.subset, sub)
+				}
+				if substr := s1.IsSubset(s2, true); substr != (c.subset && c.strict) {
+					t.Fatalf("want strict subset %t, got %t", c.subset && c.strict, substr)
+				}
+				if sup := s2.IsSuperset(s1, false); sup != c.subset {
+					t.Fatalf("want superset %t, got %t", c.subset, sup)
+				}
+				if supstr := s2.IsSuperset(s1, true); supstr != (c.subset && c.strict) {
+					t.Fatalf("want strict superset %t, got %t", c.subset && c.strict, supstr)
+				}
+				wantEq := c.subset && !c.strict
+				if eq1, eq2 := s1.IsEqual(s2), s2.IsEqual(s1); eq1 != wantEq || eq2 != wantEq {
+					t.Fatalf("want equal %t, got %t and %t", wantEq, eq1, eq2)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,233 @@
+package sketches
+
+import (
+	"math"
+
+	"github.com/mna/algo/hashes"
+	"github.com/mna/algo/internal/binfmt"
+)
+
+// CountMin is a Count-Min sketch, which estimates the number of occurrences
+// of the values in a stream using a fixed amount of memory. It is made of d
+// rows of w counters, each value being counted in one counter of each row,
+// and the estimated count of a value is the smallest of its counters. The
+// estimation is never smaller than the actual count, and it is at most
+// epsilon*N greater with probability 1-delta, where N is the total number
+// of occurrences, w is e/epsilon and d is ln(1/delta).
+//
+// With conservative update, a counter is only incremented as much as needed
+// for the estimated count to account for the new occurrences, which reduces
+// the over-estimation, but the sketch does not support decrementing counts
+// (which this implementation does not support anyway).
+//
+// See "An Improved Data Stream Summary: The Count-Min Sketch and its
+// Applications", by Graham Cormode and S. Muthukrishnan.
+type CountMin /*[T algo.Any]*/ struct {
+	width        uint64
+	depth        uint64
+	counts       []uint64 // depth rows of width counters
+	total        uint64
+	conservative bool
+	hash         hashes.Func /*[T]*/
+}
+
+// MakeCountMin returns a Count-Min sketch that over-estimates the counts by
+// at most epsilon times the total number of occurrences with a probability
+// of 1-delta, using the hash function to hash the values. If conservative
+// is true, it uses conservative update. It panics if epsilon or delta is
+// not between 0 and 1 (exclusively) or if hash is nil.
+func MakeCountMin /*[T algo.Any]*/ (epsilon, delta float64, conservative bool, hash hashes.Func /*[T]*/) *CountMin /*[T]*/ {
+	if !(epsilon > 0 && epsilon < 1) || !(delta > 0 && delta < 1) {
+		panic("sketches: invalid CountMin error bounds")
+	}
+	w := math.Ceil(math.E / epsilon)
+	d := math.Ceil(math.Log(1 / delta))
+	return MakeCountMinSize /*[T]*/ (int(w), int(d), conservative, hash)
+}
+
+// MakeCountMinSize returns a Count-Min sketch with depth rows of width
+// counters, using the hash function to hash the values. If conservative is
+// true, it uses conservative update. If width or depth is smaller than 1, 1
+// is used instead. It panics if hash is nil.
+func MakeCountMinSize /*[T algo.Any]*/ (width, depth int, conservative bool, hash hashes.Func /*[T]*/) *CountMin /*[T]*/ {
+	if hash == nil {
+		panic("sketches: nil hash function")
+	}
+	if width < 1 {
+		width = 1
+	}
+	if depth < 1 {
+		depth = 1
+	}
+	return &CountMin /*[T]*/ {
+		width:        uint64(width),
+		depth:        uint64(depth),
+		counts:       make([]uint64, width*depth),
+		conservative: conservative,
+		hash:         hash,
+	}
+}
+
+// Add adds n occurrences of v to the sketch c. It panics if n is negative.
+//
+// It runs in O(d) time complexity where d is the depth of the sketch. It
+// does not allocate.
+func (c *CountMin /*[T]*/) Add(v T, n int) {
+	if n < 0 {
+		panic("sketches: negative CountMin count")
+	}
+	if n == 0 {
+		return
+	}
+	c.total += uint64(n)
+
+	h1, h2 := countMinHashes(c.hash(v))
+	if !c.conservative {
+		for row := uint64(0); row < c.depth; row++ {
+			c.counts[row*c.width+h1%c.width] += uint64(n)
+			h1 += h2
+		}
+		return
+	}
+
+	// the new estimation is the current one plus n, counters that are
+	// already greater than that are left untouched.
+	est := c.count(h1, h2) + uint64(n)
+	for row := uint64(0); row < c.depth; row++ {
+		i := row*c.width + h1%c.width
+		if c.counts[i] < est {
+			c.counts[i] = est
+		}
+		h1 += h2
+	}
+}
+
+// Count returns the estimated number of occurrences of v in the sketch c,
+// which is never smaller than the actual number of occurrences.
+//
+// It runs in O(d) time complexity where d is the depth of the sketch. It
+// does not allocate.
+func (c *CountMin /*[T]*/) Count(v T) int {
+	h1, h2 := countMinHashes(c.hash(v))
+	return int(c.count(h1, h2))
+}
+
+func (c *CountMin /*[T]*/) count(h1, h2 uint64) uint64 {
+	est := uint64(math.MaxUint64)
+	for row := uint64(0); row < c.depth; row++ {
+		if n := c.counts[row*c.width+h1%c.width]; n < est {
+			est = n
+		}
+		h1 += h2
+	}
+	return est
+}
+
+// returns the two hashes used to derive the counter of a value in each row,
+// as for the Bloom filter.
+func countMinHashes(h uint64) (uint64, uint64) {
+	return h, hashes.Mix(h) | 1
+}
+
+// TotalLen returns the total number of occurrences added to the sketch c.
+func (c *CountMin /*[T]*/) TotalLen() int {
+	return int(c.total)
+}
+
+// Size returns the width and depth of the sketch c.
+func (c *CountMin /*[T]*/) Size() (width, depth int) {
+	return int(c.width), int(c.depth)
+}
+
+// Merge merges the other sketches into c, so that c estimates the counts of
+// the values added to c or any of the other sketches. All sketches must have
+// the same width and depth and must use the same hash function, otherwise
+// ErrIncompatible is returned and c is unchanged (the hash functions cannot
+// be compared, so it is the caller's responsibility to ensure they are the
+// same).
+//
+// The counters are summed, so that the estimations are still never smaller
+// than the actual counts, even if some sketches use conservative update.
+//
+// It runs in O(w*d*n) time complexity where w and d are the width and depth
+// of the sketches, and n is the number of other sketches.
+func (c *CountMin /*[T]*/) Merge(others ...*CountMin /*[T]*/) error {
+	for _, o := range others {
+		if o.width != c.width || o.depth != c.depth {
+			return ErrIncompatible
+		}
+	}
+	for _, o := range others {
+		for i, n := range o.counts {
+			c.counts[i] += n
+		}
+		c.total += o.total
+	}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the sketch c. The
+// hash function is not part of the binary representation, the same hash
+// function must be used when unmarshaling.
+func (c *CountMin /*[T]*/) MarshalBinary() ([]byte, error) {
+	e := binfmt.NewEncoder(binfmt.KindCountMin)
+	e.Uvarint(c.width)
+	e.Uvarint(c.depth)
+	if c.conservative {
+		e.Uvarint(1)
+	} else {
+		e.Uvarint(0)
+	}
+	e.Uvarint(c.total)
+	// most counters are small, so they are encoded as variable-length
+	// integers, the number of counters is known from the width and depth.
+	for _, n := range c.counts {
+		e.Uvarint(n)
+	}
+	return e.Data(), nil
+}
+
+// UnmarshalBinary sets the sketch c to the binary representation in data,
+// as returned by MarshalBinary. The hash function of c is kept, so c must
+// have been created with the same hash function as the one used to create
+// the marshaled sketch (see also UnmarshalCountMin). It returns
+// ErrInvalidData if data is not a valid representation of a Count-Min
+// sketch, in which case c is unchanged.
+func (c *CountMin /*[T]*/) UnmarshalBinary(data []byte) error {
+	d := binfmt.NewDecoder(data, binfmt.KindCountMin)
+	width := d.Int(math.MaxInt32)
+	depth := d.Int(math.MaxInt32)
+	conservative := d.Int(1) == 1
+	total := d.Uvarint()
+	// each counter uses at least one byte (checked with a division so that
+	// width*depth cannot overflow)
+	if width == 0 || depth == 0 || depth > len(data)/width {
+		d.Fail()
+	}
+
+	var counts []uint64
+	if d.Err() == nil {
+		counts = make([]uint64, width*depth)
+		for i := range counts {
+			counts[i] = d.Uvarint()
+		}
+	}
+	if err := d.Close(); err != nil {
+		return err
+	}
+
+	c.width, c.depth, c.conservative, c.total, c.counts = uint64(width), uint64(depth), conservative, total, counts
+	return nil
+}
+
+// UnmarshalCountMin returns the Count-Min sketch represented by data, as
+// returned by MarshalBinary, using hash as hash function. It must be the
+// same hash function as the one used to create the marshaled sketch. It
+// panics if hash is nil.
+func UnmarshalCountMin /*[T algo.Any]*/ (data []byte, hash hashes.Func /*[T]*/) (*CountMin /*[T]*/, error) {
+	c := MakeCountMinSize /*[T]*/ (1, 1, false, hash)
+	if err := c.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
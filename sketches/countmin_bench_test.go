@@ -0,0 +1,56 @@
+package sketches
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mna/algo/hashes"
+)
+
+func BenchmarkCountMin_Add(b *testing.B) {
+	for _, conservative := range []bool{false, true} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("conservative=%t;n=%d", conservative, n), func(b *testing.B) {
+				cm := MakeCountMin(0.001, 0.01, conservative, hashes.Int)
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					cm.Add(i%n, 1)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkCountMin_Count(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			cm := MakeCountMin(0.001, 0.01, false, hashes.Int)
+			for i := 0; i < n; i++ {
+				cm.Add(i, 1)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = cm.Count(i % n)
+			}
+		})
+	}
+}
+
+func BenchmarkCountMin_Merge(b *testing.B) {
+	for _, nsketches := range []int{1, 2, 3, 4, 5} {
+		b.Run(fmt.Sprintf("sketches=%d", nsketches), func(b *testing.B) {
+			cms := make([]*CountMin, nsketches)
+			for i := range cms {
+				cms[i] = MakeCountMin(0.001, 0.01, false, hashes.Int)
+			}
+			cm := MakeCountMin(0.001, 0.01, false, hashes.Int)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = cm.Merge(cms...)
+			}
+		})
+	}
+}
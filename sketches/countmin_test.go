@@ -0,0 +1,249 @@
+package sketches
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mna/algo/hashes"
+)
+
+func TestCountMinPanics(t *testing.T) {
+	cases := []struct {
+		desc string
+		fn   func()
+	}{
+		{"zero epsilon", func() { MakeCountMin(0, 0.01, false, hashes.Int) }},
+		{"epsilon of 1", func() { MakeCountMin(1, 0.01, false, hashes.Int) }},
+		{"zero delta", func() { MakeCountMin(0.01, 0, false, hashes.Int) }},
+		{"delta of 1", func() { MakeCountMin(0.01, 1, false, hashes.Int) }},
+		{"nil hash", func() { MakeCountMin(0.01, 0.01, false, nil) }},
+		{"nil hash size", func() { MakeCountMinSize(10, 10, false, nil) }},
+		{"nil hash unmarshal", func() { _, _ = UnmarshalCountMin(nil, nil) }},
+		{"negative count", func() { MakeCountMinSize(10, 10, false, hashes.Int).Add(1, -1) }},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("want panic")
+				}
+			}()
+			c.fn()
+		})
+	}
+}
+
+func TestMakeCountMin(t *testing.T) {
+	cases := []struct {
+		epsilon, delta float64
+		width, depth   int
+	}{
+		{0.5, 0.5, 6, 1},
+		{0.01, 0.01, 272, 5},
+		{0.001, 0.0001, 2719, 10},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("e=%g;d=%g", c.epsilon, c.delta), func(t *testing.T) {
+			cm := MakeCountMin(c.epsilon, c.delta, false, hashes.Int)
+			w, d := cm.Size()
+			if w != c.width || d != c.depth {
+				t.Fatalf("want size %dx%d, got %dx%d", c.width, c.depth, w, d)
+			}
+		})
+	}
+
+	cm := MakeCountMinSize(0, -1, false, hashes.Int)
+	if w, d := cm.Size(); w != 1 || d != 1 {
+		t.Fatalf("want size 1x1, got %dx%d", w, d)
+	}
+}
+
+func TestCountMin(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	const epsilon, delta = 0.001, 0.01
+	for _, conservative := range []bool{false, true} {
+		t.Run(fmt.Sprintf("conservative=%t", conservative), func(t *testing.T) {
+			cm := MakeCountMin(epsilon, delta, conservative, hashes.Int)
+			counts := make(map[T]int)
+			var total int
+			// a skewed distribution, with a few values that occur often
+			for i := 0; i < 100000; i++ {
+				v := int(r.ExpFloat64() * 1000)
+				n := r.Intn(3)
+				cm.Add(v, n)
+				counts[v] += n
+				total += n
+			}
+			if cm.TotalLen() != total {
+				t.Fatalf("want total %d, got %d", total, cm.TotalLen())
+			}
+			checkCountMin(t, cm, counts, epsilon, delta)
+		})
+	}
+
+	t.Run("Conservative", func(t *testing.T) {
+		// conservative update never over-estimates more than the standard one
+		std := MakeCountMinSize(100, 4, false, hashes.Int)
+		cons := MakeCountMinSize(100, 4, true, hashes.Int)
+		for i := 0; i < 10000; i++ {
+			std.Add(i%1000, 1)
+			cons.Add(i%1000, 1)
+		}
+		var stdErr, consErr int
+		for i := 0; i < 1000; i++ {
+			if cons.Count(i) > std.Count(i) {
+				t.Fatalf("%d: want conservative count at most %d, got %d", i, std.Count(i), cons.Count(i))
+			}
+			stdErr += std.Count(i) - 10
+			consErr += cons.Count(i) - 10
+		}
+		t.Logf("total error: standard %d, conservative %d", stdErr, consErr)
+		if consErr >= stdErr {
+			t.Fatalf("want conservative error smaller than %d, got %d", stdErr, consErr)
+		}
+	})
+}
+
+func TestCountMinMerge(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	const epsilon, delta = 0.001, 0.01
+	for _, nsketches := range []int{1, 2, 5} {
+		t.Run(fmt.Sprintf("sketches=%d", nsketches), func(t *testing.T) {
+			counts := make(map[T]int)
+			all := MakeCountMin(epsilon, delta, false, hashes.Int)
+			cms := make([]*CountMin, nsketches)
+			for i := range cms {
+				cms[i] = MakeCountMin(epsilon, delta, i%2 == 0, hashes.Int)
+				for j := 0; j < 10000; j++ {
+					v := r.Intn(1000)
+					cms[i].Add(v, 1)
+					all.Add(v, 1)
+					counts[v]++
+				}
+			}
+			if err := cms[0].Merge(cms[1:]...); err != nil {
+				t.Fatal(err)
+			}
+			if cms[0].TotalLen() != all.TotalLen() {
+				t.Fatalf("want total %d, got %d", all.TotalLen(), cms[0].TotalLen())
+			}
+			checkCountMin(t, cms[0], counts, epsilon, delta)
+		})
+	}
+
+	t.Run("Incompatible", func(t *testing.T) {
+		cm := MakeCountMinSize(10, 4, false, hashes.Int)
+		cm.Add(1, 1)
+		for _, o := range []*CountMin{MakeCountMinSize(11, 4, false, hashes.Int), MakeCountMinSize(10, 3, false, hashes.Int)} {
+			o.Add(1, 1)
+			if err := cm.Merge(MakeCountMinSize(10, 4, false, hashes.Int), o); err != ErrIncompatible {
+				t.Fatalf("want ErrIncompatible, got %v", err)
+			}
+			if cm.TotalLen() != 1 || cm.Count(1) != 1 {
+				t.Fatal("want sketch unchanged")
+			}
+		}
+	})
+}
+
+func TestCountMinMarshal(t *testing.T) {
+	for _, conservative := range []bool{false, true} {
+		t.Run(fmt.Sprintf("conservative=%t", conservative), func(t *testing.T) {
+			cm := MakeCountMinSize(100, 4, conservative, hashes.Int)
+			for i := 0; i < 1000; i++ {
+				cm.Add(i%200, i)
+			}
+			data, err := cm.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := UnmarshalCountMin(data, hashes.Int)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(cm, got, cmp.AllowUnexported(CountMin{}), cmp.Comparer(func(a, b hashes.Func) bool {
+				return (a == nil) == (b == nil)
+			})) {
+				t.Fatal("want same sketch")
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		cm := MakeCountMinSize(10, 4, false, hashes.Int)
+		cm.Add(1, 10)
+		data, _ := cm.MarshalBinary()
+
+		// number of counters does not match the size
+		cm.width++
+		badCounters, _ := cm.MarshalBinary()
+		cm.width--
+		// zero size
+		cm.width, cm.depth, cm.counts = 0, 0, nil
+		badSize, _ := cm.MarshalBinary()
+		// number of counters overflows a 32-bit int
+		cm.width, cm.depth = 65536, 65537
+		badOverflow, _ := cm.MarshalBinary()
+
+		hll, _ := MakeHyperLogLog(4, hashes.Int).MarshalBinary()
+
+		cases := []struct {
+			desc string
+			data []byte
+		}{
+			{"empty", nil},
+			{"truncated", data[:len(data)-1]},
+			{"trailing", append(append([]byte(nil), data...), 0)},
+			{"counters", badCounters},
+			{"size", badSize},
+			{"overflow", badOverflow},
+			{"hyperloglog", hll},
+		}
+		for _, c := range cases {
+			t.Run(c.desc, func(t *testing.T) {
+				g := MakeCountMinSize(1, 1, false, hashes.Int)
+				g.Add(1, 1)
+				if err := g.UnmarshalBinary(c.data); err != ErrInvalidData {
+					t.Fatalf("want ErrInvalidData, got %v", err)
+				}
+				if w, d := g.Size(); w != 1 || d != 1 || g.TotalLen() != 1 {
+					t.Fatal("want sketch unchanged")
+				}
+			})
+		}
+	})
+}
+
+// checkCountMin checks that the estimated counts of cm are never smaller
+// than the actual counts, and that at most a delta fraction of them exceed
+// the actual count by more than epsilon times the total.
+func checkCountMin(t *testing.T, cm *CountMin, counts map[T]int, epsilon, delta float64) {
+	t.Helper()
+
+	bound := int(epsilon * float64(cm.TotalLen()))
+	var bad int
+	for v, n := range counts {
+		got := cm.Count(v)
+		if got < n {
+			t.Fatalf("%d: want count of at least %d, got %d", v, n, got)
+		}
+		if got > n+bound {
+			bad++
+		}
+	}
+	rate := float64(bad) / float64(len(counts))
+	t.Logf("values over the error bound: %f", rate)
+	if rate > delta {
+		t.Fatalf("want at most %f of the values over the error bound, got %f", delta, rate)
+	}
+}
@@ -0,0 +1,279 @@
+package sketches
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/mna/algo/hashes"
+	"github.com/mna/algo/internal/binfmt"
+)
+
+// HyperLogLog is a sketch that estimates the number of distinct values in a
+// stream, using a fixed amount of memory regardless of the number of values.
+// With a precision p, it uses 2^p registers of one byte and its standard
+// error is 1.04/sqrt(2^p), e.g. about 0.81% with p=14 (16KB).
+//
+// While few registers are set, it uses a sparse representation that stores
+// only the non-zero registers, until it would use more memory than the
+// dense representation.
+//
+// See "HyperLogLog: the analysis of a near-optimal cardinality estimation
+// algorithm", by Philippe Flajolet, Éric Fusy, Olivier Gandouet and Frédéric
+// Meunier, and "HyperLogLog in Practice", by Stefan Heule, Marc Nunkesser and
+// Alexander Hall for the sparse representation.
+type HyperLogLog /*[T algo.Any]*/ struct {
+	p      uint8
+	sparse []uint32    // sorted index<<8 | rank, used while dense is nil
+	dense  []uint8     // rank of each register
+	hash   hashes.Func /*[T]*/
+}
+
+// Range of valid HyperLogLog precisions.
+const (
+	MinHyperLogLogPrecision = 4
+	MaxHyperLogLogPrecision = 18
+)
+
+// MakeHyperLogLog returns a HyperLogLog sketch with the specified precision,
+// using the hash function to hash the values. It panics if precision is not
+// between MinHyperLogLogPrecision and MaxHyperLogLogPrecision or if hash is
+// nil.
+func MakeHyperLogLog /*[T algo.Any]*/ (precision int, hash hashes.Func /*[T]*/) *HyperLogLog /*[T]*/ {
+	if precision < MinHyperLogLogPrecision || precision > MaxHyperLogLogPrecision {
+		panic("sketches: invalid HyperLogLog precision")
+	}
+	if hash == nil {
+		panic("sketches: nil hash function")
+	}
+	return &HyperLogLog /*[T]*/ {p: uint8(precision), hash: hash}
+}
+
+// Add adds the values to the sketch h.
+//
+// It runs in O(1) time complexity for each value with the dense
+// representation, and O(m) with the sparse representation, where m is the
+// number of non-zero registers (at most a quarter of the registers).
+func (h *HyperLogLog /*[T]*/) Add(vs ...T) {
+	for _, v := range vs {
+		hv := h.hash(v)
+		// the first p bits select the register, the rank is the position of
+		// the leftmost 1-bit in the remaining bits.
+		idx := uint32(hv >> (64 - h.p))
+		rank := uint8(bits.LeadingZeros64(hv<<h.p|1<<(h.p-1))) + 1
+		h.set(idx, rank)
+	}
+}
+
+// sets the register idx to rank if it is greater than its current rank.
+func (h *HyperLogLog /*[T]*/) set(idx uint32, rank uint8) {
+	if h.dense != nil {
+		if rank > h.dense[idx] {
+			h.dense[idx] = rank
+		}
+		return
+	}
+
+	i := sort.Search(len(h.sparse), func(i int) bool { return h.sparse[i]>>8 >= idx })
+	if i < len(h.sparse) && h.sparse[i]>>8 == idx {
+		if rank > uint8(h.sparse[i]) {
+			h.sparse[i] = idx<<8 | uint32(rank)
+		}
+		return
+	}
+	h.sparse = append(h.sparse, 0)
+	copy(h.sparse[i+1:], h.sparse[i:])
+	h.sparse[i] = idx<<8 | uint32(rank)
+
+	// each sparse entry uses 4 bytes, switch to dense when it would use less
+	// memory.
+	if len(h.sparse) > (1<<h.p)/4 {
+		h.toDense()
+	}
+}
+
+func (h *HyperLogLog /*[T]*/) toDense() {
+	h.dense = make([]uint8, 1<<h.p)
+	for _, e := range h.sparse {
+		h.dense[e>>8] = uint8(e)
+	}
+	h.sparse = nil
+}
+
+// EstimatedLen returns an estimation of the number of distinct values added
+// to the sketch h. For small cardinalities where some registers are still
+// zero, it uses linear counting, which is more accurate.
+//
+// It runs in O(m) time complexity where m is the number of registers. It
+// does not allocate.
+func (h *HyperLogLog /*[T]*/) EstimatedLen() int {
+	m := float64(uint(1) << h.p)
+
+	var sum float64
+	var zeros int
+	if h.dense != nil {
+		for _, r := range h.dense {
+			if r == 0 {
+				zeros++
+			}
+			sum += math.Ldexp(1, -int(r))
+		}
+	} else {
+		zeros = (1 << h.p) - len(h.sparse)
+		sum = float64(zeros)
+		for _, e := range h.sparse {
+			sum += math.Ldexp(1, -int(uint8(e)))
+		}
+	}
+
+	est := hllAlpha(m) * m * m / sum
+	if est <= 2.5*m && zeros > 0 {
+		est = m * math.Log(m/float64(zeros))
+	}
+	return int(math.Round(est))
+}
+
+// returns the bias correction constant for m registers.
+func hllAlpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/m)
+	}
+}
+
+// Precision returns the precision of the sketch h, the number of registers
+// is 2^precision.
+func (h *HyperLogLog /*[T]*/) Precision() int {
+	return int(h.p)
+}
+
+// Merge merges the other sketches into h, so that h estimates the number of
+// distinct values added to h or any of the other sketches. All sketches must
+// have the same precision and must use the same hash function, otherwise
+// ErrIncompatible is returned and h is unchanged (the hash functions cannot
+// be compared, so it is the caller's responsibility to ensure they are the
+// same).
+//
+// It runs in O(m*n) time complexity where m is the number of registers and
+// n is the number of other sketches.
+func (h *HyperLogLog /*[T]*/) Merge(others ...*HyperLogLog /*[T]*/) error {
+	for _, o := range others {
+		if o.p != h.p {
+			return ErrIncompatible
+		}
+	}
+
+	for _, o := range others {
+		if o.dense == nil {
+			for _, e := range o.sparse {
+				h.set(e>>8, uint8(e))
+			}
+			continue
+		}
+		if h.dense == nil {
+			h.toDense()
+		}
+		for i, r := range o.dense {
+			if r > h.dense[i] {
+				h.dense[i] = r
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the sketch h. The
+// hash function is not part of the binary representation, the same hash
+// function must be used when unmarshaling.
+func (h *HyperLogLog /*[T]*/) MarshalBinary() ([]byte, error) {
+	e := binfmt.NewEncoder(binfmt.KindHyperLogLog)
+	e.Uvarint(uint64(h.p))
+	if h.dense != nil {
+		e.Uvarint(1)
+		e.Bytes(h.dense)
+		return e.Data(), nil
+	}
+
+	// the sparse entries are delta-encoded, as they are sorted.
+	e.Uvarint(0)
+	e.Uvarint(uint64(len(h.sparse)))
+	var prev uint32
+	for _, v := range h.sparse {
+		e.Uvarint(uint64(v - prev))
+		prev = v
+	}
+	return e.Data(), nil
+}
+
+// UnmarshalBinary sets the sketch h to the binary representation in data,
+// as returned by MarshalBinary. The hash function of h is kept, so h must
+// have been created with the same hash function as the one used to create
+// the marshaled sketch (see also UnmarshalHyperLogLog). It returns
+// ErrInvalidData if data is not a valid representation of a HyperLogLog
+// sketch, in which case h is unchanged.
+func (h *HyperLogLog /*[T]*/) UnmarshalBinary(data []byte) error {
+	d := binfmt.NewDecoder(data, binfmt.KindHyperLogLog)
+	p := d.Int(MaxHyperLogLogPrecision)
+	isDense := d.Int(1) == 1
+	m := 1 << uint(p)
+	maxRank := uint8(64 - p + 1)
+
+	var sparse []uint32
+	var dense []uint8
+	if isDense {
+		dense = d.Bytes()
+		if len(dense) != m {
+			d.Fail()
+		}
+		for _, r := range dense {
+			if r > maxRank {
+				d.Fail()
+				break
+			}
+		}
+	} else {
+		n := d.Int(m / 4)
+		if n > 0 {
+			sparse = make([]uint32, n)
+		}
+		var prev uint64
+		for i := range sparse {
+			delta := d.Uvarint()
+			v := prev + delta
+			// entries must be strictly increasing by index with a valid rank
+			if (i > 0 && v>>8 <= prev>>8) || v>>8 >= uint64(m) || uint8(v) == 0 || uint8(v) > maxRank {
+				d.Fail()
+				break
+			}
+			sparse[i] = uint32(v)
+			prev = v
+		}
+	}
+	if err := d.Close(); err != nil {
+		return err
+	}
+	if p < MinHyperLogLogPrecision {
+		return ErrInvalidData
+	}
+
+	h.p, h.sparse, h.dense = uint8(p), sparse, dense
+	return nil
+}
+
+// UnmarshalHyperLogLog returns the HyperLogLog sketch represented by data,
+// as returned by MarshalBinary, using hash as hash function. It must be the
+// same hash function as the one used to create the marshaled sketch. It
+// panics if hash is nil.
+func UnmarshalHyperLogLog /*[T algo.Any]*/ (data []byte, hash hashes.Func /*[T]*/) (*HyperLogLog /*[T]*/, error) {
+	h := MakeHyperLogLog /*[T]*/ (MinHyperLogLogPrecision, hash)
+	if err := h.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
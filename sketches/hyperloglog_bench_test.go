@@ -0,0 +1,59 @@
+package sketches
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mna/algo/hashes"
+)
+
+func BenchmarkHyperLogLog_Add(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			h := MakeHyperLogLog(14, hashes.Int)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				h.Add(i % n)
+			}
+		})
+	}
+}
+
+func BenchmarkHyperLogLog_EstimatedLen(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			h := MakeHyperLogLog(14, hashes.Int)
+			for i := 0; i < n; i++ {
+				h.Add(i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = h.EstimatedLen()
+			}
+		})
+	}
+}
+
+func BenchmarkHyperLogLog_Merge(b *testing.B) {
+	for _, nsketches := range []int{1, 2, 3, 4, 5} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("sketches=%d;n=%d", nsketches, n), func(b *testing.B) {
+				hs := make([]*HyperLogLog, nsketches)
+				for i := range hs {
+					hs[i] = MakeHyperLogLog(14, hashes.Int)
+					for j := 0; j < n; j++ {
+						hs[i].Add(i*n + j)
+					}
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					h := MakeHyperLogLog(14, hashes.Int)
+					_ = h.Merge(hs...)
+				}
+			})
+		}
+	}
+}
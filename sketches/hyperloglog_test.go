@@ -0,0 +1,237 @@
+package sketches
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mna/algo/hashes"
+)
+
+func TestHyperLogLogPanics(t *testing.T) {
+	cases := []struct {
+		desc string
+		fn   func()
+	}{
+		{"precision too small", func() { MakeHyperLogLog(MinHyperLogLogPrecision-1, hashes.Int) }},
+		{"precision too big", func() { MakeHyperLogLog(MaxHyperLogLogPrecision+1, hashes.Int) }},
+		{"nil hash", func() { MakeHyperLogLog(14, nil) }},
+		{"nil hash unmarshal", func() { _, _ = UnmarshalHyperLogLog(nil, nil) }},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("want panic")
+				}
+			}()
+			c.fn()
+		})
+	}
+}
+
+func TestHyperLogLog(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	for _, p := range []int{6, 10, 14} {
+		for _, n := range []int{0, 1, 10, 100, 1000, 10000, 100000} {
+			t.Run(fmt.Sprintf("p=%d;n=%d", p, n), func(t *testing.T) {
+				h := MakeHyperLogLog(p, hashes.Int)
+				start := r.Int()
+				for i := 0; i < n; i++ {
+					// each value is added twice, duplicates are not counted
+					h.Add(start+i, start+i)
+				}
+				checkHyperLogLog(t, h, n)
+			})
+		}
+	}
+}
+
+func TestHyperLogLogSparse(t *testing.T) {
+	h := MakeHyperLogLog(10, hashes.Int)
+	if h.dense != nil {
+		t.Fatal("want sparse representation")
+	}
+	var i int
+	for h.dense == nil {
+		h.Add(i)
+		i++
+		if len(h.sparse) > (1<<10)/4 {
+			t.Fatalf("want at most %d sparse entries, got %d", (1<<10)/4, len(h.sparse))
+		}
+	}
+
+	// the registers are the same after the switch to dense
+	var nonZero int
+	for _, r := range h.dense {
+		if r != 0 {
+			nonZero++
+		}
+	}
+	if want := (1<<10)/4 + 1; nonZero != want {
+		t.Fatalf("want %d non-zero registers, got %d", want, nonZero)
+	}
+	checkHyperLogLog(t, h, i)
+
+	// the sparse and dense representations give the same estimation
+	s := MakeHyperLogLog(10, hashes.Int)
+	d := MakeHyperLogLog(10, hashes.Int)
+	d.toDense()
+	for i := 0; i < 100; i++ {
+		s.Add(i)
+		d.Add(i)
+		if s.EstimatedLen() != d.EstimatedLen() {
+			t.Fatalf("%d: want same estimation, got %d and %d", i, s.EstimatedLen(), d.EstimatedLen())
+		}
+	}
+	if s.dense != nil {
+		t.Fatal("want sparse representation")
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	const n = 10000
+	for _, sizes := range [][]int{{0, 0}, {10, 10}, {10, n}, {n, 10}, {n, n}, {10, 10, n, 0}} {
+		t.Run(fmt.Sprintf("%v", sizes), func(t *testing.T) {
+			// every sketch has some values in common with the next one
+			hs := make([]*HyperLogLog, len(sizes))
+			var start, want int
+			for i, size := range sizes {
+				hs[i] = MakeHyperLogLog(12, hashes.Int)
+				for j := 0; j < size; j++ {
+					hs[i].Add(start + j)
+				}
+				if end := start + size; end > want {
+					want = end
+				}
+				start += size / 2
+			}
+
+			// the merged sketch is the same as a sketch with all values
+			all := MakeHyperLogLog(12, hashes.Int)
+			for i := 0; i < want; i++ {
+				all.Add(i)
+			}
+			if err := hs[0].Merge(hs[1:]...); err != nil {
+				t.Fatal(err)
+			}
+			if got := hs[0].EstimatedLen(); got != all.EstimatedLen() {
+				t.Fatalf("want estimation %d, got %d", all.EstimatedLen(), got)
+			}
+			checkHyperLogLog(t, hs[0], want)
+		})
+	}
+
+	t.Run("Incompatible", func(t *testing.T) {
+		h := MakeHyperLogLog(12, hashes.Int)
+		h.Add(1, 2, 3)
+		o := MakeHyperLogLog(10, hashes.Int)
+		o.Add(4)
+		if err := h.Merge(MakeHyperLogLog(12, hashes.Int), o); err != ErrIncompatible {
+			t.Fatalf("want ErrIncompatible, got %v", err)
+		}
+		if h.EstimatedLen() != 3 {
+			t.Fatal("want sketch unchanged")
+		}
+	})
+}
+
+func TestHyperLogLogMarshal(t *testing.T) {
+	for _, n := range []int{0, 1, 100, 10000} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			h := MakeHyperLogLog(10, hashes.Int)
+			for i := 0; i < n; i++ {
+				h.Add(i)
+			}
+			data, err := h.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := UnmarshalHyperLogLog(data, hashes.Int)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Precision() != h.Precision() || !cmp.Equal(h.sparse, got.sparse) || !cmp.Equal(h.dense, got.dense) {
+				t.Fatal("want same sketch")
+			}
+			if got.EstimatedLen() != h.EstimatedLen() {
+				t.Fatalf("want estimation %d, got %d", h.EstimatedLen(), got.EstimatedLen())
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		h := MakeHyperLogLog(10, hashes.Int)
+		h.Add(1, 2, 3)
+		sparse, _ := h.MarshalBinary()
+
+		// sparse entries out of order
+		h.sparse[0], h.sparse[1] = h.sparse[1], h.sparse[0]
+		badOrder, _ := h.MarshalBinary()
+		h.sparse[0], h.sparse[1] = h.sparse[1], h.sparse[0]
+		// sparse entry with a zero rank
+		h.sparse[0] &^= 0xFF
+		badRank, _ := h.MarshalBinary()
+		// precision out of range
+		h.p = MinHyperLogLogPrecision - 1
+		badPrecision, _ := h.MarshalBinary()
+		h.p = 10
+
+		h.toDense()
+		dense, _ := h.MarshalBinary()
+		// rank greater than the number of bits
+		h.dense[0] = 64
+		badDense, _ := h.MarshalBinary()
+		// number of registers does not match the precision
+		h.dense = h.dense[1:]
+		badRegisters, _ := h.MarshalBinary()
+
+		cases := []struct {
+			desc string
+			data []byte
+		}{
+			{"empty", nil},
+			{"truncated sparse", sparse[:len(sparse)-1]},
+			{"trailing sparse", append(append([]byte(nil), sparse...), 0)},
+			{"truncated dense", dense[:len(dense)-1]},
+			{"trailing dense", append(append([]byte(nil), dense...), 0)},
+			{"order", badOrder},
+			{"rank", badRank},
+			{"precision", badPrecision},
+			{"dense rank", badDense},
+			{"registers", badRegisters},
+		}
+		for _, c := range cases {
+			t.Run(c.desc, func(t *testing.T) {
+				g := MakeHyperLogLog(14, hashes.Int)
+				g.Add(1)
+				if err := g.UnmarshalBinary(c.data); err != ErrInvalidData {
+					t.Fatalf("want ErrInvalidData, got %v", err)
+				}
+				if g.Precision() != 14 || g.EstimatedLen() != 1 {
+					t.Fatal("want sketch unchanged")
+				}
+			})
+		}
+	})
+}
+
+// checkHyperLogLog checks that the estimation of h is within 5 standard
+// errors of the actual number of distinct values n.
+func checkHyperLogLog(t *testing.T, h *HyperLogLog, n int) {
+	t.Helper()
+
+	got := h.EstimatedLen()
+	stdErr := 1.04 / math.Sqrt(float64(uint(1)<<uint(h.Precision())))
+	t.Logf("estimation: %d, actual: %d", got, n)
+	if diff := math.Abs(float64(got - n)); diff > 5*stdErr*float64(n)+1 {
+		t.Fatalf("want estimation within %.2f%% of %d, got %d", 500*stdErr, n, got)
+	}
+}
@@ -0,0 +1,20 @@
+package sketches
+
+import (
+	"errors"
+
+	"github.com/mna/algo/internal/binfmt"
+)
+
+type T = int // NOTE: generic type placeholder
+
+// ErrIncompatible is the error returned when merging sketches that do not
+// have the same parameters (e.g. precision or size).
+var ErrIncompatible = errors.New("sketches: incompatible sketches")
+
+// ErrInvalidData is the error returned when unmarshaling data that is not a
+// valid binary representation of the sketch.
+var ErrInvalidData = binfmt.ErrInvalid
+
+// maxInt is the largest value of an int.
+const maxInt = int(^uint(0) >> 1)
@@ -0,0 +1,315 @@
+package sketches
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mna/algo/internal/binfmt"
+)
+
+// TDigest is a t-digest sketch, which estimates the quantiles and the
+// cumulative distribution of a stream of weighted values using a bounded
+// amount of memory. It summarizes the values as centroids (a mean and a
+// weight), which are smaller near the extremes, so that the estimation of
+// the extreme quantiles (e.g. the 99th percentile) is more accurate than
+// that of the median.
+//
+// The accuracy is controlled by the compression, which is the maximum number
+// of centroids (about half of it in practice): the greater the compression,
+// the more accurate and the more memory is used. In practice, the error (in
+// rank) of the estimations is well under 1/compression, and much smaller
+// towards the extremes.
+//
+// Added values are buffered and merged in the centroids when the buffer is
+// full or when an estimation is requested, so the methods that read the
+// sketch may modify its internal representation.
+//
+// See "Computing Extremely Accurate Quantiles Using t-Digests", by Ted
+// Dunning and Otmar Ertl (this is the merging variant with the k1 scale
+// function).
+type TDigest struct {
+	compression float64
+	centroids   []centroid // sorted by mean
+	buf         []centroid // added values not merged yet
+	weight      float64    // total weight of centroids and buf
+	min, max    float64
+}
+
+// a centroid summarizes weight values around their mean.
+type centroid struct {
+	mean, weight float64
+}
+
+// MakeTDigest returns a t-digest sketch with the specified compression. It
+// panics if compression is smaller than 1, infinite or NaN.
+func MakeTDigest(compression float64) *TDigest {
+	if !(compression >= 1) || math.IsInf(compression, 1) {
+		panic("sketches: invalid TDigest compression")
+	}
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Add adds the value v with the specified weight to the sketch t. It panics
+// if v is infinite or NaN, or if weight is not strictly positive and finite.
+//
+// It runs in amortized O(log c) time complexity where c is the compression.
+func (t *TDigest) Add(v, weight float64) {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		panic("sketches: invalid TDigest value")
+	}
+	if !(weight > 0) || math.IsInf(weight, 1) {
+		panic("sketches: invalid TDigest weight")
+	}
+	t.add(centroid{mean: v, weight: weight})
+}
+
+func (t *TDigest) add(c centroid) {
+	if c.mean < t.min {
+		t.min = c.mean
+	}
+	if c.mean > t.max {
+		t.max = c.mean
+	}
+	t.weight += c.weight
+	t.buf = append(t.buf, c)
+	if len(t.buf) >= t.bufferLen() {
+		t.compress()
+	}
+}
+
+// returns the number of values buffered before they get merged in the
+// centroids.
+func (t *TDigest) bufferLen() int {
+	return 5 * int(math.Ceil(t.compression))
+}
+
+// compress merges the buffered values in the centroids.
+func (t *TDigest) compress() {
+	if len(t.buf) == 0 {
+		return
+	}
+
+	all := append(t.buf, t.centroids...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	// merge adjacent centroids as long as the merged centroid does not span
+	// more than one unit of the scale function k.
+	merged := t.centroids[:0]
+	cur := all[0]
+	var before float64 // weight of the centroids before cur
+	limit := t.weight * t.kInverse(t.k(0)+1)
+	for _, c := range all[1:] {
+		if before+cur.weight+c.weight <= limit {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+		merged = append(merged, cur)
+		before += cur.weight
+		limit = t.weight * t.kInverse(t.k(before/t.weight)+1)
+		cur = c
+	}
+	merged = append(merged, cur)
+
+	// the centroids were copied in all before being overwritten by the merged
+	// ones, so all can be reused as buffer.
+	t.centroids = merged
+	t.buf = all[:0]
+}
+
+// k is the k1 scale function, which maps a quantile to a scale where each
+// centroid spans at most one unit.
+func (t *TDigest) k(q float64) float64 {
+	return t.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// kInverse is the inverse of the scale function k, it returns 1 for the
+// values of k that are past the end of the scale.
+func (t *TDigest) kInverse(k float64) float64 {
+	if k >= t.compression/4 {
+		return 1
+	}
+	return (math.Sin(2*math.Pi*k/t.compression) + 1) / 2
+}
+
+// Quantile returns the estimated value at quantile q, that is the value that
+// is greater than or equal to a q fraction of the total weight. It returns
+// NaN if the sketch is empty. It panics if q is not between 0 and 1
+// (inclusively).
+//
+// It runs in O(c) time complexity where c is the compression.
+func (t *TDigest) Quantile(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("sketches: invalid quantile")
+	}
+	if t.weight == 0 {
+		return math.NaN()
+	}
+	t.compress()
+
+	// the estimated distribution interpolates linearly between the minimum
+	// at weight 0, the mean of each centroid at the middle of its weight and
+	// the maximum at the total weight.
+	target := q * t.weight
+	prev := centroid{mean: t.min}
+	var before float64
+	for _, c := range t.centroids {
+		mid := before + c.weight/2
+		if target < mid {
+			return interpolate(target, prev.weight, mid, prev.mean, c.mean)
+		}
+		prev = centroid{mean: c.mean, weight: mid}
+		before += c.weight
+	}
+	return interpolate(target, prev.weight, t.weight, prev.mean, t.max)
+}
+
+// CDF returns the estimated fraction of the total weight that is less than
+// or equal to v. It returns NaN if the sketch is empty.
+//
+// It runs in O(c) time complexity where c is the compression.
+func (t *TDigest) CDF(v float64) float64 {
+	if t.weight == 0 {
+		return math.NaN()
+	}
+	if v < t.min {
+		return 0
+	}
+	if v >= t.max {
+		return 1
+	}
+	t.compress()
+
+	// inverse of the interpolation used by Quantile, on the last segment that
+	// starts at or before v.
+	prev := centroid{mean: t.min}
+	var before float64
+	for _, c := range t.centroids {
+		mid := before + c.weight/2
+		if v < c.mean {
+			return interpolate(v, prev.mean, c.mean, prev.weight, mid) / t.weight
+		}
+		prev = centroid{mean: c.mean, weight: mid}
+		before += c.weight
+	}
+	return interpolate(v, prev.mean, t.max, prev.weight, t.weight) / t.weight
+}
+
+// interpolate returns the value at x on the line from (x0, y0) to (x1, y1).
+func interpolate(x, x0, x1, y0, y1 float64) float64 {
+	if x1 <= x0 {
+		return y0
+	}
+	return y0 + (x-x0)/(x1-x0)*(y1-y0)
+}
+
+// TotalWeight returns the total weight of the values added to the sketch t.
+func (t *TDigest) TotalWeight() float64 {
+	return t.weight
+}
+
+// Compression returns the compression of the sketch t.
+func (t *TDigest) Compression() float64 {
+	return t.compression
+}
+
+// Merge merges the other sketches into t, so that t estimates the
+// distribution of the values added to t or any of the other sketches. The
+// other sketches may have a different compression, the merged sketch keeps
+// the compression of t.
+//
+// It runs in O(m log m) time complexity where m is the total number of
+// centroids.
+func (t *TDigest) Merge(others ...*TDigest) {
+	for _, o := range others {
+		// adding to t may compress it, so o's centroids are copied first in
+		// case o is t.
+		cs := append(append([]centroid(nil), o.centroids...), o.buf...)
+		for _, c := range cs {
+			t.add(c)
+		}
+		// the means of the centroids may not include the extreme values
+		if o.min < t.min {
+			t.min = o.min
+		}
+		if o.max > t.max {
+			t.max = o.max
+		}
+	}
+	t.compress()
+}
+
+// MarshalBinary returns the binary representation of the sketch t.
+func (t *TDigest) MarshalBinary() ([]byte, error) {
+	t.compress()
+
+	e := binfmt.NewEncoder(binfmt.KindTDigest)
+	e.Float64(t.compression)
+	e.Uvarint(uint64(len(t.centroids)))
+	if len(t.centroids) > 0 {
+		e.Float64(t.min)
+		e.Float64(t.max)
+	}
+	for _, c := range t.centroids {
+		e.Float64(c.mean)
+		e.Float64(c.weight)
+	}
+	return e.Data(), nil
+}
+
+// UnmarshalBinary sets the sketch t to the binary representation in data,
+// as returned by MarshalBinary. It returns ErrInvalidData if data is not a
+// valid representation of a t-digest sketch, in which case t is unchanged.
+func (t *TDigest) UnmarshalBinary(data []byte) error {
+	d := binfmt.NewDecoder(data, binfmt.KindTDigest)
+	compression := d.Float64()
+	// each centroid uses 16 bytes
+	n := d.Int(len(data) / 16)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	if n > 0 {
+		lo, hi = d.Float64(), d.Float64()
+	}
+	if !(compression >= 1) || math.IsInf(compression, 1) ||
+		(n > 0 && !(lo <= hi && !math.IsInf(lo, 0) && !math.IsInf(hi, 0))) {
+		d.Fail()
+	}
+
+	var centroids []centroid
+	var weight float64
+	if d.Err() == nil && n > 0 {
+		centroids = make([]centroid, n)
+		prev := lo
+		for i := range centroids {
+			c := centroid{mean: d.Float64(), weight: d.Float64()}
+			// centroids must be sorted within the bounds with a valid weight
+			if !(c.mean >= prev && c.mean <= hi) || !(c.weight > 0) || math.IsInf(c.weight, 1) {
+				d.Fail()
+				break
+			}
+			centroids[i] = c
+			weight += c.weight
+			prev = c.mean
+		}
+	}
+	if err := d.Close(); err != nil {
+		return err
+	}
+
+	t.compression, t.centroids, t.buf = compression, centroids, nil
+	t.weight, t.min, t.max = weight, lo, hi
+	return nil
+}
+
+// UnmarshalTDigest returns the t-digest sketch represented by data, as
+// returned by MarshalBinary.
+func UnmarshalTDigest(data []byte) (*TDigest, error) {
+	t := MakeTDigest(1)
+	if err := t.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,82 @@
+package sketches
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func BenchmarkTDigest_Add(b *testing.B) {
+	for _, compression := range []float64{20, 100, 500} {
+		b.Run(fmt.Sprintf("c=%v", compression), func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+			vs := make([]float64, 1000)
+			for i := range vs {
+				vs[i] = r.NormFloat64()
+			}
+			td := MakeTDigest(compression)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				td.Add(vs[i%len(vs)], 1)
+			}
+		})
+	}
+}
+
+func BenchmarkTDigest_Quantile(b *testing.B) {
+	for _, compression := range []float64{20, 100, 500} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("c=%v;n=%d", compression, n), func(b *testing.B) {
+				r := rand.New(rand.NewSource(1))
+				td := MakeTDigest(compression)
+				for i := 0; i < n; i++ {
+					td.Add(r.NormFloat64(), 1)
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					_ = td.Quantile(0.99)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkTDigest_CDF(b *testing.B) {
+	for _, compression := range []float64{20, 100, 500} {
+		b.Run(fmt.Sprintf("c=%v", compression), func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+			td := MakeTDigest(compression)
+			for i := 0; i < 100000; i++ {
+				td.Add(r.NormFloat64(), 1)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = td.CDF(1)
+			}
+		})
+	}
+}
+
+func BenchmarkTDigest_Merge(b *testing.B) {
+	for _, nsketches := range []int{1, 2, 3, 4, 5} {
+		b.Run(fmt.Sprintf("sketches=%d", nsketches), func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+			tds := make([]*TDigest, nsketches)
+			for i := range tds {
+				tds[i] = MakeTDigest(100)
+				for j := 0; j < 10000; j++ {
+					tds[i].Add(r.NormFloat64(), 1)
+				}
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				td := MakeTDigest(100)
+				td.Merge(tds...)
+			}
+		})
+	}
+}
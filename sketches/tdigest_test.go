@@ -0,0 +1,306 @@
+package sketches
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTDigestPanics(t *testing.T) {
+	cases := []struct {
+		desc string
+		fn   func()
+	}{
+		{"compression too small", func() { MakeTDigest(0.5) }},
+		{"infinite compression", func() { MakeTDigest(math.Inf(1)) }},
+		{"NaN compression", func() { MakeTDigest(math.NaN()) }},
+		{"NaN value", func() { MakeTDigest(100).Add(math.NaN(), 1) }},
+		{"infinite value", func() { MakeTDigest(100).Add(math.Inf(-1), 1) }},
+		{"zero weight", func() { MakeTDigest(100).Add(1, 0) }},
+		{"negative weight", func() { MakeTDigest(100).Add(1, -1) }},
+		{"infinite weight", func() { MakeTDigest(100).Add(1, math.Inf(1)) }},
+		{"NaN weight", func() { MakeTDigest(100).Add(1, math.NaN()) }},
+		{"quantile too small", func() { MakeTDigest(100).Quantile(-0.1) }},
+		{"quantile too big", func() { MakeTDigest(100).Quantile(1.1) }},
+		{"NaN quantile", func() { MakeTDigest(100).Quantile(math.NaN()) }},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("want panic")
+				}
+			}()
+			c.fn()
+		})
+	}
+}
+
+func TestTDigestSmall(t *testing.T) {
+	td := MakeTDigest(100)
+	if !math.IsNaN(td.Quantile(0.5)) || !math.IsNaN(td.CDF(0)) {
+		t.Fatal("want NaN for empty sketch")
+	}
+
+	// with few values, each one is a centroid and the estimations
+	// interpolate linearly between them.
+	for _, v := range []float64{5, 1, 9, 3, 7} {
+		td.Add(v, 1)
+	}
+	quantiles := []struct {
+		q, want float64
+	}{
+		{0, 1}, {0.1, 1}, {0.3, 3}, {0.4, 4}, {0.5, 5}, {0.9, 9}, {1, 9},
+	}
+	for _, c := range quantiles {
+		if got := td.Quantile(c.q); got != c.want {
+			t.Fatalf("quantile %v: want %v, got %v", c.q, c.want, got)
+		}
+	}
+	cdfs := []struct {
+		v, want float64
+	}{
+		{0, 0}, {1, 0.1}, {4, 0.4}, {5, 0.5}, {9, 1}, {10, 1},
+	}
+	for _, c := range cdfs {
+		if got := td.CDF(c.v); math.Abs(got-c.want) > 1e-9 {
+			t.Fatalf("CDF %v: want %v, got %v", c.v, c.want, got)
+		}
+	}
+	if td.TotalWeight() != 5 || td.Compression() != 100 {
+		t.Fatalf("want total weight 5 and compression 100, got %v and %v", td.TotalWeight(), td.Compression())
+	}
+
+	// a single value with a weight
+	td = MakeTDigest(100)
+	td.Add(42, 10)
+	for _, q := range []float64{0, 0.5, 1} {
+		if got := td.Quantile(q); got != 42 {
+			t.Fatalf("quantile %v: want 42, got %v", q, got)
+		}
+	}
+	if td.CDF(41) != 0 || td.CDF(42) != 1 {
+		t.Fatal("want CDF of 0 before and 1 at the value")
+	}
+}
+
+var tdigestDistributions = []struct {
+	desc string
+	gen  func(*rand.Rand) float64
+}{
+	{"uniform", func(r *rand.Rand) float64 { return r.Float64() * 1000 }},
+	{"normal", func(r *rand.Rand) float64 { return r.NormFloat64()*10 + 100 }},
+	{"exponential", func(r *rand.Rand) float64 { return r.ExpFloat64() * 50 }},
+	{"discrete", func(r *rand.Rand) float64 { return float64(r.Intn(20)) }},
+}
+
+func TestTDigest(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	for _, dist := range tdigestDistributions {
+		for _, compression := range []float64{20, 100, 500} {
+			t.Run(fmt.Sprintf("%s;c=%v", dist.desc, compression), func(t *testing.T) {
+				td := MakeTDigest(compression)
+				vs := make([]float64, 100000)
+				for i := range vs {
+					vs[i] = dist.gen(r)
+					td.Add(vs[i], 1)
+				}
+				sort.Float64s(vs)
+				checkTDigest(t, td, vs)
+				if limit := int(compression); len(td.centroids) > limit {
+					t.Fatalf("want at most %d centroids, got %d", limit, len(td.centroids))
+				}
+			})
+		}
+	}
+
+	t.Run("Weighted", func(t *testing.T) {
+		// adding a value with weight w is the same as adding it w times
+		td := MakeTDigest(100)
+		var vs []float64
+		for i := 0; i < 10000; i++ {
+			v := r.Float64()
+			w := 1 + r.Intn(5)
+			td.Add(v, float64(w))
+			for j := 0; j < w; j++ {
+				vs = append(vs, v)
+			}
+		}
+		sort.Float64s(vs)
+		checkTDigest(t, td, vs)
+	})
+}
+
+func TestTDigestMerge(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	for _, dist := range tdigestDistributions {
+		for _, nsketches := range []int{1, 2, 5} {
+			t.Run(fmt.Sprintf("%s;sketches=%d", dist.desc, nsketches), func(t *testing.T) {
+				var vs []float64
+				tds := make([]*TDigest, nsketches)
+				for i := range tds {
+					// the sketches may have different compressions
+					tds[i] = MakeTDigest(float64(100 + 50*i))
+					for j := 0; j < 10000*(i+1); j++ {
+						v := dist.gen(r) + float64(i)
+						tds[i].Add(v, 1)
+						vs = append(vs, v)
+					}
+				}
+				tds[0].Merge(tds[1:]...)
+				sort.Float64s(vs)
+				checkTDigest(t, tds[0], vs)
+			})
+		}
+	}
+
+	t.Run("Self", func(t *testing.T) {
+		td := MakeTDigest(100)
+		var vs []float64
+		for i := 0; i < 10000; i++ {
+			v := r.Float64()
+			td.Add(v, 1)
+			vs = append(vs, v, v)
+		}
+		td.Merge(td)
+		sort.Float64s(vs)
+		checkTDigest(t, td, vs)
+	})
+}
+
+func TestTDigestMarshal(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 10000} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			td := MakeTDigest(50)
+			for i := 0; i < n; i++ {
+				td.Add(float64(i%100), 1)
+			}
+			data, err := td.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := UnmarshalTDigest(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Compression() != td.Compression() || got.TotalWeight() != td.TotalWeight() ||
+				!cmp.Equal(td.centroids, got.centroids, cmp.AllowUnexported(centroid{})) {
+				t.Fatal("want same sketch")
+			}
+			for _, q := range []float64{0, 0.1, 0.5, 0.99, 1} {
+				want, got := td.Quantile(q), got.Quantile(q)
+				if want != got && !(math.IsNaN(want) && math.IsNaN(got)) {
+					t.Fatalf("quantile %v: want %v, got %v", q, want, got)
+				}
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		td := MakeTDigest(50)
+		td.Add(1, 1)
+		td.Add(2, 3)
+		data, _ := td.MarshalBinary()
+
+		// compression too small
+		td.compression = 0
+		badCompression, _ := td.MarshalBinary()
+		td.compression = 50
+		// centroids not sorted
+		td.centroids[0], td.centroids[1] = td.centroids[1], td.centroids[0]
+		badOrder, _ := td.MarshalBinary()
+		td.centroids[0], td.centroids[1] = td.centroids[1], td.centroids[0]
+		// centroid out of the bounds
+		td.max = 1.5
+		badBounds, _ := td.MarshalBinary()
+		td.max = 2
+		// zero weight
+		td.centroids[0].weight = 0
+		badWeight, _ := td.MarshalBinary()
+		td.centroids[0].weight = 1
+		// infinite minimum
+		td.min = math.Inf(-1)
+		badMin, _ := td.MarshalBinary()
+
+		cases := []struct {
+			desc string
+			data []byte
+		}{
+			{"empty", nil},
+			{"truncated", data[:len(data)-1]},
+			{"trailing", append(append([]byte(nil), data...), 0)},
+			{"compression", badCompression},
+			{"order", badOrder},
+			{"bounds", badBounds},
+			{"weight", badWeight},
+			{"min", badMin},
+		}
+		for _, c := range cases {
+			t.Run(c.desc, func(t *testing.T) {
+				g := MakeTDigest(10)
+				g.Add(1, 1)
+				if err := g.UnmarshalBinary(c.data); err != ErrInvalidData {
+					t.Fatalf("want ErrInvalidData, got %v", err)
+				}
+				if g.Compression() != 10 || g.TotalWeight() != 1 {
+					t.Fatal("want sketch unchanged")
+				}
+			})
+		}
+	})
+}
+
+// checkTDigest checks that the estimated quantiles and CDF of td are within
+// 1/compression (in rank) of the exact ones for the sorted values vs.
+func checkTDigest(t *testing.T, td *TDigest, vs []float64) {
+	t.Helper()
+
+	n := float64(len(vs))
+	if td.TotalWeight() != n {
+		t.Fatalf("want total weight %v, got %v", n, td.TotalWeight())
+	}
+	if td.Quantile(0) != vs[0] || td.Quantile(1) != vs[len(vs)-1] {
+		t.Fatalf("want extreme quantiles %v and %v, got %v and %v", vs[0], vs[len(vs)-1], td.Quantile(0), td.Quantile(1))
+	}
+
+	eps := 1 / td.Compression()
+	for _, q := range []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999} {
+		// the estimation must be between the values at rank q-eps and q+eps,
+		// but as it interpolates between the centroids, it may be anywhere up
+		// to the next distinct values (which matters with duplicates).
+		got := td.Quantile(q)
+		lo := vs[int(math.Max(0, q-eps)*(n-1))]
+		if i := sort.SearchFloat64s(vs, lo); i > 0 {
+			lo = vs[i-1]
+		}
+		hi := vs[int(math.Min(1, q+eps)*(n-1))]
+		if i := sort.Search(len(vs), func(i int) bool { return vs[i] > hi }); i < len(vs) {
+			hi = vs[i]
+		}
+		if got < lo || got > hi {
+			t.Fatalf("quantile %v: want value within [%v, %v], got %v", q, lo, hi, got)
+		}
+
+		// the CDF of the exact quantile must be within eps of the fraction of
+		// values that are less than it and the fraction of values that are
+		// less than or equal to it (the same, unless there are duplicates).
+		v := vs[int(q*(n-1))]
+		below := float64(sort.SearchFloat64s(vs, v)) / n
+		upTo := float64(sort.Search(len(vs), func(i int) bool { return vs[i] > v })) / n
+		if cdf := td.CDF(v); cdf < below-eps || cdf > upTo+eps {
+			t.Fatalf("CDF %v: want within [%v, %v], got %v", v, below-eps, upTo+eps, cdf)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package sketches
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"github.com/mna/algo/internal/binfmt"
+)
+
+// Item is a value tracked by a TopK sketch, with its estimated count. The
+// estimation is never smaller than the actual count, and it over-estimates
+// it by at most Error.
+type Item /*[T algo.Comparable]*/ struct {
+	Value T
+	Count int
+	Error int
+}
+
+// TopK is a Space-Saving sketch that tracks the k most frequent values (the
+// heavy hitters) of a stream using O(k) memory. Every value that occurs more
+// than N/k times, where N is the total number of occurrences, is guaranteed
+// to be tracked, and the estimated count of a tracked value over-estimates
+// its actual count by at most N/k.
+//
+// When a value that is not tracked is added while k values are tracked, it
+// replaces the value with the smallest count and inherits that count as
+// over-estimation error.
+//
+// See "Efficient Computation of Frequent and Top-k Elements in Data
+// Streams", by Ahmed Metwally, Divyakant Agrawal and Amr El Abbadi, and
+// "Mergeable Summaries", by Pankaj K. Agarwal et al. for the merge.
+type TopK /*[T algo.Comparable]*/ struct {
+	k     int
+	total uint64
+	items topKHeap /*[T]*/
+}
+
+// topKHeap is a min-heap of items by count, with an index of the position
+// of each value in the heap. It implements heap.Interface.
+type topKHeap /*[T algo.Comparable]*/ struct {
+	items []Item /*[T]*/
+	index map[T]int
+}
+
+func (h *topKHeap) Len() int           { return len(h.items) }
+func (h *topKHeap) Less(i, j int) bool { return h.items[i].Count < h.items[j].Count }
+func (h *topKHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].Value] = i
+	h.index[h.items[j].Value] = j
+}
+func (h *topKHeap) Push(x interface{}) {
+	it := x.(Item)
+	h.index[it.Value] = len(h.items)
+	h.items = append(h.items, it)
+}
+func (h *topKHeap) Pop() interface{} {
+	it := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	delete(h.index, it.Value)
+	return it
+}
+
+// MakeTopK returns a TopK sketch that tracks the k most frequent values. It
+// panics if k is smaller than 1.
+func MakeTopK /*[T algo.Comparable]*/ (k int) *TopK /*[T]*/ {
+	if k < 1 {
+		panic("sketches: invalid TopK size")
+	}
+	return &TopK /*[T]*/ {
+		k:     k,
+		items: topKHeap /*[T]*/ {index: make(map[T]int)},
+	}
+}
+
+// Add adds n occurrences of v to the sketch t. It panics if n is negative.
+//
+// It runs in O(log k) time complexity. It only allocates when a value is
+// tracked for the first time while fewer than k values are tracked.
+func (t *TopK /*[T]*/) Add(v T, n int) {
+	if n < 0 {
+		panic("sketches: negative TopK count")
+	}
+	if n == 0 {
+		return
+	}
+	t.total += uint64(n)
+
+	h := &t.items
+	if i, ok := h.index[v]; ok {
+		h.items[i].Count += n
+		heap.Fix(h, i)
+		return
+	}
+	if len(h.items) < t.k {
+		heap.Push(h, Item /*[T]*/ {Value: v, Count: n})
+		return
+	}
+
+	// replace the value with the smallest count
+	smallest := h.items[0]
+	delete(h.index, smallest.Value)
+	h.items[0] = Item /*[T]*/ {Value: v, Count: smallest.Count + n, Error: smallest.Count}
+	h.index[v] = 0
+	heap.Fix(h, 0)
+}
+
+// Count returns the estimated count of v and its maximum over-estimation
+// error. If v is not tracked, it returns 0, 0 and false, in which case the
+// actual count of v is at most the smallest count of the tracked values
+// when k values are tracked.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (t *TopK /*[T]*/) Count(v T) (count, err int, ok bool) {
+	i, ok := t.items.index[v]
+	if !ok {
+		return 0, 0, false
+	}
+	it := t.items.items[i]
+	return it.Count, it.Error, true
+}
+
+// Top returns a new slice with the tracked values, sorted by decreasing
+// estimated count (and increasing error for equal counts). It contains at
+// most k items.
+//
+// It runs in O(k log k) time complexity.
+func (t *TopK /*[T]*/) Top() []Item /*[T]*/ {
+	items := append([]Item /*[T]*/ (nil), t.items.items...)
+	sortItems(items)
+	return items
+}
+
+func sortItems /*[T algo.Comparable]*/ (items []Item /*[T]*/) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return items[i].Error < items[j].Error
+	})
+}
+
+// Len returns the number of tracked values, which is at most k.
+func (t *TopK /*[T]*/) Len() int {
+	return len(t.items.items)
+}
+
+// K returns the maximum number of tracked values of the sketch t.
+func (t *TopK /*[T]*/) K() int {
+	return t.k
+}
+
+// TotalLen returns the total number of occurrences added to the sketch t.
+func (t *TopK /*[T]*/) TotalLen() int {
+	return int(t.total)
+}
+
+// Merge merges the other sketches into t, so that t tracks the most frequent
+// values added to t or any of the other sketches. All sketches must track
+// the same number of values, otherwise ErrIncompatible is returned and t is
+// unchanged.
+//
+// The count of a value is the sum of its counts in each sketch, where a
+// value that is not tracked by a sketch that tracks k values counts as the
+// smallest count of that sketch (and as much error), so that the counts are
+// still never smaller than the actual counts. Only the k values with the
+// largest counts are kept.
+//
+// It runs in O(m log m) time complexity where m is the total number of
+// tracked values in all sketches.
+func (t *TopK /*[T]*/) Merge(others ...*TopK /*[T]*/) error {
+	for _, o := range others {
+		if o.k != t.k {
+			return ErrIncompatible
+		}
+	}
+
+	all := append([]*TopK /*[T]*/ {t}, others...)
+	// collect the candidate values in a deterministic order
+	var items []Item /*[T]*/
+	index := make(map[T]int)
+	for _, s := range all {
+		for _, it := range s.items.items {
+			if _, ok := index[it.Value]; !ok {
+				index[it.Value] = len(items)
+				items = append(items, Item /*[T]*/ {Value: it.Value})
+			}
+		}
+	}
+
+	var total uint64
+	for _, s := range all {
+		var smallest int
+		if len(s.items.items) == s.k {
+			smallest = s.items.items[0].Count
+		}
+		for i := range items {
+			it := &items[i]
+			if j, ok := s.items.index[it.Value]; ok {
+				it.Count += s.items.items[j].Count
+				it.Error += s.items.items[j].Error
+			} else {
+				it.Count += smallest
+				it.Error += smallest
+			}
+		}
+		total += s.total
+	}
+
+	sortItems(items)
+	if len(items) > t.k {
+		items = items[:t.k]
+	}
+	t.total = total
+	t.items = makeTopKHeap(items)
+	return nil
+}
+
+func makeTopKHeap /*[T algo.Comparable]*/ (items []Item /*[T]*/) topKHeap /*[T]*/ {
+	h := topKHeap /*[T]*/ {items: items, index: make(map[T]int, len(items))}
+	for i, it := range items {
+		h.index[it.Value] = i
+	}
+	heap.Init(&h)
+	return h
+}
+
+// MarshalBinaryFunc returns the binary representation of the sketch t,
+// using encode to encode each tracked value. If encode returns an error,
+// it stops and returns that error.
+func (t *TopK /*[T]*/) MarshalBinaryFunc(encode func(T) ([]byte, error)) ([]byte, error) {
+	e := binfmt.NewEncoder(binfmt.KindTopK)
+	e.Uvarint(uint64(t.k))
+	e.Uvarint(t.total)
+	e.Uvarint(uint64(len(t.items.items)))
+	for _, it := range t.items.items {
+		b, err := encode(it.Value)
+		if err != nil {
+			return nil, err
+		}
+		e.Bytes(b)
+		e.Uvarint(uint64(it.Count))
+		e.Uvarint(uint64(it.Error))
+	}
+	return e.Data(), nil
+}
+
+// UnmarshalBinaryFunc sets the sketch t to the binary representation in
+// data, as returned by MarshalBinaryFunc, using decode to decode each
+// tracked value. If decode returns an error, it stops and returns that
+// error. It returns ErrInvalidData if data is not a valid representation
+// of a TopK sketch. In both cases, t is unchanged.
+func (t *TopK /*[T]*/) UnmarshalBinaryFunc(data []byte, decode func([]byte) (T, error)) error {
+	d := binfmt.NewDecoder(data, binfmt.KindTopK)
+	k := d.Int(math.MaxInt32)
+	total := d.Uvarint()
+	n := d.Int(k)
+	// each item uses at least 3 bytes
+	if k == 0 || n*3 > len(data) {
+		d.Fail()
+	}
+
+	var items []Item /*[T]*/
+	if d.Err() == nil && n > 0 {
+		items = make([]Item /*[T]*/, n)
+		seen := make(map[T]bool, n)
+		for i := range items {
+			b := d.Bytes()
+			count := d.Int(maxInt)
+			errCount := d.Int(count)
+			if d.Err() != nil {
+				break
+			}
+			v, err := decode(b)
+			if err != nil {
+				return err
+			}
+			if seen[v] {
+				d.Fail()
+				break
+			}
+			seen[v] = true
+			items[i] = Item /*[T]*/ {Value: v, Count: count, Error: errCount}
+		}
+	}
+	if err := d.Close(); err != nil {
+		return err
+	}
+
+	t.k, t.total, t.items = k, total, makeTopKHeap /*[T]*/ (items)
+	return nil
+}
+
+// UnmarshalTopK returns the TopK sketch represented by data, as returned by
+// MarshalBinaryFunc, using decode to decode each tracked value.
+func UnmarshalTopK /*[T algo.Comparable]*/ (data []byte, decode func([]byte) (T, error)) (*TopK /*[T]*/, error) {
+	t := MakeTopK /*[T]*/ (1)
+	if err := t.UnmarshalBinaryFunc(data, decode); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,66 @@
+package sketches
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func BenchmarkTopK_Add(b *testing.B) {
+	for _, k := range []int{10, 100, 1000} {
+		for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			b.Run(fmt.Sprintf("k=%d;n=%d", k, n), func(b *testing.B) {
+				// a skewed stream of n distinct values
+				z := rand.NewZipf(rand.New(rand.NewSource(1)), 1.1, 1, uint64(n-1))
+				vs := make([]T, 1000)
+				for i := range vs {
+					vs[i] = int(z.Uint64())
+				}
+				tk := MakeTopK(k)
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					tk.Add(vs[i%len(vs)], 1)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkTopK_Top(b *testing.B) {
+	for _, k := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("k=%d", k), func(b *testing.B) {
+			tk := MakeTopK(k)
+			for i := 0; i < k; i++ {
+				tk.Add(i, i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = tk.Top()
+			}
+		})
+	}
+}
+
+func BenchmarkTopK_Merge(b *testing.B) {
+	for _, nsketches := range []int{1, 2, 3, 4, 5} {
+		for _, k := range []int{10, 100, 1000} {
+			b.Run(fmt.Sprintf("sketches=%d;k=%d", nsketches, k), func(b *testing.B) {
+				tks := make([]*TopK, nsketches)
+				for i := range tks {
+					tks[i] = MakeTopK(k)
+					for j := 0; j < 2*k; j++ {
+						tks[i].Add(i*k+j, j)
+					}
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					tk := MakeTopK(k)
+					_ = tk.Merge(tks...)
+				}
+			})
+		}
+	}
+}
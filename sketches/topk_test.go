@@ -0,0 +1,300 @@
+package sketches
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTopKPanics(t *testing.T) {
+	cases := []struct {
+		desc string
+		fn   func()
+	}{
+		{"zero size", func() { MakeTopK(0) }},
+		{"negative count", func() { MakeTopK(1).Add(1, -1) }},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("want panic")
+				}
+			}()
+			c.fn()
+		})
+	}
+}
+
+func TestTopK(t *testing.T) {
+	t.Run("Exact", func(t *testing.T) {
+		// with at most k distinct values, the counts are exact
+		tk := MakeTopK(3)
+		tk.Add(1, 1)
+		tk.Add(2, 5)
+		tk.Add(3, 0)
+		tk.Add(3, 2)
+		tk.Add(1, 2)
+		tk.Add(2, 1)
+
+		want := []Item{{Value: 2, Count: 6}, {Value: 1, Count: 3}, {Value: 3, Count: 2}}
+		if diff := cmp.Diff(want, tk.Top()); diff != "" {
+			t.Fatal(diff)
+		}
+		if tk.Len() != 3 || tk.K() != 3 || tk.TotalLen() != 11 {
+			t.Fatalf("want len 3, k 3 and total 11, got %d, %d and %d", tk.Len(), tk.K(), tk.TotalLen())
+		}
+
+		// a new value replaces the smallest count
+		tk.Add(4, 1)
+		want = []Item{{Value: 2, Count: 6}, {Value: 1, Count: 3}, {Value: 4, Count: 3, Error: 2}}
+		if diff := cmp.Diff(want, tk.Top()); diff != "" {
+			t.Fatal(diff)
+		}
+		if _, _, ok := tk.Count(3); ok {
+			t.Fatal("want 3 not tracked")
+		}
+		if n, err, ok := tk.Count(4); !ok || n != 3 || err != 2 {
+			t.Fatalf("want count 3 and error 2, got %d, %d, %t", n, err, ok)
+		}
+	})
+
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	for _, k := range []int{1, 10, 100} {
+		t.Run(fmt.Sprintf("k=%d", k), func(t *testing.T) {
+			tk := MakeTopK(k)
+			counts := make(map[T]int)
+			z := rand.NewZipf(r, 1.2, 1, 10000)
+			for i := 0; i < 100000; i++ {
+				v := int(z.Uint64())
+				n := 1 + r.Intn(2)
+				tk.Add(v, n)
+				counts[v] += n
+			}
+			checkTopK(t, tk, counts)
+		})
+	}
+}
+
+func TestTopKMerge(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	for _, nsketches := range []int{1, 2, 5} {
+		for _, k := range []int{1, 10, 100} {
+			t.Run(fmt.Sprintf("sketches=%d;k=%d", nsketches, k), func(t *testing.T) {
+				counts := make(map[T]int)
+				tks := make([]*TopK, nsketches)
+				for i := range tks {
+					tks[i] = MakeTopK(k)
+					// each shard has a different distribution of values
+					z := rand.NewZipf(r, 1.1+float64(i)/10, 1, 10000)
+					for j := 0; j < 10000*(i+1); j++ {
+						v := int(z.Uint64())
+						tks[i].Add(v, 1)
+						counts[v]++
+					}
+				}
+				if err := tks[0].Merge(tks[1:]...); err != nil {
+					t.Fatal(err)
+				}
+				checkTopK(t, tks[0], counts)
+			})
+		}
+	}
+
+	t.Run("Small", func(t *testing.T) {
+		// sketches that are not full do not add to the counts of values they
+		// do not track
+		a, b := MakeTopK(3), MakeTopK(3)
+		a.Add(1, 2)
+		a.Add(2, 1)
+		b.Add(2, 3)
+		b.Add(3, 1)
+		if err := a.Merge(b); err != nil {
+			t.Fatal(err)
+		}
+		want := []Item{{Value: 2, Count: 4}, {Value: 1, Count: 2}, {Value: 3, Count: 1}}
+		if diff := cmp.Diff(want, a.Top()); diff != "" {
+			t.Fatal(diff)
+		}
+		if a.TotalLen() != 7 {
+			t.Fatalf("want total 7, got %d", a.TotalLen())
+		}
+	})
+
+	t.Run("Incompatible", func(t *testing.T) {
+		tk := MakeTopK(3)
+		tk.Add(1, 1)
+		o := MakeTopK(2)
+		o.Add(2, 1)
+		if err := tk.Merge(MakeTopK(3), o); err != ErrIncompatible {
+			t.Fatalf("want ErrIncompatible, got %v", err)
+		}
+		if diff := cmp.Diff([]Item{{Value: 1, Count: 1}}, tk.Top()); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestTopKMarshal(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 1000} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			tk := MakeTopK(10)
+			for i := 0; i < n; i++ {
+				tk.Add(i%20, i)
+			}
+			data, err := tk.MarshalBinaryFunc(encodeInt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := UnmarshalTopK(data, decodeInt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.K() != tk.K() || got.TotalLen() != tk.TotalLen() {
+				t.Fatalf("want k %d and total %d, got %d and %d", tk.K(), tk.TotalLen(), got.K(), got.TotalLen())
+			}
+			if diff := cmp.Diff(tk.Top(), got.Top()); diff != "" {
+				t.Fatal(diff)
+			}
+
+			// the unmarshaled sketch keeps working
+			tk.Add(-1, 1000)
+			got.Add(-1, 1000)
+			if diff := cmp.Diff(tk.Top(), got.Top()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+
+	t.Run("Codec", func(t *testing.T) {
+		tk := MakeTopK(10)
+		tk.Add(1, 1)
+		errCodec := errors.New("codec")
+		if _, err := tk.MarshalBinaryFunc(func(T) ([]byte, error) { return nil, errCodec }); err != errCodec {
+			t.Fatalf("want codec error, got %v", err)
+		}
+
+		data, _ := tk.MarshalBinaryFunc(encodeInt)
+		g := MakeTopK(3)
+		if err := g.UnmarshalBinaryFunc(data, func([]byte) (T, error) { return 0, errCodec }); err != errCodec {
+			t.Fatalf("want codec error, got %v", err)
+		}
+		if g.K() != 3 {
+			t.Fatal("want sketch unchanged")
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		tk := MakeTopK(3)
+		tk.Add(1, 2)
+		tk.Add(2, 1)
+		data, _ := tk.MarshalBinaryFunc(encodeInt)
+
+		// more items than k
+		tk.k = 1
+		badLen, _ := tk.MarshalBinaryFunc(encodeInt)
+		// zero k
+		tk.k = 0
+		badK, _ := tk.MarshalBinaryFunc(encodeInt)
+		tk.k = 3
+		// error greater than the count
+		tk.items.items[0].Error = 5
+		badErr, _ := tk.MarshalBinaryFunc(encodeInt)
+		tk.items.items[0].Error = 0
+		// duplicate values
+		dup, _ := tk.MarshalBinaryFunc(func(T) ([]byte, error) { return encodeInt(1) })
+
+		cases := []struct {
+			desc string
+			data []byte
+		}{
+			{"empty", nil},
+			{"truncated", data[:len(data)-1]},
+			{"trailing", append(append([]byte(nil), data...), 0)},
+			{"len", badLen},
+			{"k", badK},
+			{"error", badErr},
+			{"duplicate", dup},
+		}
+		for _, c := range cases {
+			t.Run(c.desc, func(t *testing.T) {
+				g := MakeTopK(5)
+				g.Add(1, 1)
+				if err := g.UnmarshalBinaryFunc(c.data, decodeInt); err != ErrInvalidData {
+					t.Fatalf("want ErrInvalidData, got %v", err)
+				}
+				if g.K() != 5 || g.TotalLen() != 1 {
+					t.Fatal("want sketch unchanged")
+				}
+			})
+		}
+	})
+}
+
+func encodeInt(v T) ([]byte, error) {
+	b := make([]byte, binary.MaxVarintLen64)
+	return b[:binary.PutVarint(b, int64(v))], nil
+}
+
+func decodeInt(b []byte) (T, error) {
+	v, n := binary.Varint(b)
+	if n <= 0 || n != len(b) {
+		return 0, errors.New("invalid int")
+	}
+	return int(v), nil
+}
+
+// checkTopK checks that the tracked values of tk respect the Space-Saving
+// guarantees for the actual counts.
+func checkTopK(t *testing.T, tk *TopK, counts map[T]int) {
+	t.Helper()
+
+	var total int
+	for _, n := range counts {
+		total += n
+	}
+	if tk.TotalLen() != total {
+		t.Fatalf("want total %d, got %d", total, tk.TotalLen())
+	}
+	want := tk.K()
+	if len(counts) < want {
+		want = len(counts)
+	}
+	if tk.Len() != want {
+		t.Fatalf("want len %d, got %d", want, tk.Len())
+	}
+
+	bound := total / tk.K()
+	top := tk.Top()
+	for i, it := range top {
+		n := counts[it.Value]
+		if it.Count < n || it.Count-it.Error > n {
+			t.Fatalf("%d: want count %d within [%d, %d]", it.Value, n, it.Count-it.Error, it.Count)
+		}
+		if it.Error > bound {
+			t.Fatalf("%d: want error at most %d, got %d", it.Value, bound, it.Error)
+		}
+		if i > 0 && it.Count > top[i-1].Count {
+			t.Fatal("want items sorted by decreasing count")
+		}
+	}
+
+	// the values that occur more than total/k times are tracked
+	for v, n := range counts {
+		if _, _, ok := tk.Count(v); n > bound && !ok {
+			t.Fatalf("%d: want heavy hitter with count %d tracked", v, n)
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package skiplists
+
+import (
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentMap is an ordered map of keys to values, backed by a skip list,
+// that is safe for concurrent use by multiple goroutines. It implements the
+// lazy skip list of Herlihy, Lev, Luchangco and Shavit: writers lock only
+// the nodes around the key they change, so that writers of distinct keys
+// mostly do not contend, and readers never lock, so that they are not
+// blocked by concurrent writes. Its zero-value is not ready to use, it must
+// be created with MakeConcurrent.
+//
+// A key is removed by first marking its node as deleted (the point where
+// the key logically leaves the map), then unlinking it. A key is added by
+// linking a new node at each level, then marking it as fully linked (the
+// point where the key logically enters the map). Readers only consider the
+// nodes that are fully linked and not marked.
+type ConcurrentMap /*[K algo.Ordered, V algo.Any]*/ struct {
+	// len is the first field so that it is 64-bit aligned, as required for
+	// atomic operations on 32-bit platforms.
+	len  int64
+	head *cnode /*[K, V]*/ // sentinel before the first node, at all levels
+
+	rmu sync.Mutex // protects r
+	r   *rand.Rand
+}
+
+type cnode /*[K algo.Ordered, V algo.Any]*/ struct {
+	key  K
+	val  atomic.Value   // holds a cvalue
+	next []atomic.Value // holds the next *cnode at each level of the node
+
+	mu          sync.Mutex // locked to change the links from this node or its value
+	marked      int32      // 1 when the node is logically deleted
+	fullyLinked int32      // 1 when the node is linked at all its levels
+}
+
+// cvalue wraps the values of a ConcurrentMap, as an atomic.Value must always
+// store the same concrete type.
+type cvalue /*[V algo.Any]*/ struct {
+	v V
+}
+
+// MakeConcurrent returns a concurrent ordered map of some key and value
+// types, that uses the provided *rand.Rand to select the level of the nodes.
+// Access to r is serialized by the map, but it must not be used elsewhere
+// concurrently. If r is nil, the global source of the math/rand package is
+// used.
+func MakeConcurrent /*[K algo.Ordered, V algo.Any]*/ (r *rand.Rand) *ConcurrentMap /*[K, V]*/ {
+	var k K
+	var v V
+	return &ConcurrentMap /*[K, V]*/ {
+		head: newCNode /*[K, V]*/ (k, v, maxLevel),
+		r:    r,
+	}
+}
+
+func newCNode /*[K algo.Ordered, V algo.Any]*/ (k K, v V, level int) *cnode /*[K, V]*/ {
+	n := &cnode /*[K, V]*/ {key: k, next: make([]atomic.Value, level)}
+	n.val.Store(cvalue /*[V]*/ {v})
+	return n
+}
+
+// returns the next node of n at level i, or nil.
+func (n *cnode /*[K, V]*/) nextAt(i int) *cnode /*[K, V]*/ {
+	next, _ := n.next[i].Load().(*cnode /*[K, V]*/)
+	return next
+}
+
+func (n *cnode /*[K, V]*/) setNextAt(i int, next *cnode /*[K, V]*/) {
+	n.next[i].Store(next)
+}
+
+func (n *cnode /*[K, V]*/) value() V {
+	return n.val.Load().(cvalue /*[V]*/).v
+}
+
+func (n *cnode /*[K, V]*/) isMarked() bool {
+	return atomic.LoadInt32(&n.marked) == 1
+}
+
+func (n *cnode /*[K, V]*/) isFullyLinked() bool {
+	return atomic.LoadInt32(&n.fullyLinked) == 1
+}
+
+// returns true if n is in the map, i.e. it is fully linked and not marked.
+func (n *cnode /*[K, V]*/) isLive() bool {
+	return n.isFullyLinked() && !n.isMarked()
+}
+
+func (m *ConcurrentMap /*[K, V]*/) randomLevel() int {
+	m.rmu.Lock()
+	defer m.rmu.Unlock()
+	return randomLevel(m.r)
+}
+
+// sets preds and succs to the last node with a key smaller than k and the
+// node that follows it at each level, and returns the highest level where
+// the key k was found, or -1.
+func (m *ConcurrentMap /*[K, V]*/) find(k K, preds, succs *[maxLevel]*cnode /*[K, V]*/) int {
+	found := -1
+	pred := m.head
+	for i := maxLevel - 1; i >= 0; i-- {
+		cur := pred.nextAt(i)
+		for cur != nil && cur.key < k {
+			pred, cur = cur, cur.nextAt(i)
+		}
+		if found == -1 && cur != nil && cur.key == k {
+			found = i
+		}
+		preds[i], succs[i] = pred, cur
+	}
+	return found
+}
+
+// returns the first node with a key greater than or equal to k, which may
+// not be live, or nil.
+func (m *ConcurrentMap /*[K, V]*/) search(k K) *cnode /*[K, V]*/ {
+	pred := m.head
+	var cur *cnode /*[K, V]*/
+	for i := maxLevel - 1; i >= 0; i-- {
+		cur = pred.nextAt(i)
+		for cur != nil && cur.key < k {
+			pred, cur = cur, cur.nextAt(i)
+		}
+	}
+	return cur
+}
+
+// returns the last node with a key smaller than k, or smaller than or equal
+// to k if inclusive is true, which may not be live, or nil.
+func (m *ConcurrentMap /*[K, V]*/) searchLast(k K, inclusive bool) *cnode /*[K, V]*/ {
+	pred := m.head
+	for i := maxLevel - 1; i >= 0; i-- {
+		for cur := pred.nextAt(i); cur != nil && (cur.key < k || (inclusive && cur.key == k)); cur = cur.nextAt(i) {
+			pred = cur
+		}
+	}
+	if pred == m.head {
+		return nil
+	}
+	return pred
+}
+
+// Len returns the number of keys in the map m. With concurrent changes, it
+// may not be accurate by the time it returns.
+func (m *ConcurrentMap /*[K, V]*/) Len() int {
+	return int(atomic.LoadInt64(&m.len))
+}
+
+// Get returns the value associated with k and true, or the zero value of V
+// and false if k is not in the map. It never blocks.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *ConcurrentMap /*[K, V]*/) Get(k K) (V, bool) {
+	if n := m.search(k); n != nil && n.key == k && n.isLive() {
+		return n.value(), true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether k is in the map m. It never blocks.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *ConcurrentMap /*[K, V]*/) Contains(k K) bool {
+	_, ok := m.Get(k)
+	return ok
+}
+
+// Put associates the value v with the key k in the map m, replacing the
+// previous value if k is already in m. It returns true if k was added, false
+// if its value was replaced.
+//
+// It runs in O(log n) expected time complexity.
+func (m *ConcurrentMap /*[K, V]*/) Put(k K, v V) bool {
+	var preds, succs [maxLevel]*cnode /*[K, V]*/
+	level := m.randomLevel()
+	for {
+		if found := m.find(k, &preds, &succs); found != -1 {
+			n := succs[found]
+			if n.isMarked() {
+				// being deleted, retry once it is unlinked
+				continue
+			}
+			// wait for a concurrent insert of the same key to complete
+			for !n.isFullyLinked() {
+				runtime.Gosched()
+			}
+			n.mu.Lock()
+			if n.isMarked() {
+				n.mu.Unlock()
+				continue
+			}
+			n.val.Store(cvalue /*[V]*/ {v})
+			n.mu.Unlock()
+			return false
+		}
+
+		// lock the predecessors and check that they are still valid, i.e. not
+		// deleted and still linked to the successors.
+		highest, valid := lockPreds(&preds, level, func(i int, pred *cnode /*[K, V]*/) bool {
+			succ := succs[i]
+			return !pred.isMarked() && (succ == nil || !succ.isMarked()) && pred.nextAt(i) == succ
+		})
+		if !valid {
+			unlockPreds(&preds, highest)
+			continue
+		}
+
+		n := newCNode /*[K, V]*/ (k, v, level)
+		for i := 0; i < level; i++ {
+			n.setNextAt(i, succs[i])
+		}
+		for i := 0; i < level; i++ {
+			preds[i].setNextAt(i, n)
+		}
+		atomic.StoreInt32(&n.fullyLinked, 1)
+		unlockPreds(&preds, highest)
+		atomic.AddInt64(&m.len, 1)
+		return true
+	}
+}
+
+// Delete removes the key k and its associated value from the map m and
+// returns true, or false if k is not in m.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *ConcurrentMap /*[K, V]*/) Delete(k K) bool {
+	var preds, succs [maxLevel]*cnode /*[K, V]*/
+
+	// the node of the key, once it is marked as deleted
+	var victim *cnode /*[K, V]*/
+	for {
+		found := m.find(k, &preds, &succs)
+		if victim == nil {
+			// the node can only be deleted once it is fully linked, and found at
+			// its top level (otherwise it is not fully linked or being deleted).
+			if found == -1 {
+				return false
+			}
+			n := succs[found]
+			if !n.isFullyLinked() || len(n.next)-1 != found || n.isMarked() {
+				return false
+			}
+			n.mu.Lock()
+			if n.isMarked() {
+				n.mu.Unlock()
+				return false
+			}
+			atomic.StoreInt32(&n.marked, 1)
+			victim = n
+		}
+
+		// the key is now logically deleted, unlink its node once its
+		// predecessors are locked and still valid.
+		level := len(victim.next)
+		highest, valid := lockPreds(&preds, level, func(i int, pred *cnode /*[K, V]*/) bool {
+			return !pred.isMarked() && pred.nextAt(i) == victim
+		})
+		if !valid {
+			unlockPreds(&preds, highest)
+			continue
+		}
+		for i := level - 1; i >= 0; i-- {
+			preds[i].setNextAt(i, victim.nextAt(i))
+		}
+		victim.mu.Unlock()
+		unlockPreds(&preds, highest)
+		atomic.AddInt64(&m.len, -1)
+		return true
+	}
+}
+
+// locks the distinct predecessors in preds up to level (excluded), from the
+// bottom level up, checking each one with valid. It stops at the first
+// predecessor that is not valid, and returns the highest level locked (-1
+// if none) and whether all predecessors are valid.
+func lockPreds /*[K algo.Ordered, V algo.Any]*/ (preds *[maxLevel]*cnode /*[K, V]*/, level int, valid func(int, *cnode /*[K, V]*/) bool) (int, bool) {
+	highest := -1
+	var prev *cnode /*[K, V]*/
+	for i := 0; i < level; i++ {
+		pred := preds[i]
+		if pred != prev {
+			pred.mu.Lock()
+			prev = pred
+		}
+		highest = i
+		if !valid(i, pred) {
+			return highest, false
+		}
+	}
+	return highest, true
+}
+
+// unlocks the distinct predecessors in preds up to highest (included).
+func unlockPreds /*[K algo.Ordered, V algo.Any]*/ (preds *[maxLevel]*cnode /*[K, V]*/, highest int) {
+	var prev *cnode /*[K, V]*/
+	for i := 0; i <= highest; i++ {
+		if preds[i] != prev {
+			preds[i].mu.Unlock()
+			prev = preds[i]
+		}
+	}
+}
+
+// Floor returns the largest key of the map that is smaller than or equal to
+// k and its value, and true, or the zero values and false if there is no
+// such key. It never blocks.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *ConcurrentMap /*[K, V]*/) Floor(k K) (K, V, bool) {
+	// the nodes only link forward, so if the last node before k is not live,
+	// search again for the last node before that one.
+	n := m.searchLast(k, true)
+	for n != nil && !n.isLive() {
+		n = m.searchLast(n.key, false)
+	}
+	if n == nil {
+		var zero K
+		var v V
+		return zero, v, false
+	}
+	return n.key, n.value(), true
+}
+
+// Ceiling returns the smallest key of the map that is larger than or equal
+// to k and its value, and true, or the zero values and false if there is no
+// such key. It never blocks.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *ConcurrentMap /*[K, V]*/) Ceiling(k K) (K, V, bool) {
+	for n := m.search(k); n != nil; n = n.nextAt(0) {
+		if n.isLive() {
+			return n.key, n.value(), true
+		}
+	}
+	var zero K
+	var v V
+	return zero, v, false
+}
+
+// Ascend calls fn for each key and value of the map, in ascending order of
+// keys, until all keys have been visited or fn returns false. It never
+// blocks, and the map may be modified during the iteration: the keys that
+// are in the map for the whole iteration are visited, the keys that are
+// added or removed during the iteration may or may not be visited.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *ConcurrentMap /*[K, V]*/) Ascend(fn func(K, V) bool) {
+	m.ascendFrom(m.head.nextAt(0), nil, fn)
+}
+
+// AscendRange calls fn for each key and value of the map in the half-open
+// range [lo, hi), in ascending order of keys, until all keys in the range
+// have been visited or fn returns false. Like Ascend, it never blocks and
+// the map may be modified during the iteration.
+//
+// It runs in O(log n + m) expected time complexity where m is the number of
+// keys visited.
+func (m *ConcurrentMap /*[K, V]*/) AscendRange(lo, hi K, fn func(K, V) bool) {
+	if lo < hi {
+		m.ascendFrom(m.search(lo), &hi, fn)
+	}
+}
+
+func (m *ConcurrentMap /*[K, V]*/) ascendFrom(n *cnode /*[K, V]*/, hi *K, fn func(K, V) bool) {
+	for ; n != nil && (hi == nil || n.key < *hi); n = n.nextAt(0) {
+		if n.isLive() && !fn(n.key, n.value()) {
+			return
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package skiplists
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// returns a concurrent map with the keys 0, 2, 4, ..., 2*(n-1), inserted in
+// random order.
+func benchConcurrentMap(n int) *ConcurrentMap {
+	r := rand.New(rand.NewSource(1))
+	m := MakeConcurrent(r)
+	for _, k := range r.Perm(n) {
+		m.Put(2*k, k)
+	}
+	return m
+}
+
+func BenchmarkConcurrentMap_PutDelete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchConcurrentMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				k := 2*(i%n) + 1
+				m.Put(k, i)
+				m.Delete(k)
+			}
+		})
+	}
+}
+
+func BenchmarkConcurrentMap_Get(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchConcurrentMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, ok := m.Get(2 * (i % n)); !ok {
+					b.Fatal("Get returned false")
+				}
+			}
+		})
+	}
+}
+
+// rwMap is a Map protected by a read-write lock, for comparison with
+// ConcurrentMap.
+type rwMap struct {
+	sync.RWMutex
+	m *Map
+}
+
+func BenchmarkConcurrentMap_MixedParallel(b *testing.B) {
+	const n = 100000
+
+	// 90% reads, 10% writes
+	b.Run("lazy", func(b *testing.B) {
+		m := benchConcurrentMap(n)
+		b.ResetTimer()
+
+		b.RunParallel(func(pb *testing.PB) {
+			var i int
+			for pb.Next() {
+				k := 2 * (i % n)
+				if i%10 == 0 {
+					m.Put(k+1, i)
+					m.Delete(k + 1)
+				} else {
+					_, _ = m.Get(k)
+				}
+				i++
+			}
+		})
+	})
+	b.Run("rwmutex", func(b *testing.B) {
+		m := rwMap{m: benchMap(n)}
+		b.ResetTimer()
+
+		b.RunParallel(func(pb *testing.PB) {
+			var i int
+			for pb.Next() {
+				k := 2 * (i % n)
+				if i%10 == 0 {
+					m.Lock()
+					m.m.Put(k+1, i)
+					m.m.Delete(k + 1)
+					m.Unlock()
+				} else {
+					m.RLock()
+					_, _ = m.m.Get(k)
+					m.RUnlock()
+				}
+				i++
+			}
+		})
+	})
+}
@@ -0,0 +1,244 @@
+package skiplists
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestConcurrentMap(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	m := MakeConcurrent(r)
+	if _, ok := m.Get(1); ok || m.Len() != 0 || m.Delete(1) {
+		t.Fatal("want empty map")
+	}
+	if _, _, ok := m.Floor(1); ok {
+		t.Fatal("want no floor")
+	}
+	if _, _, ok := m.Ceiling(1); ok {
+		t.Fatal("want no ceiling")
+	}
+
+	// sequential use behaves like a Map
+	ref := Make(r)
+	for i := 0; i < 10000; i++ {
+		k := r.Intn(1000)
+		if r.Intn(3) == 0 {
+			if got, want := m.Delete(k), ref.Delete(k); got != want {
+				t.Fatalf("%d: want deleted %t, got %t", k, want, got)
+			}
+		} else {
+			v := r.Int()
+			want := !ref.Contains(k)
+			ref.Put(k, v)
+			if got := m.Put(k, v); got != want {
+				t.Fatalf("%d: want added %t, got %t", k, want, got)
+			}
+		}
+	}
+	checkConcurrentMap(t, m, ref)
+
+	for _, k := range []K{-1, 0, 500, 999, 1000} {
+		want, wv, wok := ref.Floor(k)
+		got, gv, gok := m.Floor(k)
+		if got != want || gv != wv || gok != wok {
+			t.Fatalf("%d: want floor %d, got %d", k, want, got)
+		}
+		want, wv, wok = ref.Ceiling(k)
+		got, gv, gok = m.Ceiling(k)
+		if got != want || gv != wv || gok != wok {
+			t.Fatalf("%d: want ceiling %d, got %d", k, want, got)
+		}
+	}
+	for _, c := range [][2]K{{0, 1000}, {100, 200}, {500, 500}, {999, 2000}} {
+		var want, got []K
+		ref.AscendRange(c[0], c[1], func(k K, _ V) bool {
+			want = append(want, k)
+			return true
+		})
+		m.AscendRange(c[0], c[1], func(k K, _ V) bool {
+			got = append(got, k)
+			return true
+		})
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("[%d, %d): %s", c[0], c[1], diff)
+		}
+	}
+}
+
+func TestConcurrentMapFloorMarked(t *testing.T) {
+	// a node that is marked but not yet unlinked, as during a concurrent
+	// Delete, is skipped by Floor.
+	m := MakeConcurrent(rand.New(rand.NewSource(1)))
+	for _, k := range []K{10, 20, 30} {
+		m.Put(k, k)
+	}
+	var preds, succs [maxLevel]*cnode
+	m.find(20, &preds, &succs)
+	succs[0].marked = 1
+
+	cases := []struct {
+		k, want K
+		ok      bool
+	}{
+		{5, 0, false},
+		{10, 10, true},
+		{20, 10, true},
+		{25, 10, true},
+		{30, 30, true},
+	}
+	for _, c := range cases {
+		if k, _, ok := m.Floor(c.k); k != c.want || ok != c.ok {
+			t.Fatalf("%d: want floor %d (%t), got %d (%t)", c.k, c.want, c.ok, k, ok)
+		}
+	}
+}
+
+func TestConcurrentMapParallel(t *testing.T) {
+	const (
+		writers = 8
+		readers = 4
+		keys    = 1000
+		ops     = 5000
+	)
+
+	m := MakeConcurrent(nil)
+
+	// the even keys are always in the map, so readers must always find them
+	for k := 0; k < keys; k += 2 {
+		m.Put(k, k)
+	}
+
+	var wg, rwg sync.WaitGroup
+	done := make(chan struct{})
+	errs := make(chan string, readers)
+	for i := 0; i < readers; i++ {
+		rwg.Add(1)
+		go func(seed int64) {
+			defer rwg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				k := 2 * r.Intn(keys/2)
+				if v, ok := m.Get(k); !ok || v != k {
+					errs <- "even key not found"
+					return
+				}
+				prev, sorted := -1, true
+				m.Ascend(func(k K, v V) bool {
+					sorted = k > prev
+					prev = k
+					return sorted
+				})
+				if !sorted {
+					errs <- "keys out of order"
+					return
+				}
+			}
+		}(int64(i))
+	}
+
+	// writers add and delete the odd keys, each writer owns the keys equal to
+	// its index modulo the number of writers.
+	added := make([]map[K]bool, writers)
+	for i := 0; i < writers; i++ {
+		added[i] = make(map[K]bool)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(i)))
+			for j := 0; j < ops; j++ {
+				k := 2*r.Intn(keys/2) + 1
+				if k%writers != i {
+					continue
+				}
+				if r.Intn(2) == 0 {
+					if m.Put(k, k) == added[i][k] {
+						t.Errorf("%d: unexpected Put result", k)
+						return
+					}
+					added[i][k] = true
+				} else {
+					if m.Delete(k) != added[i][k] {
+						t.Errorf("%d: unexpected Delete result", k)
+						return
+					}
+					delete(added[i], k)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(done)
+	rwg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	ref := Make(nil)
+	for k := 0; k < keys; k += 2 {
+		ref.Put(k, k)
+	}
+	for _, a := range added {
+		for k := range a {
+			ref.Put(k, k)
+		}
+	}
+	checkConcurrentMap(t, m, ref)
+}
+
+// checkConcurrentMap checks that m contains the same keys and values as ref,
+// and that its skip list is valid.
+func checkConcurrentMap(t *testing.T, m *ConcurrentMap, ref *Map) {
+	t.Helper()
+
+	if m.Len() != ref.Len() {
+		t.Fatalf("want len %d, got %d", ref.Len(), m.Len())
+	}
+	var keys []K
+	var vals []V
+	m.Ascend(func(k K, v V) bool {
+		keys = append(keys, k)
+		vals = append(vals, v)
+		return true
+	})
+	if diff := cmp.Diff(ref.Keys(), keys, cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("keys: %s", diff)
+	}
+	if diff := cmp.Diff(ref.Values(), vals, cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("values: %s", diff)
+	}
+	ref.Ascend(func(k K, v V) bool {
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Fatalf("%d: want value %d, got %d", k, v, got)
+		}
+		return true
+	})
+
+	// no node is left marked or partially linked, and each level is sorted
+	for i := 0; i < maxLevel; i++ {
+		var levelKeys []K
+		for n := m.head.nextAt(i); n != nil; n = n.nextAt(i) {
+			if !n.isLive() {
+				t.Fatalf("level %d: node %d is not live", i, n.key)
+			}
+			levelKeys = append(levelKeys, n.key)
+		}
+		if !sort.IntsAreSorted(levelKeys) {
+			t.Fatalf("level %d: keys out of order", i)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package skiplists
+
+import "math/rand"
+
+type (
+	K = int // NOTE: generic type placeholder
+	V = int // NOTE: generic type placeholder
+)
+
+// maxLevel is the maximum number of levels of a skip list. With a 1/4
+// probability of promotion to the next level, it is enough for 4^32 keys.
+const maxLevel = 32
+
+// Map is an ordered map of keys to values, backed by a skip list. Its keys
+// are kept in ascending order as defined by the standard <, <=, >, >=
+// operators, so that they can be iterated in order and queried by range.
+//
+// A skip list is a sorted linked list with additional levels of links that
+// skip over an increasing number of nodes. The level of each node is chosen
+// at random when it is inserted, so that the expected time complexity of
+// operations is O(log n), regardless of the order of insertions. Its
+// zero-value is ready to use, with the global source of the math/rand
+// package.
+//
+// See ConcurrentMap for a skip list that is safe for concurrent use.
+type Map /*[K algo.Ordered, V algo.Any]*/ struct {
+	head  node /*[K, V]*/ // sentinel before the first node
+	level int  // number of levels in use
+	len   int
+	r     *rand.Rand
+}
+
+type node /*[K algo.Ordered, V algo.Any]*/ struct {
+	key  K
+	val  V
+	next []*node /*[K, V]*/ // next node at each level of the node
+}
+
+// Make returns an ordered map of some key and value types, that uses the
+// provided *rand.Rand to select the level of the nodes. If r is nil, the
+// global source of the math/rand package is used.
+func Make /*[K algo.Ordered, V algo.Any]*/ (r *rand.Rand) *Map /*[K, V]*/ {
+	return &Map /*[K, V]*/ {r: r}
+}
+
+// returns a random level for a new node, between 1 and maxLevel, with a
+// probability of 1/4 of going to each next level.
+func randomLevel(r *rand.Rand) int {
+	intn := rand.Intn
+	if r != nil {
+		intn = r.Intn
+	}
+	level := 1
+	for level < maxLevel && intn(4) == 0 {
+		level++
+	}
+	return level
+}
+
+// returns the first node with a key greater than or equal to k, or nil, and
+// the last node with a key smaller than k (which may be the head). If update
+// is not nil, it is set to the last node with a key smaller than k at each
+// level.
+func (m *Map /*[K, V]*/) search(k K, update *[maxLevel]*node /*[K, V]*/) (ge, lt *node /*[K, V]*/) {
+	x := &m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < k {
+			x = x.next[i]
+		}
+		if update != nil {
+			update[i] = x
+		}
+	}
+	if m.level == 0 {
+		return nil, x
+	}
+	return x.next[0], x
+}
+
+// Len returns the number of keys in the map m.
+func (m *Map /*[K, V]*/) Len() int {
+	return m.len
+}
+
+// Get returns the value associated with k and true, or the zero value of V
+// and false if k is not in the map.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Get(k K) (V, bool) {
+	if n, _ := m.search(k, nil); n != nil && n.key == k {
+		return n.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether k is in the map m.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Contains(k K) bool {
+	_, ok := m.Get(k)
+	return ok
+}
+
+// Put associates the value v with the key k in the map m, replacing the
+// previous value if k is already in m.
+//
+// It runs in O(log n) expected time complexity.
+func (m *Map /*[K, V]*/) Put(k K, v V) {
+	var update [maxLevel]*node /*[K, V]*/
+	n, _ := m.search(k, &update)
+	if n != nil && n.key == k {
+		n.val = v
+		return
+	}
+
+	if m.head.next == nil {
+		m.head.next = make([]*node /*[K, V]*/, maxLevel)
+	}
+	level := randomLevel(m.r)
+	for i := m.level; i < level; i++ {
+		update[i] = &m.head
+	}
+	if level > m.level {
+		m.level = level
+	}
+
+	n = &node /*[K, V]*/ {key: k, val: v, next: make([]*node /*[K, V]*/, level)}
+	for i := 0; i < level; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+	m.len++
+}
+
+// Delete removes the key k and its associated value from the map m and
+// returns true, or false if k is not in m.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Delete(k K) bool {
+	var update [maxLevel]*node /*[K, V]*/
+	n, _ := m.search(k, &update)
+	if n == nil || n.key != k {
+		return false
+	}
+	for i := range n.next {
+		update[i].next[i] = n.next[i]
+	}
+	m.len--
+	m.shrink()
+	return true
+}
+
+// DeleteRange removes the keys in the half-open range [lo, hi) and their
+// associated values from the map m, and returns the number of keys removed.
+//
+// It runs in O(log n + m) expected time complexity where m is the number of
+// keys removed. It does not allocate.
+func (m *Map /*[K, V]*/) DeleteRange(lo, hi K) int {
+	if !(lo < hi) {
+		return 0
+	}
+	var update [maxLevel]*node /*[K, V]*/
+	m.search(lo, &update)
+
+	// at each level, link the last node before lo to the first node at or
+	// after hi.
+	var count int
+	for i := 0; i < m.level; i++ {
+		x := update[i].next[i]
+		for x != nil && x.key < hi {
+			if i == 0 {
+				count++
+			}
+			x = x.next[i]
+		}
+		update[i].next[i] = x
+	}
+	m.len -= count
+	m.shrink()
+	return count
+}
+
+// removes the empty levels at the top of the skip list.
+func (m *Map /*[K, V]*/) shrink() {
+	for m.level > 0 && m.head.next[m.level-1] == nil {
+		m.level--
+	}
+}
+
+// Min returns the smallest key of the map and its value, and true, or the
+// zero values and false if the map is empty.
+//
+// It runs in O(1) time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Min() (K, V, bool) {
+	if m.len == 0 {
+		var k K
+		var v V
+		return k, v, false
+	}
+	n := m.head.next[0]
+	return n.key, n.val, true
+}
+
+// Max returns the largest key of the map and its value, and true, or the
+// zero values and false if the map is empty.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Max() (K, V, bool) {
+	if m.len == 0 {
+		var k K
+		var v V
+		return k, v, false
+	}
+	x := &m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			x = x.next[i]
+		}
+	}
+	return x.key, x.val, true
+}
+
+// Floor returns the largest key of the map that is smaller than or equal to
+// k and its value, and true, or the zero values and false if there is no
+// such key.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Floor(k K) (K, V, bool) {
+	ge, lt := m.search(k, nil)
+	if ge != nil && ge.key == k {
+		return ge.key, ge.val, true
+	}
+	if lt == &m.head {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return lt.key, lt.val, true
+}
+
+// Ceiling returns the smallest key of the map that is larger than or equal
+// to k and its value, and true, or the zero values and false if there is no
+// such key.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Ceiling(k K) (K, V, bool) {
+	ge, _ := m.search(k, nil)
+	if ge == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return ge.key, ge.val, true
+}
+
+// Keys returns a slice of all keys of the map, in ascending order.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Keys() []K {
+	var keys []K
+	if m.len > 0 {
+		keys = make([]K, 0, m.len)
+		for n := m.head.next[0]; n != nil; n = n.next[0] {
+			keys = append(keys, n.key)
+		}
+	}
+	return keys
+}
+
+// Values returns a slice of all values of the map, in ascending order of
+// their keys.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Values() []V {
+	var vals []V
+	if m.len > 0 {
+		vals = make([]V, 0, m.len)
+		for n := m.head.next[0]; n != nil; n = n.next[0] {
+			vals = append(vals, n.val)
+		}
+	}
+	return vals
+}
+
+// Ascend calls fn for each key and value of the map, in ascending order of
+// keys, until all keys have been visited or fn returns false. The map must
+// not be modified during the iteration.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Ascend(fn func(K, V) bool) {
+	for it := m.Iter(); it.Next(); {
+		if !fn(it.Key(), it.Value()) {
+			return
+		}
+	}
+}
+
+// AscendRange calls fn for each key and value of the map in the half-open
+// range [lo, hi), in ascending order of keys, until all keys in the range
+// have been visited or fn returns false. The map must not be modified during
+// the iteration.
+//
+// It runs in O(log n + m) expected time complexity where m is the number of
+// keys visited.
+func (m *Map /*[K, V]*/) AscendRange(lo, hi K, fn func(K, V) bool) {
+	for it := m.IterFrom(lo); it.Next() && it.Key() < hi; {
+		if !fn(it.Key(), it.Value()) {
+			return
+		}
+	}
+}
+
+// Iterator is an iterator over the keys and values of a Map, in ascending
+// order of keys. Next advances the iterator to the next key, which is then
+// available via Key and Value, and returns false when there are no more
+// keys. The map must not be modified during the iteration.
+type Iterator /*[K algo.Ordered, V algo.Any]*/ struct {
+	cur, next *node /*[K, V]*/
+}
+
+// Iter returns an iterator over the keys and values of the map m, in
+// ascending order of keys.
+//
+// It runs in O(1) time complexity.
+func (m *Map /*[K, V]*/) Iter() *Iterator /*[K, V]*/ {
+	var first *node /*[K, V]*/
+	if m.len > 0 {
+		first = m.head.next[0]
+	}
+	return &Iterator /*[K, V]*/ {next: first}
+}
+
+// IterFrom returns an iterator over the keys and values of the map m that
+// are greater than or equal to k, in ascending order of keys.
+//
+// It runs in O(log n) expected time complexity.
+func (m *Map /*[K, V]*/) IterFrom(k K) *Iterator /*[K, V]*/ {
+	ge, _ := m.search(k, nil)
+	return &Iterator /*[K, V]*/ {next: ge}
+}
+
+// Next advances the iterator to the next key and returns true, or returns
+// false if there are no more keys.
+func (it *Iterator /*[K, V]*/) Next() bool {
+	it.cur = it.next
+	if it.cur == nil {
+		return false
+	}
+	it.next = it.cur.next[0]
+	return true
+}
+
+// Key returns the current key of the iterator. It panics if Next has not
+// been called or if it returned false.
+func (it *Iterator /*[K, V]*/) Key() K {
+	return it.cur.key
+}
+
+// Value returns the current value of the iterator. It panics if Next has not
+// been called or if it returned false.
+func (it *Iterator /*[K, V]*/) Value() V {
+	return it.cur.val
+}
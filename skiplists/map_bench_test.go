@@ -0,0 +1,127 @@
+package skiplists
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// returns a map with the keys 0, 2, 4, ..., 2*(n-1), inserted in random
+// order.
+func benchMap(n int) *Map {
+	r := rand.New(rand.NewSource(1))
+	m := Make(r)
+	for _, k := range r.Perm(n) {
+		m.Put(2*k, k)
+	}
+	return m
+}
+
+func BenchmarkMap_Put(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// replace existing keys, so that the size of the map is stable
+				m.Put(2*(i%n), i)
+			}
+		})
+	}
+}
+
+func BenchmarkMap_PutDelete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				k := 2*(i%n) + 1
+				m.Put(k, i)
+				m.Delete(k)
+			}
+		})
+	}
+}
+
+func BenchmarkMap_Get(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, ok := m.Get(2 * (i % n)); !ok {
+					b.Fatal("Get returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMap_Floor(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, _, ok := m.Floor(2*(i%n) + 1); !ok {
+					b.Fatal("Floor returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMap_DeleteRange(b *testing.B) {
+	for _, n := range []int{10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// delete and restore 5 keys
+				lo := 2 * (i % (n - 5))
+				m.DeleteRange(lo, lo+10)
+				b.StopTimer()
+				for k := lo; k < lo+10; k += 2 {
+					m.Put(k, k)
+				}
+				b.StartTimer()
+			}
+		})
+	}
+}
+
+func BenchmarkMap_Iter(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				for it := m.Iter(); it.Next(); {
+					_ = it.Key()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMap_AscendRange(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// visit 10 keys
+				lo := 2 * (i % n)
+				m.AscendRange(lo, lo+20, func(K, V) bool { return true })
+			}
+		})
+	}
+}
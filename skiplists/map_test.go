@@ -0,0 +1,266 @@
+package skiplists
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestMap(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var m Map
+		if m.Len() != 0 || m.Keys() != nil || m.Values() != nil {
+			t.Fatal("want empty map")
+		}
+		if _, ok := m.Get(1); ok {
+			t.Fatal("want no value")
+		}
+		if _, _, ok := m.Min(); ok {
+			t.Fatal("want no min")
+		}
+		if _, _, ok := m.Max(); ok {
+			t.Fatal("want no max")
+		}
+		if _, _, ok := m.Floor(1); ok {
+			t.Fatal("want no floor")
+		}
+		if _, _, ok := m.Ceiling(1); ok {
+			t.Fatal("want no ceiling")
+		}
+		if m.Delete(1) || m.DeleteRange(0, 10) != 0 {
+			t.Fatal("want no delete")
+		}
+		if m.Iter().Next() || m.IterFrom(1).Next() {
+			t.Fatal("want no iteration")
+		}
+		m.Put(1, 2)
+		if v, ok := m.Get(1); !ok || v != 2 || m.Len() != 1 {
+			t.Fatal("want single key")
+		}
+	})
+
+	t.Run("PutReplace", func(t *testing.T) {
+		m := Make(rand.New(rand.NewSource(1)))
+		m.Put(1, 10)
+		m.Put(2, 20)
+		m.Put(1, 11)
+		if m.Len() != 2 {
+			t.Fatalf("want len 2, got %d", m.Len())
+		}
+		if v, _ := m.Get(1); v != 11 {
+			t.Fatalf("want replaced value 11, got %d", v)
+		}
+	})
+
+	t.Run("PutDeleteRandom", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		m := Make(r)
+		ref := make(map[K]V)
+		for i := 0; i < 10000; i++ {
+			k := r.Intn(1000)
+			switch r.Intn(10) {
+			case 0:
+				// delete a small range
+				lo, hi := k, k+r.Intn(10)
+				var want int
+				for k := range ref {
+					if k >= lo && k < hi {
+						delete(ref, k)
+						want++
+					}
+				}
+				if got := m.DeleteRange(lo, hi); got != want {
+					t.Fatalf("[%d, %d): want %d deleted, got %d", lo, hi, want, got)
+				}
+			case 1, 2, 3:
+				_, want := ref[k]
+				if got := m.Delete(k); got != want {
+					t.Fatalf("%d: want deleted %t, got %t", k, want, got)
+				}
+				delete(ref, k)
+			default:
+				v := r.Int()
+				m.Put(k, v)
+				ref[k] = v
+			}
+			if i%100 == 0 {
+				checkMap(t, m, ref)
+			}
+		}
+		checkMap(t, m, ref)
+
+		if n := m.DeleteRange(-1, 1000); n != len(ref) {
+			t.Fatalf("want %d deleted, got %d", len(ref), n)
+		}
+		checkMap(t, m, nil)
+		if m.level != 0 {
+			t.Fatalf("want no level, got %d", m.level)
+		}
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		// the same source of random numbers builds the same skip list
+		levels := func() []int {
+			m := Make(rand.New(rand.NewSource(42)))
+			for i := 0; i < 100; i++ {
+				m.Put(i, i)
+			}
+			var ls []int
+			for n := m.head.next[0]; n != nil; n = n.next[0] {
+				ls = append(ls, len(n.next))
+			}
+			return ls
+		}
+		if diff := cmp.Diff(levels(), levels()); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestMapQueries(t *testing.T) {
+	m := Make(rand.New(rand.NewSource(1)))
+	for _, k := range []K{50, 10, 40, 20, 30} {
+		m.Put(k, k*10)
+	}
+	if k, v, ok := m.Min(); !ok || k != 10 || v != 100 {
+		t.Fatalf("want min 10, got %d", k)
+	}
+	if k, v, ok := m.Max(); !ok || k != 50 || v != 500 {
+		t.Fatalf("want max 50, got %d", k)
+	}
+
+	cases := []struct {
+		k           K
+		floor, ceil K // -1 if none
+	}{
+		{5, -1, 10},
+		{10, 10, 10},
+		{15, 10, 20},
+		{30, 30, 30},
+		{45, 40, 50},
+		{50, 50, 50},
+		{55, 50, -1},
+	}
+	for _, c := range cases {
+		k, v, ok := m.Floor(c.k)
+		if (c.floor == -1 && ok) || (c.floor != -1 && (!ok || k != c.floor || v != c.floor*10)) {
+			t.Fatalf("%d: want floor %d, got %d (%t)", c.k, c.floor, k, ok)
+		}
+		k, v, ok = m.Ceiling(c.k)
+		if (c.ceil == -1 && ok) || (c.ceil != -1 && (!ok || k != c.ceil || v != c.ceil*10)) {
+			t.Fatalf("%d: want ceiling %d, got %d (%t)", c.k, c.ceil, k, ok)
+		}
+	}
+
+	ranges := []struct {
+		lo, hi K
+		want   []K
+	}{
+		{0, 100, []K{10, 20, 30, 40, 50}},
+		{10, 50, []K{10, 20, 30, 40}},
+		{11, 50, []K{20, 30, 40}},
+		{20, 21, []K{20}},
+		{21, 29, nil},
+		{30, 30, nil},
+		{40, 20, nil},
+		{60, 70, nil},
+	}
+	for _, c := range ranges {
+		var got []K
+		m.AscendRange(c.lo, c.hi, func(k K, v V) bool {
+			got = append(got, k)
+			return true
+		})
+		if diff := cmp.Diff(c.want, got); diff != "" {
+			t.Fatalf("[%d, %d): %s", c.lo, c.hi, diff)
+		}
+
+		got = nil
+		for it := m.IterFrom(c.lo); it.Next() && it.Key() < c.hi; {
+			got = append(got, it.Key())
+		}
+		if diff := cmp.Diff(c.want, got); diff != "" {
+			t.Fatalf("[%d, %d) iterator: %s", c.lo, c.hi, diff)
+		}
+	}
+
+	// stop early
+	var got []K
+	m.Ascend(func(k K, v V) bool {
+		got = append(got, k)
+		return k < 30
+	})
+	if diff := cmp.Diff([]K{10, 20, 30}, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// delete a range in the middle
+	if n := m.DeleteRange(15, 45); n != 3 {
+		t.Fatalf("want 3 deleted, got %d", n)
+	}
+	checkMap(t, m, map[K]V{10: 100, 50: 500})
+}
+
+// checkMap checks that m contains the same keys and values as ref, and that
+// its skip list is valid.
+func checkMap(t *testing.T, m *Map, ref map[K]V) {
+	t.Helper()
+
+	keys := make([]K, 0, len(ref))
+	vals := make([]V, 0, len(ref))
+	for k := range ref {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	for _, k := range keys {
+		vals = append(vals, ref[k])
+	}
+
+	if m.Len() != len(ref) {
+		t.Fatalf("want len %d, got %d", len(ref), m.Len())
+	}
+	if diff := cmp.Diff(keys, m.Keys(), cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("keys: %s", diff)
+	}
+	if diff := cmp.Diff(vals, m.Values(), cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("values: %s", diff)
+	}
+	for _, k := range keys {
+		if v, ok := m.Get(k); !ok || v != ref[k] {
+			t.Fatalf("%d: want value %d, got %d", k, ref[k], v)
+		}
+	}
+
+	// each level is a sorted sublist of the level below, and the levels above
+	// the level in use are empty.
+	for i := 0; i < maxLevel && m.head.next != nil; i++ {
+		if i >= m.level {
+			if m.head.next[i] != nil {
+				t.Fatalf("level %d: want empty level", i)
+			}
+			continue
+		}
+		below := m.head.next[0]
+		for n := m.head.next[i]; n != nil; n = n.next[i] {
+			if len(n.next) <= i {
+				t.Fatalf("level %d: node %d has %d levels", i, n.key, len(n.next))
+			}
+			if n.next[i] != nil && n.next[i].key <= n.key {
+				t.Fatalf("level %d: %d out of order", i, n.key)
+			}
+			for below != nil && below != n {
+				below = below.next[0]
+			}
+			if below == nil {
+				t.Fatalf("level %d: node %d not in the bottom level", i, n.key)
+			}
+		}
+	}
+}
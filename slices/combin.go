@@ -0,0 +1,383 @@
+package slices
+
+import "math/bits"
+
+// Permutations is an iterator over all permutations of a slice of values,
+// generated using Heap's algorithm. Each permutation is generated from the
+// previous one by swapping a single pair of values, so the order of the
+// permutations is not lexicographic (see NextPermutation for that).
+//
+// The iterator does not allocate once created: the slice returned by Value
+// is reused and updated in-place by each call to Next.
+type Permutations /*[T algo.Any]*/ struct {
+	vals    []T
+	c       []int // stack state of Heap's algorithm
+	i       int
+	started bool
+}
+
+// MakePermutations returns an iterator over all permutations of vals. There
+// are n! permutations, where n is the number of values. The values are
+// copied, so vals is not modified by the iterator.
+func MakePermutations /*[T algo.Any]*/ (vals []T) *Permutations /*[T]*/ {
+	return &Permutations{
+		vals: append(make([]T, 0, len(vals)), vals...),
+		c:    make([]int, len(vals)),
+		i:    1,
+	}
+}
+
+// Next advances the iterator to the next permutation, which is then
+// available via Value. It returns false when there are no more permutations.
+// The first call to Next returns the values in their original order.
+//
+// It runs in O(1) amortized time complexity. It does not allocate.
+func (p *Permutations /*[T]*/) Next() bool {
+	if !p.started {
+		p.started = true
+		return true
+	}
+
+	for p.i < len(p.vals) {
+		if p.c[p.i] < p.i {
+			if p.i%2 == 0 {
+				p.vals[0], p.vals[p.i] = p.vals[p.i], p.vals[0]
+			} else {
+				p.vals[p.c[p.i]], p.vals[p.i] = p.vals[p.i], p.vals[p.c[p.i]]
+			}
+			p.c[p.i]++
+			p.i = 1
+			return true
+		}
+		p.c[p.i] = 0
+		p.i++
+	}
+	return false
+}
+
+// Value returns the current permutation. The returned slice is only valid
+// until the next call to Next, use Clone to keep a copy of it.
+func (p *Permutations /*[T]*/) Value() []T {
+	return p.vals
+}
+
+// NextPermutation rearranges vals in-place into the next lexicographically
+// greater permutation, as defined by the standard < operator. It returns
+// true if there was such a permutation, otherwise vals was the last
+// permutation (sorted in descending order) and it is rearranged into the
+// first one (sorted in ascending order), and it returns false. It supports
+// duplicate values, in which case only distinct permutations are generated.
+//
+// To generate all permutations, start with vals sorted in ascending order
+// and call NextPermutation until it returns false.
+//
+// It runs in O(n) time complexity and O(1) space complexity. It does not
+// allocate.
+func NextPermutation /*[T algo.Ordered]*/ (vals []T) bool {
+	// find the longest non-increasing suffix, the pivot is the value just
+	// before it.
+	i := len(vals) - 2
+	for i >= 0 && vals[i] >= vals[i+1] {
+		i--
+	}
+	if i < 0 {
+		reverse(vals)
+		return false
+	}
+
+	// swap the pivot with the rightmost value greater than it, and reverse the
+	// suffix so that it is the smallest possible.
+	j := len(vals) - 1
+	for vals[j] <= vals[i] {
+		j--
+	}
+	vals[i], vals[j] = vals[j], vals[i]
+	reverse(vals[i+1:])
+	return true
+}
+
+// RankPermutation returns the lexicographic rank of the permutation perm
+// among all permutations of its values, as defined by the standard <
+// operator. The values must be distinct. The rank of the values sorted in
+// ascending order is 0, and the rank of the values sorted in descending order
+// is n!-1. It panics if perm has more than 20 values (12 on 32-bit
+// platforms), as the rank would not fit in an int.
+//
+// It runs in O(n²) time complexity and O(1) space complexity. It does not
+// allocate.
+func RankPermutation /*[T algo.Ordered]*/ (perm []T) int {
+	n := len(perm)
+	if n > maxRankLen {
+		panic("slices: too many values to rank")
+	}
+
+	// the rank is the sum, for each value, of the number of smaller values
+	// that follow it (its Lehmer code digit) times the factorial of the number
+	// of values that follow it.
+	var rank int
+	fact := 1
+	for i := n - 1; i >= 0; i-- {
+		var smaller int
+		for _, v := range perm[i+1:] {
+			if v < perm[i] {
+				smaller++
+			}
+		}
+		rank += smaller * fact
+		fact *= n - i
+	}
+	return rank
+}
+
+// UnrankPermutation returns a new slice with the permutation of vals that has
+// the lexicographic rank rank, as defined by the standard < operator. The
+// values of vals must be distinct and sorted in ascending order. It is the
+// reverse of RankPermutation, and can be used with a random rank to sample
+// permutations in a reproducible way. It panics if rank is not in the range
+// [0, n!) or if vals has more than 20 values (12 on 32-bit platforms).
+//
+// It runs in O(n²) time complexity and O(n) space complexity.
+func UnrankPermutation /*[T algo.Ordered]*/ (vals []T, rank int) []T {
+	n := len(vals)
+	if n > maxRankLen {
+		panic("slices: too many values to unrank")
+	}
+	fact := 1
+	for i := 2; i <= n; i++ {
+		fact *= i
+	}
+	if rank < 0 || rank >= fact {
+		panic("slices: rank out of range")
+	}
+
+	// decompose the rank into its Lehmer code, each digit indicating which of
+	// the remaining values comes next.
+	remain := append(make([]T, 0, n), vals...)
+	perm := make([]T, 0, n)
+	for i := n; i > 0; i-- {
+		fact /= i
+		ix := rank / fact
+		rank %= fact
+
+		perm = append(perm, remain[ix])
+		remain = append(remain[:ix], remain[ix+1:]...)
+	}
+	return perm
+}
+
+// maxRankLen is the largest n such that n! fits in an int: 20! fits in a
+// 64-bit int, 12! in a 32-bit int.
+const maxRankLen = 12 + 8*(bits.UintSize/64)
+
+// Combinations is an iterator over all k-combinations of a slice of values,
+// that is all distinct subsets of exactly k values, without regard to order.
+// The combinations are generated in lexicographic order of the indices of
+// the values, so the values of each combination keep the same relative order
+// as in the source slice.
+//
+// The iterator does not allocate once created: the slice returned by Value
+// is reused and updated in-place by each call to Next.
+type Combinations /*[T algo.Any]*/ struct {
+	vals    []T
+	ixs     []int
+	cur     []T
+	started bool
+	done    bool
+}
+
+// MakeCombinations returns an iterator over all k-combinations of vals.
+// There are n!/(k!(n-k)!) combinations, where n is the number of values. If k
+// is greater than n, there are no combinations, and if it is 0 there is a
+// single empty combination. It panics if k is negative.
+func MakeCombinations /*[T algo.Any]*/ (vals []T, k int) *Combinations /*[T]*/ {
+	if k < 0 {
+		panic("slices: negative combination size")
+	}
+	c := &Combinations{
+		vals: vals,
+		ixs:  make([]int, k),
+		cur:  make([]T, k),
+		done: k > len(vals),
+	}
+	for i := range c.ixs {
+		c.ixs[i] = i
+	}
+	return c
+}
+
+// Next advances the iterator to the next combination, which is then
+// available via Value. It returns false when there are no more combinations.
+//
+// It runs in O(k) time complexity. It does not allocate.
+func (c *Combinations /*[T]*/) Next() bool {
+	if c.done {
+		return false
+	}
+
+	if c.started {
+		// find the rightmost index that can be incremented, increment it and
+		// reset all indices after it to consecutive values.
+		k, n := len(c.ixs), len(c.vals)
+		i := k - 1
+		for i >= 0 && c.ixs[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			c.done = true
+			return false
+		}
+		c.ixs[i]++
+		for j := i + 1; j < k; j++ {
+			c.ixs[j] = c.ixs[j-1] + 1
+		}
+	}
+	c.started = true
+
+	for i, ix := range c.ixs {
+		c.cur[i] = c.vals[ix]
+	}
+	return true
+}
+
+// Value returns the current combination. The returned slice is only valid
+// until the next call to Next, use Clone to keep a copy of it.
+func (c *Combinations /*[T]*/) Value() []T {
+	return c.cur
+}
+
+// PowerSet is an iterator over all subsets of a slice of values, including
+// the empty set and the full set. The values of each subset keep the same
+// relative order as in the source slice.
+//
+// The iterator does not allocate once created: the slice returned by Value
+// is reused and updated in-place by each call to Next.
+type PowerSet /*[T algo.Any]*/ struct {
+	vals    []T
+	mask    []bool
+	cur     []T
+	started bool
+	done    bool
+}
+
+// MakePowerSet returns an iterator over all subsets of vals. There are 2^n
+// subsets, where n is the number of values.
+func MakePowerSet /*[T algo.Any]*/ (vals []T) *PowerSet /*[T]*/ {
+	return &PowerSet{
+		vals: vals,
+		mask: make([]bool, len(vals)),
+		cur:  make([]T, 0, len(vals)),
+	}
+}
+
+// Next advances the iterator to the next subset, which is then available via
+// Value. It returns false when there are no more subsets. The first subset
+// is the empty set.
+//
+// It runs in O(n) time complexity. It does not allocate.
+func (p *PowerSet /*[T]*/) Next() bool {
+	if p.done {
+		return false
+	}
+	if !p.started {
+		p.started = true
+		return true
+	}
+
+	// the mask is incremented as a binary counter, the subset contains the
+	// values for which the mask is set.
+	i := 0
+	for ; i < len(p.mask) && p.mask[i]; i++ {
+		p.mask[i] = false
+	}
+	if i == len(p.mask) {
+		p.done = true
+		return false
+	}
+	p.mask[i] = true
+
+	p.cur = p.cur[:0]
+	for i, set := range p.mask {
+		if set {
+			p.cur = append(p.cur, p.vals[i])
+		}
+	}
+	return true
+}
+
+// Value returns the current subset. The returned slice is only valid until
+// the next call to Next, use Clone to keep a copy of it.
+func (p *PowerSet /*[T]*/) Value() []T {
+	return p.cur
+}
+
+// Product is an iterator over the Cartesian product of a number of slices,
+// that is all tuples where the first value comes from the first slice, the
+// second value from the second slice, and so on. The tuples are generated in
+// lexicographic order of the indices of the values, the last slice varying
+// the fastest.
+//
+// The iterator does not allocate once created: the slice returned by Value
+// is reused and updated in-place by each call to Next.
+type Product /*[T algo.Any]*/ struct {
+	sets    [][]T
+	ixs     []int
+	cur     []T
+	started bool
+	done    bool
+}
+
+// MakeProduct returns an iterator over the Cartesian product of sets. There
+// are as many tuples as the product of the number of values in each slice,
+// so if any slice is empty there are no tuples, and if no slice is provided
+// there is a single empty tuple.
+func MakeProduct /*[T algo.Any]*/ (sets ...[]T) *Product /*[T]*/ {
+	p := &Product{
+		sets: sets,
+		ixs:  make([]int, len(sets)),
+		cur:  make([]T, len(sets)),
+	}
+	for _, set := range sets {
+		if len(set) == 0 {
+			p.done = true
+		}
+	}
+	return p
+}
+
+// Next advances the iterator to the next tuple, which is then available via
+// Value. It returns false when there are no more tuples.
+//
+// It runs in O(1) amortized time complexity. It does not allocate.
+func (p *Product /*[T]*/) Next() bool {
+	if p.done {
+		return false
+	}
+
+	if !p.started {
+		p.started = true
+		for i, set := range p.sets {
+			p.cur[i] = set[0]
+		}
+		return true
+	}
+
+	// increment the indices like an odometer, the last one first.
+	i := len(p.ixs) - 1
+	for ; i >= 0; i-- {
+		p.ixs[i]++
+		if p.ixs[i] < len(p.sets[i]) {
+			p.cur[i] = p.sets[i][p.ixs[i]]
+			return true
+		}
+		p.ixs[i] = 0
+		p.cur[i] = p.sets[i][0]
+	}
+	p.done = true
+	return false
+}
+
+// Value returns the current tuple. The returned slice is only valid until the
+// next call to Next, use Clone to keep a copy of it.
+func (p *Product /*[T]*/) Value() []T {
+	return p.cur
+}
@@ -0,0 +1,146 @@
+package slices
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkPermutations(b *testing.B) {
+	for _, n := range []int{1, 2, 4, 6, 8, 10} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			want := factorial(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				var count int
+				p := MakePermutations(vals)
+				for p.Next() {
+					count++
+				}
+				if count != want {
+					b.Fatalf("want %d permutations, got %d", want, count)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNextPermutation(b *testing.B) {
+	for _, n := range []int{1, 2, 4, 6, 8, 10} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			want := factorial(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				count := 1
+				for NextPermutation(vals) {
+					count++
+				}
+				if count != want {
+					b.Fatalf("want %d permutations, got %d", want, count)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRankPermutation(b *testing.B) {
+	for _, n := range []int{1, 5, 10, 15, 20} {
+		if n > maxRankLen {
+			continue
+		}
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			Rotate(vals, n/2)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				RankPermutation(vals)
+			}
+		})
+	}
+}
+
+func BenchmarkUnrankPermutation(b *testing.B) {
+	for _, n := range []int{1, 5, 10, 15, 20} {
+		if n > maxRankLen {
+			continue
+		}
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			rank := factorial(n) / 2
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := UnrankPermutation(vals, rank); len(got) != n {
+					b.Fatalf("want len %d, got %d", n, len(got))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCombinations(b *testing.B) {
+	for _, n := range []int{1, 5, 10, 15, 20} {
+		b.Run(fmt.Sprintf("n=%d;k=%d", n, n/2), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			k := n / 2
+			want := factorial(n) / (factorial(k) * factorial(n-k))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				var count int
+				c := MakeCombinations(vals, k)
+				for c.Next() {
+					count++
+				}
+				if count != want {
+					b.Fatalf("want %d combinations, got %d", want, count)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPowerSet(b *testing.B) {
+	for _, n := range []int{1, 5, 10, 15, 20} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			want := 1 << n
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				var count int
+				p := MakePowerSet(vals)
+				for p.Next() {
+					count++
+				}
+				if count != want {
+					b.Fatalf("want %d subsets, got %d", want, count)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkProduct(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000} {
+		b.Run(fmt.Sprintf("sets=2;n=%d", n), func(b *testing.B) {
+			v1, v2 := sortedSlice(n, 1), sortedSlice(n, 2)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				var count int
+				p := MakeProduct(v1, v2)
+				for p.Next() {
+					count++
+				}
+				if count != n*n {
+					b.Fatalf("want %d tuples, got %d", n*n, count)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package slices
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPermutations(t *testing.T) {
+	cases := []struct {
+		in  []int
+		out [][]int
+	}{
+		{nil, [][]int{{}}},
+		{[]int{1}, [][]int{{1}}},
+		{[]int{1, 2}, [][]int{{1, 2}, {2, 1}}},
+		{[]int{1, 2, 3}, [][]int{{1, 2, 3}, {2, 1, 3}, {3, 1, 2}, {1, 3, 2}, {2, 3, 1}, {3, 2, 1}}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.in), func(t *testing.T) {
+			got := [][]int{}
+			p := MakePermutations(c.in)
+			for p.Next() {
+				got = append(got, Clone(p.Value()))
+			}
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("want %v, got %v", c.out, got)
+			}
+			if p.Next() {
+				t.Fatal("want Next to return false once done")
+			}
+		})
+	}
+
+	for n := 4; n <= 7; n++ {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			vals := sortedSlice(n, 1)
+			want := factorial(n)
+
+			seen := make(map[string]bool)
+			p := MakePermutations(vals)
+			for p.Next() {
+				seen[fmt.Sprint(p.Value())] = true
+			}
+			if len(seen) != want {
+				t.Fatalf("want %d distinct permutations, got %d", want, len(seen))
+			}
+			if !cmp.Equal(sortedSlice(n, 1), vals) {
+				t.Fatalf("source values were modified: %v", vals)
+			}
+		})
+	}
+}
+
+func TestNextPermutation(t *testing.T) {
+	cases := []struct {
+		in  []int
+		out [][]int
+	}{
+		{nil, [][]int{{}}},
+		{[]int{1}, [][]int{{1}}},
+		{[]int{1, 2}, [][]int{{1, 2}, {2, 1}}},
+		{[]int{1, 2, 3}, [][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}}},
+		{[]int{1, 1, 2}, [][]int{{1, 1, 2}, {1, 2, 1}, {2, 1, 1}}},
+		{[]int{2, 2}, [][]int{{2, 2}}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.in), func(t *testing.T) {
+			vals := Clone(c.in)
+			got := [][]int{Clone(vals)}
+			if vals == nil {
+				got = [][]int{{}}
+			}
+			for NextPermutation(vals) {
+				got = append(got, Clone(vals))
+			}
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("want %v, got %v", c.out, got)
+			}
+			if !Equal(c.in, vals) {
+				t.Fatalf("want values reset to %v, got %v", c.in, vals)
+			}
+		})
+	}
+}
+
+func TestRankPermutation(t *testing.T) {
+	for n := 0; n <= 6; n++ {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			vals := sortedSlice(n, 1)
+			perm := Clone(vals)
+			rank := 0
+			for {
+				if got := RankPermutation(perm); got != rank {
+					t.Fatalf("%v: want rank %d, got %d", perm, rank, got)
+				}
+				if got := UnrankPermutation(vals, rank); !Equal(perm, got) {
+					t.Fatalf("%d: want permutation %v, got %v", rank, perm, got)
+				}
+				if !NextPermutation(perm) {
+					break
+				}
+				rank++
+			}
+			if rank != factorial(n)-1 {
+				t.Fatalf("want last rank %d, got %d", factorial(n)-1, rank)
+			}
+		})
+	}
+
+	t.Run("Large", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		vals := sortedSlice(maxRankLen, 1)
+		for i := 0; i < 100; i++ {
+			rank := r.Intn(factorial(maxRankLen))
+			perm := UnrankPermutation(vals, rank)
+			if got := RankPermutation(perm); got != rank {
+				t.Fatalf("%v: want rank %d, got %d", perm, rank, got)
+			}
+		}
+	})
+}
+
+func TestCombinations(t *testing.T) {
+	cases := []struct {
+		in  []int
+		k   int
+		out [][]int
+	}{
+		{nil, 0, [][]int{{}}},
+		{nil, 1, nil},
+		{[]int{1}, 0, [][]int{{}}},
+		{[]int{1}, 1, [][]int{{1}}},
+		{[]int{1}, 2, nil},
+		{[]int{1, 2, 3}, 1, [][]int{{1}, {2}, {3}}},
+		{[]int{1, 2, 3}, 2, [][]int{{1, 2}, {1, 3}, {2, 3}}},
+		{[]int{1, 2, 3}, 3, [][]int{{1, 2, 3}}},
+		{[]int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v choose %d", c.in, c.k), func(t *testing.T) {
+			var got [][]int
+			it := MakeCombinations(c.in, c.k)
+			for it.Next() {
+				got = append(got, Clone(it.Value()))
+			}
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("want %v, got %v", c.out, got)
+			}
+		})
+	}
+
+	t.Run("Count", func(t *testing.T) {
+		n := 10
+		for k := 0; k <= n; k++ {
+			var count int
+			it := MakeCombinations(sortedSlice(n, 1), k)
+			for it.Next() {
+				count++
+			}
+			want := factorial(n) / (factorial(k) * factorial(n-k))
+			if count != want {
+				t.Fatalf("%d choose %d: want %d, got %d", n, k, want, count)
+			}
+		}
+	})
+}
+
+func TestPowerSet(t *testing.T) {
+	cases := []struct {
+		in  []int
+		out [][]int
+	}{
+		{nil, [][]int{{}}},
+		{[]int{1}, [][]int{{}, {1}}},
+		{[]int{1, 2}, [][]int{{}, {1}, {2}, {1, 2}}},
+		{[]int{1, 2, 3}, [][]int{{}, {1}, {2}, {1, 2}, {3}, {1, 3}, {2, 3}, {1, 2, 3}}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.in), func(t *testing.T) {
+			var got [][]int
+			it := MakePowerSet(c.in)
+			for it.Next() {
+				got = append(got, Clone(it.Value()))
+			}
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("want %v, got %v", c.out, got)
+			}
+		})
+	}
+}
+
+func TestProduct(t *testing.T) {
+	cases := []struct {
+		in  [][]int
+		out [][]int
+	}{
+		{nil, [][]int{{}}},
+		{[][]int{{}}, nil},
+		{[][]int{{1, 2}, {}}, nil},
+		{[][]int{{1, 2}}, [][]int{{1}, {2}}},
+		{[][]int{{1, 2}, {3}}, [][]int{{1, 3}, {2, 3}}},
+		{[][]int{{1, 2}, {3, 4}}, [][]int{{1, 3}, {1, 4}, {2, 3}, {2, 4}}},
+		{[][]int{{1}, {2, 3}, {4, 5}}, [][]int{{1, 2, 4}, {1, 2, 5}, {1, 3, 4}, {1, 3, 5}}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.in), func(t *testing.T) {
+			var got [][]int
+			it := MakeProduct(c.in...)
+			for it.Next() {
+				got = append(got, Clone(it.Value()))
+			}
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("want %v, got %v", c.out, got)
+			}
+		})
+	}
+}
+
+func factorial(n int) int {
+	f := 1
+	for i := 2; i <= n; i++ {
+		f *= i
+	}
+	return f
+}
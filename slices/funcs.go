@@ -0,0 +1,289 @@
+package slices
+
+type (
+	U = int // NOTE: generic type placeholder
+	K = int // NOTE: generic type placeholder, for map keys
+	V = int // NOTE: generic type placeholder, for map values
+)
+
+// Pair is a pair of values, as generated by Zip.
+type Pair /*[T, U algo.Any]*/ struct {
+	First  T
+	Second U
+}
+
+// Map returns a new slice with the result of calling fn on each value of
+// vals, in order. It returns nil if vals is empty.
+//
+// It runs in O(n) time and space complexity. It allocates only once.
+func Map /*[T, U algo.Any]*/ (vals []T, fn func(T) U) []U {
+	if len(vals) == 0 {
+		return nil
+	}
+	return MapInto(make([]U, 0, len(vals)), vals, fn)
+}
+
+// MapInto is like Map, but the results are appended to dst. It returns the
+// updated dst slice, it is therefore necessary to store the result of
+// MapInto.
+//
+// Its time complexity is the same as Map. It allocates at most once, when
+// dst does not have sufficient capacity.
+func MapInto /*[T, U algo.Any]*/ (dst []U, vals []T, fn func(T) U) []U {
+	dst = Grow(dst, len(vals))
+	for _, v := range vals {
+		dst = append(dst, fn(v))
+	}
+	return dst
+}
+
+// FlatMap returns a new slice with the concatenation of the slices returned
+// by calling fn on each value of vals, in order. It returns nil if the
+// result is empty.
+//
+// It runs in O(n) time and space complexity where n is the total number of
+// values returned by fn.
+func FlatMap /*[T, U algo.Any]*/ (vals []T, fn func(T) []U) []U {
+	return FlatMapInto(nil, vals, fn)
+}
+
+// FlatMapInto is like FlatMap, but the results are appended to dst. It
+// returns the updated dst slice, it is therefore necessary to store the
+// result of FlatMapInto.
+//
+// Its time complexity is the same as FlatMap.
+func FlatMapInto /*[T, U algo.Any]*/ (dst []U, vals []T, fn func(T) []U) []U {
+	for _, v := range vals {
+		dst = append(dst, fn(v)...)
+	}
+	return dst
+}
+
+// Reduce reduces vals to a single value by calling fn with the accumulated
+// value and each value of vals, in order. The first value of vals is used as
+// the initial accumulated value, so fn is first called with the first and
+// second values. If vals is empty, it returns the zero value of T, and if it
+// has a single value, that value is returned. See Fold for a version that
+// takes an explicit initial value and can reduce to a different type.
+//
+// It runs in O(n) time complexity and O(1) space complexity. It does not
+// allocate.
+func Reduce /*[T algo.Any]*/ (vals []T, fn func(T, T) T) T {
+	var acc T
+	if len(vals) == 0 {
+		return acc
+	}
+	acc = vals[0]
+	for _, v := range vals[1:] {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// Fold reduces vals to a single value by calling fn with the accumulated
+// value and each value of vals, in order. The init value is used as the
+// initial accumulated value, and it is returned as-is if vals is empty.
+//
+// It runs in O(n) time complexity and O(1) space complexity. It does not
+// allocate.
+func Fold /*[T, U algo.Any]*/ (vals []T, init U, fn func(U, T) U) U {
+	acc := init
+	for _, v := range vals {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// GroupBy returns a map where the values of vals are grouped under the key
+// returned by calling key on each value. The values of each group keep the
+// same relative order as in vals. It returns nil if vals is empty.
+//
+// It runs in O(n) time and space complexity.
+func GroupBy /*[T algo.Any, K algo.Comparable]*/ (vals []T, key func(T) K) map[K][]T {
+	if len(vals) == 0 {
+		return nil
+	}
+	dst := make(map[K][]T)
+	GroupByInto(dst, vals, key)
+	return dst
+}
+
+// GroupByInto is like GroupBy, but the groups are stored in dst. If dst
+// already contains a group for a key, the new values are appended to that
+// group.
+//
+// Its time complexity is the same as GroupBy.
+func GroupByInto /*[T algo.Any, K algo.Comparable]*/ (dst map[K][]T, vals []T, key func(T) K) {
+	for _, v := range vals {
+		k := key(v)
+		dst[k] = append(dst[k], v)
+	}
+}
+
+// Partition splits vals in two new slices, the first one with the values for
+// which pred returns true and the second one with the values for which it
+// returns false. It is a stable partition, meaning that the values keep the
+// same relative order as in vals. Both returned slices share the same
+// underlying array (but the first one has its capacity clipped so that
+// appending to it does not overwrite the values of the second one).
+//
+// It runs in O(n) time and space complexity. It allocates only once.
+func Partition /*[T algo.Any]*/ (vals []T, pred func(T) bool) (match, rest []T) {
+	if len(vals) == 0 {
+		return nil, nil
+	}
+
+	// matching values are stored from the start of buf and the others from the
+	// end, so that a single allocation is required. The values stored at the
+	// end are in reverse order, so they get reversed at the end.
+	buf := make([]T, len(vals))
+	i, j := 0, len(buf)
+	for _, v := range vals {
+		if pred(v) {
+			buf[i] = v
+			i++
+		} else {
+			j--
+			buf[j] = v
+		}
+	}
+	reverse(buf[i:])
+	return buf[:i:i], buf[i:]
+}
+
+// PartitionInto is like Partition, but the values for which pred returns
+// true are appended to match and the others are appended to rest. It returns
+// the updated match and rest slices, it is therefore necessary to store the
+// results of PartitionInto.
+//
+// Its time complexity is the same as Partition.
+func PartitionInto /*[T algo.Any]*/ (match, rest, vals []T, pred func(T) bool) ([]T, []T) {
+	for _, v := range vals {
+		if pred(v) {
+			match = append(match, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return match, rest
+}
+
+// Zip returns a new slice of pairs where the first value comes from v1 and
+// the second from v2 at the same index. The resulting slice has the length
+// of the shortest of v1 and v2, the extra values of the longest one are
+// ignored. It returns nil if the result is empty.
+//
+// It runs in O(n) time and space complexity. It allocates only once.
+func Zip /*[T, U algo.Any]*/ (v1 []T, v2 []U) []Pair /*[T, U]*/ {
+	n := len(v1)
+	if len(v2) < n {
+		n = len(v2)
+	}
+	if n == 0 {
+		return nil
+	}
+	return ZipInto(make([]Pair /*[T, U]*/, 0, n), v1, v2)
+}
+
+// ZipInto is like Zip, but the pairs are appended to dst. It returns the
+// updated dst slice, it is therefore necessary to store the result of
+// ZipInto.
+//
+// Its time complexity is the same as Zip. It allocates at most once, when
+// dst does not have sufficient capacity.
+func ZipInto /*[T, U algo.Any]*/ (dst []Pair /*[T, U]*/, v1 []T, v2 []U) []Pair /*[T, U]*/ {
+	n := len(v1)
+	if len(v2) < n {
+		n = len(v2)
+	}
+
+	// same as Grow(dst, n), which only takes a slice of T until type
+	// parameters are available.
+	if cap(dst)-len(dst) < n {
+		newDst := make([]Pair /*[T, U]*/, len(dst), growCap(cap(dst), len(dst)+n))
+		copy(newDst, dst)
+		dst = newDst
+	}
+	for i := 0; i < n; i++ {
+		dst = append(dst, Pair /*[T, U]*/ {First: v1[i], Second: v2[i]})
+	}
+	return dst
+}
+
+// Unzip returns two new slices, the first one with the first value of each
+// pair and the second one with the second value, in order. It is the
+// reverse of Zip. It returns nil slices if pairs is empty.
+//
+// It runs in O(n) time and space complexity. It allocates only once per
+// returned slice.
+func Unzip /*[T, U algo.Any]*/ (pairs []Pair /*[T, U]*/) ([]T, []U) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	return UnzipInto(make([]T, 0, len(pairs)), make([]U, 0, len(pairs)), pairs)
+}
+
+// UnzipInto is like Unzip, but the values are appended to v1 and v2. It
+// returns the updated v1 and v2 slices, it is therefore necessary to store
+// the results of UnzipInto.
+//
+// Its time complexity is the same as Unzip.
+func UnzipInto /*[T, U algo.Any]*/ (v1 []T, v2 []U, pairs []Pair /*[T, U]*/) ([]T, []U) {
+	v1, v2 = Grow(v1, len(pairs)), Grow(v2, len(pairs))
+	for _, p := range pairs {
+		v1 = append(v1, p.First)
+		v2 = append(v2, p.Second)
+	}
+	return v1, v2
+}
+
+// Associate returns a map where each key-value pair is the result of
+// calling fn on each value of vals, in order. If fn returns the same key
+// for more than one value, the last one wins. It returns nil if vals is
+// empty.
+//
+// It runs in O(n) time and space complexity.
+func Associate /*[T, V algo.Any, K algo.Comparable]*/ (vals []T, fn func(T) (K, V)) map[K]V {
+	if len(vals) == 0 {
+		return nil
+	}
+	dst := make(map[K]V, len(vals))
+	AssociateInto(dst, vals, fn)
+	return dst
+}
+
+// AssociateInto is like Associate, but the key-value pairs are stored in
+// dst, replacing any existing value for the same key.
+//
+// Its time complexity is the same as Associate.
+func AssociateInto /*[T, V algo.Any, K algo.Comparable]*/ (dst map[K]V, vals []T, fn func(T) (K, V)) {
+	for _, v := range vals {
+		k, vv := fn(v)
+		dst[k] = vv
+	}
+}
+
+// CountBy returns a map where the keys are the result of calling key on
+// each value of vals, and the values are the number of values of vals that
+// generated that key. It returns nil if vals is empty.
+//
+// It runs in O(n) time complexity and O(k) space complexity where k is the
+// number of distinct keys.
+func CountBy /*[T algo.Any, K algo.Comparable]*/ (vals []T, key func(T) K) map[K]int {
+	if len(vals) == 0 {
+		return nil
+	}
+	dst := make(map[K]int)
+	CountByInto(dst, vals, key)
+	return dst
+}
+
+// CountByInto is like CountBy, but the counts are stored in dst. If dst
+// already contains a count for a key, it is incremented.
+//
+// Its time complexity is the same as CountBy.
+func CountByInto /*[T algo.Any, K algo.Comparable]*/ (dst map[K]int, vals []T, key func(T) K) {
+	for _, v := range vals {
+		dst[key(v)]++
+	}
+}
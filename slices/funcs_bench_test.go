@@ -0,0 +1,177 @@
+package slices
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkMap(b *testing.B) {
+	double := func(v int) int { return v * 2 }
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := Map(vals, double); len(got) != n {
+					b.Fatalf("want len %d, got %d", n, len(got))
+				}
+			}
+		})
+
+		// with a pre-allocated destination, MapInto does not allocate
+		b.Run(fmt.Sprintf("into n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			dst := make([]int, 0, n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := MapInto(dst, vals, double); len(got) != n {
+					b.Fatalf("want len %d, got %d", n, len(got))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFlatMap(b *testing.B) {
+	dup := func(v int) []int { return []int{v, v} }
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := FlatMap(vals, dup); len(got) != 2*n {
+					b.Fatalf("want len %d, got %d", 2*n, len(got))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkReduce(b *testing.B) {
+	sum := func(acc, v int) int { return acc + v }
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := Reduce(vals, sum); got != n*(n+1)/2 {
+					b.Fatalf("want %d, got %d", n*(n+1)/2, got)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFold(b *testing.B) {
+	sum := func(acc, v int) int { return acc + v }
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := Fold(vals, 0, sum); got != n*(n+1)/2 {
+					b.Fatalf("want %d, got %d", n*(n+1)/2, got)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGroupBy(b *testing.B) {
+	mod10 := func(v int) int { return v % 10 }
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := GroupBy(vals, mod10); len(got) == 0 {
+					b.Fatal("want non-empty groups")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPartition(b *testing.B) {
+	isEven := func(v int) bool { return v%2 == 0 }
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if match, rest := Partition(vals, isEven); len(match)+len(rest) != n {
+					b.Fatalf("want total len %d, got %d", n, len(match)+len(rest))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkZip(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			v1, v2 := sortedSlice(n, 1), sortedSlice(n, 2)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := Zip(v1, v2); len(got) != n {
+					b.Fatalf("want len %d, got %d", n, len(got))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkUnzip(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			pairs := Zip(sortedSlice(n, 1), sortedSlice(n, 2))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if v1, _ := Unzip(pairs); len(v1) != n {
+					b.Fatalf("want len %d, got %d", n, len(v1))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAssociate(b *testing.B) {
+	fn := func(v int) (int, int) { return v, v * 2 }
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := Associate(vals, fn); len(got) != n {
+					b.Fatalf("want len %d, got %d", n, len(got))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCountBy(b *testing.B) {
+	mod10 := func(v int) int { return v % 10 }
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := CountBy(vals, mod10); len(got) == 0 {
+					b.Fatal("want non-empty counts")
+				}
+			}
+		})
+	}
+}
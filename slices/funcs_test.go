@@ -0,0 +1,243 @@
+package slices
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestMap(t *testing.T) {
+	double := func(v int) int { return v * 2 }
+	cases := []struct {
+		dst []int
+		in  []int
+		out []int
+	}{
+		{nil, nil, nil},
+		{nil, []int{1}, []int{2}},
+		{nil, sortedSlice(3, 1), sortedSlice(3, 2)},
+		{[]int{9}, nil, []int{9}},
+		{[]int{9}, sortedSlice(3, 1), []int{9, 2, 4, 6}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v + %v", c.dst, c.in), func(t *testing.T) {
+			var got []int
+			if c.dst != nil {
+				got = MapInto(c.dst, c.in, double)
+			} else {
+				got = Map(c.in, double)
+			}
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("want %d, got %d", c.out, got)
+			}
+		})
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	repeat := func(v int) []int {
+		vals := make([]int, v)
+		for i := range vals {
+			vals[i] = v
+		}
+		return vals
+	}
+	cases := []struct {
+		dst []int
+		in  []int
+		out []int
+	}{
+		{nil, nil, nil},
+		{nil, []int{0}, nil},
+		{nil, []int{1}, []int{1}},
+		{nil, []int{1, 0, 3, 2}, []int{1, 3, 3, 3, 2, 2}},
+		{[]int{9}, []int{2}, []int{9, 2, 2}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v + %v", c.dst, c.in), func(t *testing.T) {
+			var got []int
+			if c.dst != nil {
+				got = FlatMapInto(c.dst, c.in, repeat)
+			} else {
+				got = FlatMap(c.in, repeat)
+			}
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("want %d, got %d", c.out, got)
+			}
+		})
+	}
+}
+
+func TestReduceFold(t *testing.T) {
+	sum := func(acc, v int) int { return acc + v }
+	cases := []struct {
+		in     []int
+		reduce int
+		fold   int // with init 10
+	}{
+		{nil, 0, 10},
+		{[]int{1}, 1, 11},
+		{[]int{1, 2}, 3, 13},
+		{sortedSlice(10, 1), 55, 65},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.in), func(t *testing.T) {
+			if got := Reduce(c.in, sum); got != c.reduce {
+				t.Fatalf("Reduce: want %d, got %d", c.reduce, got)
+			}
+			if got := Fold(c.in, 10, sum); got != c.fold {
+				t.Fatalf("Fold: want %d, got %d", c.fold, got)
+			}
+		})
+	}
+
+	t.Run("FoldOrder", func(t *testing.T) {
+		got := Fold([]int{1, 2, 3}, 0, func(acc, v int) int { return acc*10 + v })
+		if got != 123 {
+			t.Fatalf("want %d, got %d", 123, got)
+		}
+	})
+}
+
+func TestGroupByCountBy(t *testing.T) {
+	mod3 := func(v int) int { return v % 3 }
+	cases := []struct {
+		groupDst map[int][]int // if non-nil, use the Into variations
+		countDst map[int]int
+		in       []int
+		groups   map[int][]int
+		counts   map[int]int
+	}{
+		{nil, nil, nil, nil, nil},
+		{nil, nil, []int{1}, map[int][]int{1: {1}}, map[int]int{1: 1}},
+		{nil, nil, sortedSlice(7, 1), map[int][]int{0: {3, 6}, 1: {1, 4, 7}, 2: {2, 5}}, map[int]int{0: 2, 1: 3, 2: 2}},
+		{map[int][]int{0: {9}}, map[int]int{0: 1}, []int{2, 3}, map[int][]int{0: {9, 3}, 2: {2}}, map[int]int{0: 2, 2: 1}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v + %v", c.groupDst, c.in), func(t *testing.T) {
+			groups, counts := c.groupDst, c.countDst
+			if groups != nil {
+				GroupByInto(groups, c.in, mod3)
+				CountByInto(counts, c.in, mod3)
+			} else {
+				groups = GroupBy(c.in, mod3)
+				counts = CountBy(c.in, mod3)
+			}
+			if !cmp.Equal(c.groups, groups) {
+				t.Fatalf("GroupBy: want %v, got %v", c.groups, groups)
+			}
+			if !cmp.Equal(c.counts, counts) {
+				t.Fatalf("CountBy: want %v, got %v", c.counts, counts)
+			}
+		})
+	}
+}
+
+func TestPartition(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+	cases := []struct {
+		match, rest []int // if non-nil, use the Into variation
+		in          []int
+		wantMatch   []int
+		wantRest    []int
+	}{
+		{nil, nil, nil, nil, nil},
+		{nil, nil, []int{1}, nil, []int{1}},
+		{nil, nil, []int{2}, []int{2}, nil},
+		{nil, nil, []int{5, 2, 3, 6, 1, 4}, []int{2, 6, 4}, []int{5, 3, 1}},
+		{[]int{10}, []int{11}, []int{5, 2, 3, 6, 1, 4}, []int{10, 2, 6, 4}, []int{11, 5, 3, 1}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.in), func(t *testing.T) {
+			var match, rest []int
+			if c.match != nil {
+				match, rest = PartitionInto(c.match, c.rest, c.in, isEven)
+			} else {
+				match, rest = Partition(c.in, isEven)
+			}
+			if !cmp.Equal(c.wantMatch, match, cmpopts.EquateEmpty()) {
+				t.Fatalf("match: want %d, got %d", c.wantMatch, match)
+			}
+			if !cmp.Equal(c.wantRest, rest, cmpopts.EquateEmpty()) {
+				t.Fatalf("rest: want %d, got %d", c.wantRest, rest)
+			}
+
+			// appending to match must not overwrite rest
+			restCopy := Clone(rest)
+			_ = append(match, -1)
+			if !cmp.Equal(restCopy, rest) {
+				t.Fatalf("append to match modified rest: want %d, got %d", restCopy, rest)
+			}
+		})
+	}
+}
+
+func TestZipUnzip(t *testing.T) {
+	cases := []struct {
+		v1, v2 []int
+		out    []Pair
+	}{
+		{nil, nil, nil},
+		{[]int{1}, nil, nil},
+		{nil, []int{1}, nil},
+		{[]int{1}, []int{2}, []Pair{{1, 2}}},
+		{[]int{1, 2, 3}, []int{4, 5}, []Pair{{1, 4}, {2, 5}}},
+		{[]int{1, 2}, []int{4, 5, 6}, []Pair{{1, 4}, {2, 5}}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v + %v", c.v1, c.v2), func(t *testing.T) {
+			got := Zip(c.v1, c.v2)
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("Zip: want %v, got %v", c.out, got)
+			}
+
+			got = ZipInto([]Pair{{0, 0}}, c.v1, c.v2)
+			if !cmp.Equal(append([]Pair{{0, 0}}, c.out...), got) {
+				t.Fatalf("ZipInto: want %v, got %v", c.out, got)
+			}
+
+			n := len(c.out)
+			v1, v2 := Unzip(c.out)
+			if !cmp.Equal(c.v1[:n], v1, cmpopts.EquateEmpty()) || !cmp.Equal(c.v2[:n], v2, cmpopts.EquateEmpty()) {
+				t.Fatalf("Unzip: want %d and %d, got %d and %d", c.v1[:n], c.v2[:n], v1, v2)
+			}
+
+			v1, v2 = UnzipInto([]int{0}, []int{0}, c.out)
+			if !cmp.Equal(append([]int{0}, c.v1[:n]...), v1) || !cmp.Equal(append([]int{0}, c.v2[:n]...), v2) {
+				t.Fatalf("UnzipInto: want %d and %d, got %d and %d", c.v1[:n], c.v2[:n], v1, v2)
+			}
+		})
+	}
+}
+
+func TestAssociate(t *testing.T) {
+	// key is the number of digits, value is the sum of the values
+	fn := func(v int) (int, int) { return len(strconv.Itoa(v)), v }
+	cases := []struct {
+		dst map[int]int
+		in  []int
+		out map[int]int
+	}{
+		{nil, nil, nil},
+		{nil, []int{1}, map[int]int{1: 1}},
+		{nil, []int{1, 20, 3, 40}, map[int]int{1: 3, 2: 40}},
+		{map[int]int{1: 9, 3: 9}, []int{1, 20}, map[int]int{1: 1, 2: 20, 3: 9}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v + %v", c.dst, c.in), func(t *testing.T) {
+			var got map[int]int
+			if c.dst != nil {
+				got = c.dst
+				AssociateInto(got, c.in, fn)
+			} else {
+				got = Associate(c.in, fn)
+			}
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("want %v, got %v", c.out, got)
+			}
+		})
+	}
+}
@@ -1,7 +1,8 @@
 package slices
 
-// TODO: implement this? https://github.com/golang/go/issues/45955
-// And anything missing from this? https://github.com/golang/go/wiki/SliceTricks
+// NOTE: the functions of this package are inspired by the proposal for a
+// standard slices package (https://github.com/golang/go/issues/45955) and by
+// the SliceTricks wiki page (https://github.com/golang/go/wiki/SliceTricks).
 
 type T = int // NOTE: generic type placeholder
 
@@ -19,18 +20,334 @@ func Prepend /*[T algo.Any]*/ (vals []T, v ...T) []T {
 	return newVals
 }
 
+// Insert inserts the values v at index i of vals, shifting the values
+// previously at i and after to the right. If vals has sufficient capacity, it
+// is resliced to accommodate the new elements, otherwise a new underlying
+// array will be allocated. It panics if i is out of range. Insert returns
+// the updated slice, it is therefore necessary to store the result of Insert.
+//
+// It runs in O(n) time complexity where n is the number of values from i to
+// the end of the slice (plus the number of values to insert).
+func Insert /*[T algo.Any]*/ (vals []T, i int, v ...T) []T {
+	tail := vals[i:]
+	newVals := growSlice(vals, len(vals)+len(v))
+	copy(newVals[i+len(v):], tail)
+	copy(newVals, vals[:i])
+	copy(newVals[i:], v)
+	return newVals
+}
+
+// Delete removes the values vals[i:j] from vals, shifting the values
+// previously at j and after to the left. It panics if vals[i:j] is not a
+// valid slice of vals. Delete modifies vals in-place and returns the
+// updated slice, it is therefore necessary to store the result of Delete.
+// The now unused elements at the end of the slice are set to the zero value
+// of T so that they can be garbage-collected if T holds references.
+//
+// It runs in O(n) time complexity where n is the number of values from i to
+// the end of the slice. It does not allocate.
+func Delete /*[T algo.Any]*/ (vals []T, i, j int) []T {
+	_ = vals[i:j] // bounds check
+	n := copy(vals[i:], vals[j:])
+	clearTail(vals, i+n)
+	return vals[:i+n]
+}
+
+// DeleteFunc removes any value from vals for which del returns true. It
+// modifies vals in-place and returns the updated slice, it is therefore
+// necessary to store the result of DeleteFunc. The relative order of the
+// remaining values is preserved.
+//
+// It runs in O(n) time complexity. It does not allocate.
+func DeleteFunc /*[T algo.Any]*/ (vals []T, del func(T) bool) []T {
+	return Filter(vals, func(v T) bool { return !del(v) })
+}
+
+// Filter keeps only the values of vals for which keep returns true. It
+// modifies vals in-place and returns the updated slice, it is therefore
+// necessary to store the result of Filter. The relative order of the kept
+// values is preserved.
+//
+// It runs in O(n) time complexity. It does not allocate.
+func Filter /*[T algo.Any]*/ (vals []T, keep func(T) bool) []T {
+	n := 0
+	for _, v := range vals {
+		if keep(v) {
+			vals[n] = v
+			n++
+		}
+	}
+	clearTail(vals, n)
+	return vals[:n]
+}
+
+// Compact replaces consecutive runs of equal values with a single copy, like
+// the uniq Unix command. If vals is sorted, this removes all duplicates. It
+// modifies vals in-place and returns the updated slice, it is therefore
+// necessary to store the result of Compact.
+//
+// It runs in O(n) time complexity. It does not allocate.
+func Compact /*[T algo.Comparable]*/ (vals []T) []T {
+	return CompactFunc(vals, func(v1, v2 T) bool { return v1 == v2 })
+}
+
+// CompactFunc is like Compact, but it calls eq to check equality of pairs of
+// consecutive values. When a run of values are equal, CompactFunc keeps the
+// first one.
+//
+// It runs in O(n) time complexity. It does not allocate.
+func CompactFunc /*[T algo.Any]*/ (vals []T, eq func(T, T) bool) []T {
+	if len(vals) < 2 {
+		return vals
+	}
+
+	n := 1
+	for _, v := range vals[1:] {
+		if !eq(vals[n-1], v) {
+			vals[n] = v
+			n++
+		}
+	}
+	clearTail(vals, n)
+	return vals[:n]
+}
+
+// Index returns the index of the first occurrence of v in vals, or -1 if it
+// is not present.
+//
+// It runs in O(n) time complexity and O(1) space complexity. It does not
+// allocate.
+func Index /*[T algo.Comparable]*/ (vals []T, v T) int {
+	for i, vv := range vals {
+		if vv == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexFunc returns the index of the first value in vals for which fn
+// returns true, or -1 if there is none.
+//
+// It runs in O(n) time complexity and O(1) space complexity. It does not
+// allocate.
+func IndexFunc /*[T algo.Any]*/ (vals []T, fn func(T) bool) int {
+	for i, v := range vals {
+		if fn(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Contains reports whether v is present in vals.
+//
+// It runs in O(n) time complexity and O(1) space complexity. It does not
+// allocate.
+func Contains /*[T algo.Comparable]*/ (vals []T, v T) bool {
+	return Index(vals, v) >= 0
+}
+
+// Equal reports whether v1 and v2 contain the same values in the same order.
+// A nil slice is equal to an empty slice.
+//
+// It runs in O(n) time complexity and O(1) space complexity. It does not
+// allocate.
+func Equal /*[T algo.Comparable]*/ (v1, v2 []T) bool {
+	if len(v1) != len(v2) {
+		return false
+	}
+	for i, v := range v1 {
+		if v != v2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualFunc reports whether v1 and v2 contain the same values in the same
+// order, calling eq to check equality of each pair of values.
+//
+// It runs in O(n) time complexity and O(1) space complexity. It does not
+// allocate.
+func EqualFunc /*[T algo.Any]*/ (v1, v2 []T, eq func(T, T) bool) bool {
+	if len(v1) != len(v2) {
+		return false
+	}
+	for i, v := range v1 {
+		if !eq(v, v2[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a copy of vals, with a new underlying array. The values are
+// copied using assignment, so this is a shallow clone. A nil slice returns a
+// nil slice.
+//
+// It runs in O(n) time and space complexity.
+func Clone /*[T algo.Any]*/ (vals []T) []T {
+	if vals == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(vals)), vals...)
+}
+
+// Grow increases the capacity of vals, if necessary, to guarantee space for
+// another n values. After Grow(vals, n), at least n values can be appended
+// to the returned slice without another allocation. Its length is
+// unchanged. It panics if n is negative.
+//
+// It runs in O(1) time complexity if vals already has sufficient capacity,
+// O(n) otherwise where n is the number of values in vals.
+func Grow /*[T algo.Any]*/ (vals []T, n int) []T {
+	if n < 0 {
+		panic("slices: negative Grow")
+	}
+	if cap(vals)-len(vals) >= n {
+		return vals
+	}
+	newVals := growSlice(vals, len(vals)+n)
+	copy(newVals, vals)
+	return newVals[:len(vals)]
+}
+
+// Clip removes unused capacity from vals, returning vals[:len(vals):len(vals)].
+// Appending to the returned slice always allocates a new underlying array,
+// leaving the original one untouched.
+//
+// It runs in O(1) time and space complexity. It does not allocate.
+func Clip /*[T algo.Any]*/ (vals []T) []T {
+	return vals[:len(vals):len(vals)]
+}
+
+// Rotate rotates the values of vals in-place by k positions to the left, so
+// that the value at index k becomes the first value. A negative k rotates to
+// the right, and k may be larger than the length of vals.
+//
+// It runs in O(n) time complexity and O(1) space complexity. It does not
+// allocate.
+func Rotate /*[T algo.Any]*/ (vals []T, k int) {
+	n := len(vals)
+	if n == 0 {
+		return
+	}
+	if k %= n; k < 0 {
+		k += n
+	}
+	if k == 0 {
+		return
+	}
+
+	// rotate using the reversal algorithm: reverse both parts separately,
+	// then reverse the whole slice.
+	// [1, 2, 3, 4, 5] (k=2) => [2, 1, 5, 4, 3] => [3, 4, 5, 1, 2]
+	reverse(vals[:k])
+	reverse(vals[k:])
+	reverse(vals)
+}
+
+// Chunk splits vals in consecutive sub-slices of size values (the last one
+// may be smaller). The sub-slices share the underlying array of vals but their
+// capacity is clipped so that appending to one does not overwrite the values
+// of the next. It panics if size is smaller than 1.
+//
+// It runs in O(n / size) time and space complexity. It allocates only the
+// slice of chunks, not the chunks themselves. See Batch for a version that
+// does not allocate.
+func Chunk /*[T algo.Any]*/ (vals []T, size int) [][]T {
+	if size < 1 {
+		panic("slices: invalid Chunk size")
+	}
+	if len(vals) == 0 {
+		return nil
+	}
+
+	chunks := make([][]T, 0, (len(vals)+size-1)/size)
+	Batch(vals, size, func(batch []T) bool {
+		chunks = append(chunks, batch)
+		return true
+	})
+	return chunks
+}
+
+// Windows returns all sliding windows of size consecutive values of vals,
+// that is vals[0:size], vals[1:size+1] and so on up to the end of vals. The
+// windows share the underlying array of vals but their capacity is clipped.
+// If vals has fewer than size values, it returns nil. It panics if size is
+// smaller than 1.
+//
+// It runs in O(n) time and space complexity. It allocates only the slice of
+// windows, not the windows themselves.
+func Windows /*[T algo.Any]*/ (vals []T, size int) [][]T {
+	if size < 1 {
+		panic("slices: invalid Windows size")
+	}
+	if len(vals) < size {
+		return nil
+	}
+
+	wins := make([][]T, len(vals)-size+1)
+	for i := range wins {
+		wins[i] = vals[i : i+size : i+size]
+	}
+	return wins
+}
+
+// Batch calls fn with consecutive sub-slices of size values of vals (the last
+// one may be smaller), in order, until all values have been processed or fn
+// returns false. The sub-slices share the underlying array of vals but their
+// capacity is clipped. It panics if size is smaller than 1.
+//
+// It runs in O(n / size) time complexity and O(1) space complexity. It does
+// not allocate.
+func Batch /*[T algo.Any]*/ (vals []T, size int, fn func([]T) bool) {
+	if size < 1 {
+		panic("slices: invalid Batch size")
+	}
+	for len(vals) > size {
+		if !fn(vals[:size:size]) {
+			return
+		}
+		vals = vals[size:]
+	}
+	if len(vals) > 0 {
+		fn(vals[:len(vals):len(vals)])
+	}
+}
+
+// reverses vals in-place.
+func reverse /*[T algo.Any]*/ (vals []T) {
+	for i, j := 0, len(vals)-1; i < j; i, j = i+1, j-1 {
+		vals[i], vals[j] = vals[j], vals[i]
+	}
+}
+
+// sets the values of vals[from:len(vals)] to the zero value of T, so that
+// values removed from a slice do not keep references alive.
+func clearTail /*[T algo.Any]*/ (vals []T, from int) {
+	var zero T
+	for i := from; i < len(vals); i++ {
+		vals[i] = zero
+	}
+}
+
 // returns a (possibly new) slice with at least minCap capacity and len ==
 // minCap. No elements are copied or moved, it just adjusts capacity and len.
-// This is similar logic used internally for the append builtin (see
-// https://github.com/golang/go/blob/master/src/runtime/slice.go#L144).
 func growSlice /* [T algo.Any]*/ (vals []T, minCap int) []T {
-	oldCap := cap(vals)
-	if oldCap >= minCap {
+	if cap(vals) >= minCap {
 		// slice capacity is already big enough, just adjust len
 		vals = vals[:minCap]
 		return vals
 	}
+	return make([]T, minCap, growCap(cap(vals), minCap))
+}
 
+// returns the capacity of a slice grown from oldCap to hold at least minCap
+// values. This is similar logic used internally for the append builtin (see
+// https://github.com/golang/go/blob/master/src/runtime/slice.go#L144).
+func growCap(oldCap, minCap int) int {
 	newCap := oldCap
 	doubleCap := newCap + newCap
 	if minCap > doubleCap {
@@ -51,5 +368,5 @@ func growSlice /* [T algo.Any]*/ (vals []T, minCap int) []T {
 			}
 		}
 	}
-	return make([]T, minCap, newCap)
+	return newCap
 }
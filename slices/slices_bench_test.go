@@ -20,3 +20,271 @@ func BenchmarkPrepend(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkInsert(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				newVals := Insert(vals, n/2, 1)
+				if len(newVals) != len(vals)+1 {
+					b.Fatalf("want new len %d, got %d", len(vals)+1, len(newVals))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDelete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// delete the first value and restore the length (the last value is
+				// zeroed, which is fine for the benchmark).
+				newVals := Delete(vals, 0, 1)
+				if len(newVals) != len(vals)-1 {
+					b.Fatalf("want new len %d, got %d", len(vals)-1, len(newVals))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDeleteFunc(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				newVals := DeleteFunc(vals, func(v int) bool { return v < 0 })
+				if len(newVals) != len(vals) {
+					b.Fatalf("want new len %d, got %d", len(vals), len(newVals))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				newVals := Filter(vals, func(v int) bool { return v > 0 })
+				if len(newVals) != len(vals) {
+					b.Fatalf("want new len %d, got %d", len(vals), len(newVals))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCompact(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				newVals := Compact(vals)
+				if len(newVals) != len(vals) {
+					b.Fatalf("want new len %d, got %d", len(vals), len(newVals))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCompactFunc(b *testing.B) {
+	eq := func(v1, v2 int) bool { return v1 == v2 }
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				newVals := CompactFunc(vals, eq)
+				if len(newVals) != len(vals) {
+					b.Fatalf("want new len %d, got %d", len(vals), len(newVals))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkIndex(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if got := Index(vals, n+1); got != -1 {
+					b.Fatalf("want -1, got %d", got)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkContains(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if Contains(vals, n+1) {
+					b.Fatal("Contains returned true")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEqual(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			v1, v2 := sortedSlice(n, 1), sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !Equal(v1, v2) {
+					b.Fatal("Equal returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEqualFunc(b *testing.B) {
+	eq := func(v1, v2 int) bool { return v1 == v2 }
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			v1, v2 := sortedSlice(n, 1), sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if !EqualFunc(v1, v2, eq) {
+					b.Fatal("EqualFunc returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkClone(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if newVals := Clone(vals); len(newVals) != n {
+					b.Fatalf("want len %d, got %d", n, len(newVals))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGrow(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if newVals := Grow(vals, 1); cap(newVals) <= n {
+					b.Fatalf("want cap > %d, got %d", n, cap(newVals))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkClip(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := make([]int, n, 2*n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if newVals := Clip(vals); cap(newVals) != n {
+					b.Fatalf("want cap %d, got %d", n, cap(newVals))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRotate(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				Rotate(vals, n/3)
+			}
+		})
+	}
+}
+
+func BenchmarkChunk(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if chunks := Chunk(vals, 10); len(chunks) != (n+9)/10 {
+					b.Fatalf("want %d chunks, got %d", (n+9)/10, len(chunks))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkWindows(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if wins := Windows(vals, 1); len(wins) != n {
+					b.Fatalf("want %d windows, got %d", n, len(wins))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBatch(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := sortedSlice(n, 1)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				var count int
+				Batch(vals, 10, func(batch []int) bool {
+					count += len(batch)
+					return true
+				})
+				if count != n {
+					b.Fatalf("want %d values, got %d", n, count)
+				}
+			}
+		})
+	}
+}
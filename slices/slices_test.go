@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 func TestPrepend(t *testing.T) {
@@ -42,3 +43,333 @@ func sortedSlice(n, mul int) []int {
 	}
 	return vals
 }
+
+func TestInsert(t *testing.T) {
+	cases := []struct {
+		in  []int
+		i   int
+		add []int
+		out []int
+	}{
+		{nil, 0, nil, nil},
+		{nil, 0, []int{1}, []int{1}},
+		{[]int{1}, 0, []int{2}, []int{2, 1}},
+		{[]int{1}, 1, []int{2}, []int{1, 2}},
+		{[]int{1, 2, 3}, 1, []int{4, 5}, []int{1, 4, 5, 2, 3}},
+		{[]int{1, 2, 3}, 3, []int{4, 5}, []int{1, 2, 3, 4, 5}},
+		{append(make([]int, 0, 10), 1, 2, 3), 2, []int{4}, []int{1, 2, 4, 3}},
+		{sortedSlice(1024, 1), 1024, []int{1}, append(sortedSlice(1024, 1), 1)},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v <= %v at %d", c.in, c.add, c.i), func(t *testing.T) {
+			got := Insert(c.in, c.i, c.add...)
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("want %d, got %d", c.out, got)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := []struct {
+		in   []int
+		i, j int
+		out  []int
+	}{
+		{nil, 0, 0, nil},
+		{[]int{1}, 0, 0, []int{1}},
+		{[]int{1}, 0, 1, []int{}},
+		{[]int{1, 2, 3}, 0, 1, []int{2, 3}},
+		{[]int{1, 2, 3}, 1, 2, []int{1, 3}},
+		{[]int{1, 2, 3}, 2, 3, []int{1, 2}},
+		{[]int{1, 2, 3, 4, 5}, 1, 4, []int{1, 5}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v[%d:%d]", c.in, c.i, c.j), func(t *testing.T) {
+			n := len(c.in)
+			got := Delete(c.in, c.i, c.j)
+			if !cmp.Equal(c.out, got, cmpopts.EquateEmpty()) {
+				t.Fatalf("want %d, got %d", c.out, got)
+			}
+			for _, v := range c.in[len(got):n] {
+				if v != 0 {
+					t.Fatalf("want deleted values to be cleared, got %d", c.in[:n])
+				}
+			}
+		})
+	}
+}
+
+func TestFilterDeleteFunc(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+	cases := []struct {
+		in   []int
+		even []int
+		odd  []int
+	}{
+		{nil, nil, nil},
+		{[]int{1}, nil, []int{1}},
+		{[]int{2}, []int{2}, nil},
+		{sortedSlice(6, 1), []int{2, 4, 6}, []int{1, 3, 5}},
+		{[]int{4, 2, 7, 1, 8}, []int{4, 2, 8}, []int{7, 1}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.in), func(t *testing.T) {
+			got := Filter(Clone(c.in), isEven)
+			if !cmp.Equal(c.even, got, cmpopts.EquateEmpty()) {
+				t.Fatalf("Filter: want %d, got %d", c.even, got)
+			}
+			got = DeleteFunc(Clone(c.in), isEven)
+			if !cmp.Equal(c.odd, got, cmpopts.EquateEmpty()) {
+				t.Fatalf("DeleteFunc: want %d, got %d", c.odd, got)
+			}
+		})
+	}
+}
+
+func TestCompact(t *testing.T) {
+	cases := []struct {
+		in  []int
+		out []int
+	}{
+		{nil, nil},
+		{[]int{1}, []int{1}},
+		{[]int{1, 1}, []int{1}},
+		{[]int{1, 2}, []int{1, 2}},
+		{[]int{1, 1, 2, 2, 2, 3, 1, 1}, []int{1, 2, 3, 1}},
+		{sortedSlice(5, 1), sortedSlice(5, 1)},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.in), func(t *testing.T) {
+			got := Compact(Clone(c.in))
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("Compact: want %d, got %d", c.out, got)
+			}
+
+			// compact values whose difference is < 10
+			got = CompactFunc(Clone(c.in), func(v1, v2 int) bool { return v2-v1 < 10 && v1-v2 < 10 })
+			if len(c.in) > 0 {
+				want := c.in[:1]
+				if !cmp.Equal(want, got) {
+					t.Fatalf("CompactFunc: want %d, got %d", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIndexContains(t *testing.T) {
+	cases := []struct {
+		in  []int
+		v   int
+		out int
+	}{
+		{nil, 1, -1},
+		{[]int{1}, 1, 0},
+		{[]int{1}, 2, -1},
+		{[]int{1, 2, 3, 2}, 2, 1},
+		{[]int{1, 2, 3, 2}, 3, 2},
+		{sortedSlice(10, 10), 100, 9},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%d in %v", c.v, c.in), func(t *testing.T) {
+			if got := Index(c.in, c.v); got != c.out {
+				t.Fatalf("Index: want %d, got %d", c.out, got)
+			}
+			if got := IndexFunc(c.in, func(v int) bool { return v == c.v }); got != c.out {
+				t.Fatalf("IndexFunc: want %d, got %d", c.out, got)
+			}
+			if got := Contains(c.in, c.v); got != (c.out >= 0) {
+				t.Fatalf("Contains: want %t, got %t", c.out >= 0, got)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	cases := []struct {
+		v1, v2 []int
+		out    bool
+	}{
+		{nil, nil, true},
+		{nil, []int{}, true},
+		{[]int{1}, nil, false},
+		{[]int{1}, []int{1}, true},
+		{[]int{1}, []int{2}, false},
+		{[]int{1, 2}, []int{2, 1}, false},
+		{sortedSlice(10, 1), sortedSlice(10, 1), true},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v == %v", c.v1, c.v2), func(t *testing.T) {
+			if got := Equal(c.v1, c.v2); got != c.out {
+				t.Fatalf("Equal: want %t, got %t", c.out, got)
+			}
+			if got := EqualFunc(c.v1, c.v2, func(v1, v2 int) bool { return v1 == v2 }); got != c.out {
+				t.Fatalf("EqualFunc: want %t, got %t", c.out, got)
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	if got := Clone(nil); got != nil {
+		t.Fatalf("want nil, got %d", got)
+	}
+	if got := Clone([]int{}); got == nil || len(got) != 0 {
+		t.Fatalf("want empty non-nil, got %#v", got)
+	}
+
+	vals := sortedSlice(3, 1)
+	got := Clone(vals)
+	if !cmp.Equal(vals, got) {
+		t.Fatalf("want %d, got %d", vals, got)
+	}
+	got[0] = 42
+	if vals[0] != 1 {
+		t.Fatalf("clone shares the underlying array")
+	}
+}
+
+func TestGrowClip(t *testing.T) {
+	cases := []struct {
+		in []int
+		n  int
+	}{
+		{nil, 0},
+		{nil, 10},
+		{[]int{1}, 1},
+		{make([]int, 3, 10), 7},
+		{make([]int, 3, 10), 8},
+		{sortedSlice(1024, 1), 1},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%d/%d + %d", len(c.in), cap(c.in), c.n), func(t *testing.T) {
+			got := Grow(c.in, c.n)
+			if !cmp.Equal(c.in, got, cmpopts.EquateEmpty()) {
+				t.Fatalf("want %d, got %d", c.in, got)
+			}
+			if cap(got)-len(got) < c.n {
+				t.Fatalf("want capacity for %d values, got %d", c.n, cap(got)-len(got))
+			}
+
+			got = Clip(got)
+			if !cmp.Equal(c.in, got, cmpopts.EquateEmpty()) {
+				t.Fatalf("want %d, got %d", c.in, got)
+			}
+			if cap(got) != len(got) {
+				t.Fatalf("want cap %d, got %d", len(got), cap(got))
+			}
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	cases := []struct {
+		in  []int
+		k   int
+		out []int
+	}{
+		{nil, 1, nil},
+		{[]int{1}, 1, []int{1}},
+		{[]int{1, 2}, 1, []int{2, 1}},
+		{[]int{1, 2, 3, 4, 5}, 0, []int{1, 2, 3, 4, 5}},
+		{[]int{1, 2, 3, 4, 5}, 2, []int{3, 4, 5, 1, 2}},
+		{[]int{1, 2, 3, 4, 5}, 5, []int{1, 2, 3, 4, 5}},
+		{[]int{1, 2, 3, 4, 5}, 7, []int{3, 4, 5, 1, 2}},
+		{[]int{1, 2, 3, 4, 5}, -1, []int{5, 1, 2, 3, 4}},
+		{[]int{1, 2, 3, 4, 5}, -6, []int{5, 1, 2, 3, 4}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v by %d", c.in, c.k), func(t *testing.T) {
+			Rotate(c.in, c.k)
+			if !cmp.Equal(c.out, c.in) {
+				t.Fatalf("want %d, got %d", c.out, c.in)
+			}
+		})
+	}
+}
+
+func TestChunkBatch(t *testing.T) {
+	cases := []struct {
+		in   []int
+		size int
+		out  [][]int
+	}{
+		{nil, 1, nil},
+		{[]int{1}, 1, [][]int{{1}}},
+		{[]int{1}, 2, [][]int{{1}}},
+		{[]int{1, 2, 3}, 1, [][]int{{1}, {2}, {3}}},
+		{[]int{1, 2, 3}, 2, [][]int{{1, 2}, {3}}},
+		{[]int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{[]int{1, 2, 3, 4}, 4, [][]int{{1, 2, 3, 4}}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v by %d", c.in, c.size), func(t *testing.T) {
+			got := Chunk(c.in, c.size)
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("Chunk: want %d, got %d", c.out, got)
+			}
+			for i := range got {
+				if cap(got[i]) != len(got[i]) {
+					t.Fatalf("Chunk: want chunk %d to be clipped, got cap %d", i, cap(got[i]))
+				}
+			}
+
+			var batches [][]int
+			Batch(c.in, c.size, func(batch []int) bool {
+				batches = append(batches, batch)
+				return true
+			})
+			if !cmp.Equal(c.out, batches) {
+				t.Fatalf("Batch: want %d, got %d", c.out, batches)
+			}
+		})
+	}
+
+	t.Run("BatchAppendLast", func(t *testing.T) {
+		// appending to the last batch must not overwrite the values past the
+		// end of vals in its spare capacity.
+		backing := []int{1, 2, 3, 4, 5, 6}
+		Batch(backing[:5], 2, func(batch []int) bool {
+			_ = append(batch, -1)
+			return true
+		})
+		if diff := cmp.Diff([]int{1, 2, 3, 4, 5, 6}, backing); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("BatchStop", func(t *testing.T) {
+		var calls int
+		Batch(sortedSlice(10, 1), 3, func(batch []int) bool {
+			calls++
+			return calls < 2
+		})
+		if calls != 2 {
+			t.Fatalf("want %d calls, got %d", 2, calls)
+		}
+	})
+}
+
+func TestWindows(t *testing.T) {
+	cases := []struct {
+		in   []int
+		size int
+		out  [][]int
+	}{
+		{nil, 1, nil},
+		{[]int{1}, 1, [][]int{{1}}},
+		{[]int{1}, 2, nil},
+		{[]int{1, 2, 3}, 1, [][]int{{1}, {2}, {3}}},
+		{[]int{1, 2, 3}, 2, [][]int{{1, 2}, {2, 3}}},
+		{[]int{1, 2, 3}, 3, [][]int{{1, 2, 3}}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v by %d", c.in, c.size), func(t *testing.T) {
+			got := Windows(c.in, c.size)
+			if !cmp.Equal(c.out, got) {
+				t.Fatalf("want %d, got %d", c.out, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,431 @@
+package splaytrees
+
+type (
+	K = int // NOTE: generic type placeholder
+	V = int // NOTE: generic type placeholder
+)
+
+// Map is an ordered map of keys to values, backed by a splay tree. Its keys
+// are kept in ascending order as defined by the standard <, <=, >, >=
+// operators, so that they can be iterated in order and queried by range.
+// Its zero-value is ready to use.
+//
+// A splay tree is a self-adjusting binary search tree: each access moves
+// the accessed key to the root with a sequence of rotations (a splay),
+// which keeps the tree balanced in an amortized sense. Operations run in
+// O(log n) amortized time complexity, and recently accessed keys are faster
+// to access again. It can be split and merged in O(log n) amortized time
+// complexity, see Split and Merge.
+//
+// Note that as lookups modify the tree, it is not safe to call any method
+// concurrently, even those that only read from the map.
+type Map /*[K algo.Ordered, V algo.Any]*/ struct {
+	root *node /*[K, V]*/
+}
+
+type node /*[K algo.Ordered, V algo.Any]*/ struct {
+	key         K
+	val         V
+	size        int   // number of nodes in the subtree rooted at this node
+	left, right *node /*[K, V]*/
+}
+
+// Make returns an ordered map of some key and value types.
+func Make /*[K algo.Ordered, V algo.Any]*/ () *Map /*[K, V]*/ {
+	return &Map /*[K, V]*/ {}
+}
+
+// splays the subtree rooted at t for the key k and returns its new root,
+// which is the node of k if it is in the subtree, or the last node visited
+// when searching for k otherwise (i.e. its predecessor or successor in the
+// subtree). It implements the top-down splay of Sleator and Tarjan, with
+// the maintenance of the subtree sizes.
+func splay /*[K algo.Ordered, V algo.Any]*/ (t *node /*[K, V]*/, k K) *node /*[K, V]*/ {
+	if t == nil {
+		return nil
+	}
+
+	// the nodes smaller than k are linked in the left tree, on the right
+	// spine below header.right, and the nodes larger than k in the right
+	// tree, on the left spine below header.left.
+	var header node /*[K, V]*/
+	l, r := &header, &header
+	var lsize, rsize int
+	for {
+		if k < t.key {
+			if t.left == nil {
+				break
+			}
+			if k < t.left.key {
+				// rotate right
+				y := t.left
+				t.left = y.right
+				y.right = t
+				t.update()
+				t = y
+				if t.left == nil {
+					break
+				}
+			}
+			// link right
+			r.left = t
+			r = t
+			t = t.left
+			rsize += 1 + r.right.len()
+		} else if k > t.key {
+			if t.right == nil {
+				break
+			}
+			if k > t.right.key {
+				// rotate left
+				y := t.right
+				t.right = y.left
+				y.left = t
+				t.update()
+				t = y
+				if t.right == nil {
+					break
+				}
+			}
+			// link left
+			l.right = t
+			l = t
+			t = t.right
+			lsize += 1 + l.left.len()
+		} else {
+			break
+		}
+	}
+
+	// the sizes of the nodes on the spines of the left and right trees are
+	// fixed from the top down, as each one is the size of the whole tree minus
+	// the nodes above it.
+	lsize += t.left.len()
+	rsize += t.right.len()
+	t.size = lsize + rsize + 1
+	l.right, r.left = nil, nil
+	for y := header.right; y != nil; y = y.right {
+		y.size = lsize
+		lsize -= 1 + y.left.len()
+	}
+	for y := header.left; y != nil; y = y.left {
+		y.size = rsize
+		rsize -= 1 + y.right.len()
+	}
+
+	// assemble the left, middle and right trees
+	l.right, r.left = t.left, t.right
+	t.left, t.right = header.right, header.left
+	return t
+}
+
+// Len returns the number of keys in the map m.
+func (m *Map /*[K, V]*/) Len() int {
+	return m.root.len()
+}
+
+// Get returns the value associated with k and true, or the zero value of V
+// and false if k is not in the map. It moves k, or the last key visited
+// when searching for k, to the root of the tree.
+//
+// It runs in O(log n) amortized time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Get(k K) (V, bool) {
+	m.root = splay(m.root, k)
+	if m.root != nil && m.root.key == k {
+		return m.root.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether k is in the map m. Like Get, it moves k, or the
+// last key visited when searching for k, to the root of the tree.
+//
+// It runs in O(log n) amortized time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Contains(k K) bool {
+	_, ok := m.Get(k)
+	return ok
+}
+
+// Put associates the value v with the key k in the map m, replacing the
+// previous value if k is already in m. It moves k to the root of the tree.
+//
+// It runs in O(log n) amortized time complexity.
+func (m *Map /*[K, V]*/) Put(k K, v V) {
+	t := splay(m.root, k)
+	if t != nil && t.key == k {
+		t.val = v
+		m.root = t
+		return
+	}
+
+	// the new node becomes the root, with the keys smaller than k on its left
+	// and the keys larger than k on its right.
+	n := &node /*[K, V]*/ {key: k, val: v}
+	if t != nil {
+		if k < t.key {
+			n.left, n.right = t.left, t
+			t.left = nil
+		} else {
+			n.left, n.right = t, t.right
+			t.right = nil
+		}
+		t.update()
+	}
+	n.update()
+	m.root = n
+}
+
+// Delete removes the key k and its associated value from the map m and
+// returns true, or false if k is not in m.
+//
+// It runs in O(log n) amortized time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Delete(k K) bool {
+	t := splay(m.root, k)
+	m.root = t
+	if t == nil || t.key != k {
+		return false
+	}
+	if t.left == nil {
+		m.root = t.right
+		return true
+	}
+
+	// splaying the left subtree for k moves its largest key to its root,
+	// which then has no right child.
+	l := splay(t.left, k)
+	l.right = t.right
+	l.update()
+	m.root = l
+	return true
+}
+
+// Split removes the keys greater than or equal to k from the map m and
+// returns them in a new map. The map m keeps the keys smaller than k.
+//
+// It runs in O(log n) amortized time complexity. It does not allocate,
+// except for the returned map.
+func (m *Map /*[K, V]*/) Split(k K) *Map /*[K, V]*/ {
+	t := splay(m.root, k)
+	if t == nil {
+		return &Map /*[K, V]*/ {}
+	}
+	if t.key < k {
+		r := t.right
+		t.right = nil
+		t.update()
+		m.root = t
+		return &Map /*[K, V]*/ {root: r}
+	}
+	l := t.left
+	t.left = nil
+	t.update()
+	m.root = l
+	return &Map /*[K, V]*/ {root: t}
+}
+
+// Merge returns a map with all keys and values of the maps a and b, which
+// are left empty. It panics if the keys of a are not all smaller than the
+// keys of b.
+//
+// It runs in O(log n) amortized time complexity. It does not allocate,
+// except for the returned map.
+func Merge /*[K algo.Ordered, V algo.Any]*/ (a, b *Map /*[K, V]*/) *Map /*[K, V]*/ {
+	amax, _, aok := a.Max()
+	bmin, _, bok := b.Min()
+	if aok && bok && !(amax < bmin) {
+		panic("splaytrees: keys of a are not smaller than keys of b")
+	}
+
+	// after Max, the largest key of a is at its root, which has no right
+	// child.
+	m := &Map /*[K, V]*/ {root: b.root}
+	if a.root != nil {
+		a.root.right = b.root
+		a.root.update()
+		m.root = a.root
+	}
+	a.root, b.root = nil, nil
+	return m
+}
+
+// Min returns the smallest key of the map and its value, and true, or the
+// zero values and false if the map is empty. It moves the smallest key to
+// the root of the tree.
+//
+// It runs in O(log n) amortized time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Min() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	m.root = splay(m.root, n.key)
+	return n.key, n.val, true
+}
+
+// Max returns the largest key of the map and its value, and true, or the
+// zero values and false if the map is empty. It moves the largest key to the
+// root of the tree.
+//
+// It runs in O(log n) amortized time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Max() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	m.root = splay(m.root, n.key)
+	return n.key, n.val, true
+}
+
+// Floor returns the largest key of the map that is smaller than or equal to
+// k and its value, and true, or the zero values and false if there is no
+// such key. It moves the returned key to the root of the tree.
+//
+// It runs in O(log n) amortized time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Floor(k K) (K, V, bool) {
+	t := splay(m.root, k)
+	m.root = t
+	if t == nil || (k < t.key && t.left == nil) {
+		var k K
+		var v V
+		return k, v, false
+	}
+	if k < t.key {
+		// the root is the successor of k, so the floor is the largest key of
+		// the left subtree.
+		n := t.left
+		for n.right != nil {
+			n = n.right
+		}
+		m.root = splay(t, n.key)
+	}
+	return m.root.key, m.root.val, true
+}
+
+// Ceiling returns the smallest key of the map that is larger than or equal
+// to k and its value, and true, or the zero values and false if there is no
+// such key. It moves the returned key to the root of the tree.
+//
+// It runs in O(log n) amortized time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Ceiling(k K) (K, V, bool) {
+	t := splay(m.root, k)
+	m.root = t
+	if t == nil || (k > t.key && t.right == nil) {
+		var k K
+		var v V
+		return k, v, false
+	}
+	if k > t.key {
+		// the root is the predecessor of k, so the ceiling is the smallest key
+		// of the right subtree.
+		n := t.right
+		for n.left != nil {
+			n = n.left
+		}
+		m.root = splay(t, n.key)
+	}
+	return m.root.key, m.root.val, true
+}
+
+// Keys returns a slice of all keys of the map, in ascending order.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Keys() []K {
+	var keys []K
+	if n := m.Len(); n > 0 {
+		keys = make([]K, 0, n)
+		m.Ascend(func(k K, _ V) bool {
+			keys = append(keys, k)
+			return true
+		})
+	}
+	return keys
+}
+
+// Values returns a slice of all values of the map, in ascending order of
+// their keys.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Values() []V {
+	var vals []V
+	if n := m.Len(); n > 0 {
+		vals = make([]V, 0, n)
+		m.Ascend(func(_ K, v V) bool {
+			vals = append(vals, v)
+			return true
+		})
+	}
+	return vals
+}
+
+// Ascend calls fn for each key and value of the map, in ascending order of
+// keys, until all keys have been visited or fn returns false. The map must
+// not be modified during the iteration. It does not change the shape of the
+// tree.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Ascend(fn func(K, V) bool) {
+	m.ascend(m.root, nil, fn)
+}
+
+// AscendRange calls fn for each key and value of the map in the half-open
+// range [lo, hi), in ascending order of keys, until all keys in the range
+// have been visited or fn returns false. The map must not be modified during
+// the iteration. It moves the first key of the range to the root of the
+// tree.
+//
+// It runs in O(log n + m) amortized time complexity where m is the number of
+// keys visited.
+func (m *Map /*[K, V]*/) AscendRange(lo, hi K, fn func(K, V) bool) {
+	if !(lo < hi) || m.root == nil {
+		return
+	}
+	if _, _, ok := m.Ceiling(lo); !ok {
+		return
+	}
+	// the first key of the range is at the root, so the rest of the range is
+	// in its right subtree.
+	if t := m.root; t.key < hi && fn(t.key, t.val) {
+		m.ascend(t.right, &hi, fn)
+	}
+}
+
+// visits the subtree rooted at n in order, up to hi (excluded) if it is not
+// nil. A splay tree may be deep, so it uses an explicit stack instead of
+// recursion.
+func (m *Map /*[K, V]*/) ascend(n *node /*[K, V]*/, hi *K, fn func(K, V) bool) {
+	var stack []*node /*[K, V]*/
+	for n != nil || len(stack) > 0 {
+		for n != nil {
+			stack = append(stack, n)
+			n = n.left
+		}
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if (hi != nil && !(n.key < *hi)) || !fn(n.key, n.val) {
+			return
+		}
+		n = n.right
+	}
+}
+
+func (n *node /*[K, V]*/) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *node /*[K, V]*/) update() {
+	n.size = n.left.len() + n.right.len() + 1
+}
@@ -0,0 +1,79 @@
+package splaytrees
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// returns a map with the keys 0, 2, 4, ..., 2*(n-1), inserted in random
+// order.
+func benchMap(n int) *Map {
+	m := Make()
+	for _, k := range rand.New(rand.NewSource(1)).Perm(n) {
+		m.Put(2*k, k)
+	}
+	return m
+}
+
+func BenchmarkMap_PutDelete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				k := 2*(i%n) + 1
+				m.Put(k, i)
+				m.Delete(k)
+			}
+		})
+	}
+}
+
+func BenchmarkMap_Get(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, ok := m.Get(2 * r.Intn(n)); !ok {
+					b.Fatal("Get returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMap_GetSkewed(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			// most accesses are to a few keys, which stay near the root
+			z := rand.NewZipf(rand.New(rand.NewSource(1)), 1.5, 1, uint64(n-1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, ok := m.Get(2 * int(z.Uint64())); !ok {
+					b.Fatal("Get returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMap_SplitMerge(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				r := m.Split(2*(i%n) + 1)
+				m = Merge(m, r)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package splaytrees
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestMap(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var m Map
+		if m.Len() != 0 || m.Keys() != nil || m.Values() != nil {
+			t.Fatal("want empty map")
+		}
+		if _, ok := m.Get(1); ok {
+			t.Fatal("want no value")
+		}
+		if _, _, ok := m.Min(); ok {
+			t.Fatal("want no min")
+		}
+		if _, _, ok := m.Max(); ok {
+			t.Fatal("want no max")
+		}
+		if _, _, ok := m.Floor(1); ok {
+			t.Fatal("want no floor")
+		}
+		if _, _, ok := m.Ceiling(1); ok {
+			t.Fatal("want no ceiling")
+		}
+		if m.Delete(1) {
+			t.Fatal("want no delete")
+		}
+		if s := m.Split(1); s.Len() != 0 || m.Len() != 0 {
+			t.Fatal("want empty split")
+		}
+		m.Put(1, 2)
+		if v, ok := m.Get(1); !ok || v != 2 || m.Len() != 1 {
+			t.Fatal("want single key")
+		}
+	})
+
+	t.Run("PutReplace", func(t *testing.T) {
+		m := Make()
+		m.Put(1, 10)
+		m.Put(2, 20)
+		m.Put(1, 11)
+		if m.Len() != 2 {
+			t.Fatalf("want len 2, got %d", m.Len())
+		}
+		if v, _ := m.Get(1); v != 11 {
+			t.Fatalf("want replaced value 11, got %d", v)
+		}
+		if m.root.key != 1 {
+			t.Fatal("want accessed key at the root")
+		}
+	})
+
+	t.Run("PutDeleteRandom", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		m := Make()
+		ref := make(map[K]V)
+		for i := 0; i < 10000; i++ {
+			k := r.Intn(1000)
+			switch r.Intn(4) {
+			case 0:
+				_, want := ref[k]
+				if got := m.Delete(k); got != want {
+					t.Fatalf("%d: want deleted %t, got %t", k, want, got)
+				}
+				delete(ref, k)
+			case 1:
+				_, want := ref[k]
+				if got := m.Contains(k); got != want {
+					t.Fatalf("%d: want contains %t, got %t", k, want, got)
+				}
+			default:
+				v := r.Int()
+				m.Put(k, v)
+				ref[k] = v
+			}
+			if i%100 == 0 {
+				checkMap(t, m, ref)
+			}
+		}
+		checkMap(t, m, ref)
+	})
+
+	t.Run("Sequential", func(t *testing.T) {
+		// sorted insertions create a degenerate tree, which accesses rebalance
+		m := Make()
+		ref := make(map[K]V)
+		for i := 0; i < 100000; i++ {
+			m.Put(i, i)
+			ref[i] = i
+		}
+		checkMap(t, m, ref)
+		for i := 0; i < 100000; i += 1000 {
+			m.Get(i)
+		}
+		checkMap(t, m, ref)
+	})
+}
+
+func TestMapQueries(t *testing.T) {
+	m := Make()
+	for _, k := range []K{50, 10, 40, 20, 30} {
+		m.Put(k, k*10)
+	}
+	if k, v, ok := m.Min(); !ok || k != 10 || v != 100 {
+		t.Fatalf("want min 10, got %d", k)
+	}
+	if k, v, ok := m.Max(); !ok || k != 50 || v != 500 {
+		t.Fatalf("want max 50, got %d", k)
+	}
+
+	cases := []struct {
+		k           K
+		floor, ceil K // -1 if none
+	}{
+		{5, -1, 10},
+		{10, 10, 10},
+		{15, 10, 20},
+		{30, 30, 30},
+		{45, 40, 50},
+		{50, 50, 50},
+		{55, 50, -1},
+	}
+	for _, c := range cases {
+		k, v, ok := m.Floor(c.k)
+		if (c.floor == -1 && ok) || (c.floor != -1 && (!ok || k != c.floor || v != c.floor*10)) {
+			t.Fatalf("%d: want floor %d, got %d (%t)", c.k, c.floor, k, ok)
+		}
+		k, v, ok = m.Ceiling(c.k)
+		if (c.ceil == -1 && ok) || (c.ceil != -1 && (!ok || k != c.ceil || v != c.ceil*10)) {
+			t.Fatalf("%d: want ceiling %d, got %d (%t)", c.k, c.ceil, k, ok)
+		}
+	}
+
+	ranges := []struct {
+		lo, hi K
+		want   []K
+	}{
+		{0, 100, []K{10, 20, 30, 40, 50}},
+		{10, 50, []K{10, 20, 30, 40}},
+		{11, 50, []K{20, 30, 40}},
+		{20, 21, []K{20}},
+		{21, 29, nil},
+		{30, 30, nil},
+		{40, 20, nil},
+		{60, 70, nil},
+	}
+	for _, c := range ranges {
+		var got []K
+		m.AscendRange(c.lo, c.hi, func(k K, v V) bool {
+			got = append(got, k)
+			return true
+		})
+		if diff := cmp.Diff(c.want, got); diff != "" {
+			t.Fatalf("[%d, %d): %s", c.lo, c.hi, diff)
+		}
+	}
+
+	// stop early
+	var got []K
+	m.AscendRange(0, 100, func(k K, v V) bool {
+		got = append(got, k)
+		return k < 30
+	})
+	if diff := cmp.Diff([]K{10, 20, 30}, got); diff != "" {
+		t.Fatal(diff)
+	}
+	got = nil
+	m.AscendRange(0, 100, func(k K, v V) bool {
+		got = append(got, k)
+		return false
+	})
+	if diff := cmp.Diff([]K{10}, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestSplitMerge(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("random seed: %d", seed)
+	r := rand.New(rand.NewSource(seed))
+
+	m := Make()
+	ref := make(map[K]V)
+	for i := 0; i < 1000; i++ {
+		k := r.Intn(2000)
+		m.Put(k, i)
+		ref[k] = i
+	}
+
+	for _, at := range []K{-1, 0, 500, 1000, 1999, 2000, 3000} {
+		right := m.Split(at)
+		lref, rref := make(map[K]V), make(map[K]V)
+		for k, v := range ref {
+			if k < at {
+				lref[k] = v
+			} else {
+				rref[k] = v
+			}
+		}
+		checkMap(t, m, lref)
+		checkMap(t, right, rref)
+
+		m = Merge(m, right)
+		checkMap(t, m, ref)
+		if right.Len() != 0 {
+			t.Fatal("want merged map emptied")
+		}
+	}
+
+	t.Run("Overlap", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("want panic")
+			}
+		}()
+
+		a, b := Make(), Make()
+		a.Put(1, 1)
+		a.Put(5, 5)
+		b.Put(5, 5)
+		Merge(a, b)
+	})
+}
+
+// checkMap checks that m contains the same keys and values as ref, and that
+// its tree is a valid binary search tree. Note that it changes the shape of
+// the tree, as it looks up all keys.
+func checkMap(t *testing.T, m *Map, ref map[K]V) {
+	t.Helper()
+
+	keys := make([]K, 0, len(ref))
+	vals := make([]V, 0, len(ref))
+	for k := range ref {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	for _, k := range keys {
+		vals = append(vals, ref[k])
+	}
+
+	if m.Len() != len(ref) {
+		t.Fatalf("want len %d, got %d", len(ref), m.Len())
+	}
+	if diff := cmp.Diff(keys, m.Keys(), cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("keys: %s", diff)
+	}
+	if diff := cmp.Diff(vals, m.Values(), cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("values: %s", diff)
+	}
+	checkNodes(t, m.root)
+	for _, k := range keys {
+		if v, ok := m.Get(k); !ok || v != ref[k] {
+			t.Fatalf("%d: want value %d, got %d", k, ref[k], v)
+		}
+	}
+	checkNodes(t, m.root)
+}
+
+// checkNodes checks the order of the keys and the sizes of the subtrees of
+// the tree rooted at root, without recursion as the tree may be deep.
+func checkNodes(t *testing.T, root *node) {
+	t.Helper()
+
+	type bounded struct {
+		n      *node
+		lo, hi *K
+	}
+	stack := []bounded{{n: root}}
+	for len(stack) > 0 {
+		b := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		n := b.n
+		if n == nil {
+			continue
+		}
+		if (b.lo != nil && n.key <= *b.lo) || (b.hi != nil && n.key >= *b.hi) {
+			t.Fatalf("%d: key out of order", n.key)
+		}
+		if n.size != n.left.len()+n.right.len()+1 {
+			t.Fatalf("%d: want size %d, got %d", n.key, n.left.len()+n.right.len()+1, n.size)
+		}
+		stack = append(stack, bounded{n.left, b.lo, &n.key}, bounded{n.right, &n.key, b.hi})
+	}
+}
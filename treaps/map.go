@@ -0,0 +1,384 @@
+package treaps
+
+import "math/rand"
+
+type (
+	K = int // NOTE: generic type placeholder
+	V = int // NOTE: generic type placeholder
+)
+
+// Map is an ordered map of keys to values, backed by a treap. Its keys are
+// kept in ascending order as defined by the standard <, <=, >, >=
+// operators, so that they can be iterated in order and queried by range.
+//
+// A treap is a binary search tree on the keys that is also a heap on random
+// priorities assigned to the nodes, so that its shape is the same as if the
+// keys had been inserted in random order, and its expected height is
+// O(log n) regardless of the order of insertions. It can be split and
+// merged in O(log n) expected time complexity, see Split and Merge. Its
+// zero-value is ready to use, with the global source of the math/rand
+// package.
+type Map /*[K algo.Ordered, V algo.Any]*/ struct {
+	root *node /*[K, V]*/
+	r    *rand.Rand
+}
+
+type node /*[K algo.Ordered, V algo.Any]*/ struct {
+	key         K
+	val         V
+	prio        uint32
+	size        int   // number of nodes in the subtree rooted at this node
+	left, right *node /*[K, V]*/
+}
+
+// Make returns an ordered map of some key and value types, that uses the
+// provided *rand.Rand to generate the priorities of the nodes. If r is nil,
+// the global source of the math/rand package is used.
+func Make /*[K algo.Ordered, V algo.Any]*/ (r *rand.Rand) *Map /*[K, V]*/ {
+	return &Map /*[K, V]*/ {r: r}
+}
+
+func randomPriority(r *rand.Rand) uint32 {
+	if r == nil {
+		return rand.Uint32()
+	}
+	return r.Uint32()
+}
+
+// Len returns the number of keys in the map m.
+func (m *Map /*[K, V]*/) Len() int {
+	return m.root.len()
+}
+
+// Get returns the value associated with k and true, or the zero value of V
+// and false if k is not in the map.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Get(k K) (V, bool) {
+	for n := m.root; n != nil; {
+		switch {
+		case k < n.key:
+			n = n.left
+		case k > n.key:
+			n = n.right
+		default:
+			return n.val, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether k is in the map m.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Contains(k K) bool {
+	_, ok := m.Get(k)
+	return ok
+}
+
+// Put associates the value v with the key k in the map m, replacing the
+// previous value if k is already in m.
+//
+// It runs in O(log n) expected time complexity.
+func (m *Map /*[K, V]*/) Put(k K, v V) {
+	for n := m.root; n != nil; {
+		switch {
+		case k < n.key:
+			n = n.left
+		case k > n.key:
+			n = n.right
+		default:
+			n.val = v
+			return
+		}
+	}
+	nn := &node /*[K, V]*/ {key: k, val: v, prio: randomPriority(m.r), size: 1}
+	m.root = insert(m.root, nn)
+}
+
+// inserts the node nn, whose key is not in the subtree rooted at n, and
+// returns the new root of the subtree. The node is placed where its
+// priority is higher than its descendants, by splitting the subtree at its
+// key.
+func insert /*[K algo.Ordered, V algo.Any]*/ (n, nn *node /*[K, V]*/) *node /*[K, V]*/ {
+	if n == nil {
+		return nn
+	}
+	if nn.prio > n.prio {
+		nn.left, nn.right = split(n, nn.key)
+		nn.update()
+		return nn
+	}
+	if nn.key < n.key {
+		n.left = insert(n.left, nn)
+	} else {
+		n.right = insert(n.right, nn)
+	}
+	n.update()
+	return n
+}
+
+// Delete removes the key k and its associated value from the map m and
+// returns true, or false if k is not in m.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Delete(k K) bool {
+	if !m.Contains(k) {
+		return false
+	}
+	m.root = remove(m.root, k)
+	return true
+}
+
+// removes k, which must be in the subtree rooted at n, and returns the new
+// root of the subtree.
+func remove /*[K algo.Ordered, V algo.Any]*/ (n *node /*[K, V]*/, k K) *node /*[K, V]*/ {
+	switch {
+	case k < n.key:
+		n.left = remove(n.left, k)
+	case k > n.key:
+		n.right = remove(n.right, k)
+	default:
+		return merge(n.left, n.right)
+	}
+	n.update()
+	return n
+}
+
+// Split removes the keys greater than or equal to k from the map m and
+// returns them in a new map, which uses the same source of random numbers
+// as m. The map m keeps the keys smaller than k.
+//
+// It runs in O(log n) expected time complexity. It does not allocate,
+// except for the returned map.
+func (m *Map /*[K, V]*/) Split(k K) *Map /*[K, V]*/ {
+	l, r := split(m.root, k)
+	m.root = l
+	return &Map /*[K, V]*/ {root: r, r: m.r}
+}
+
+// splits the subtree rooted at n in two subtrees, one with the keys smaller
+// than k and one with the keys greater than or equal to k.
+func split /*[K algo.Ordered, V algo.Any]*/ (n *node /*[K, V]*/, k K) (l, r *node /*[K, V]*/) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.key < k {
+		n.right, r = split(n.right, k)
+		n.update()
+		return n, r
+	}
+	l, n.left = split(n.left, k)
+	n.update()
+	return l, n
+}
+
+// Merge returns a map with all keys and values of the maps a and b, which
+// are left empty. The returned map uses the same source of random numbers as
+// a. It panics if the keys of a are not all smaller than the keys of b.
+//
+// It runs in O(log n) expected time complexity. It does not allocate,
+// except for the returned map.
+func Merge /*[K algo.Ordered, V algo.Any]*/ (a, b *Map /*[K, V]*/) *Map /*[K, V]*/ {
+	if amax, _, ok := a.Max(); ok {
+		if bmin, _, ok := b.Min(); ok && !(amax < bmin) {
+			panic("treaps: keys of a are not smaller than keys of b")
+		}
+	}
+	m := &Map /*[K, V]*/ {root: merge(a.root, b.root), r: a.r}
+	a.root, b.root = nil, nil
+	return m
+}
+
+// merges the subtrees rooted at l and r, where all keys of l are smaller
+// than all keys of r, and returns the root of the merged subtree.
+func merge /*[K algo.Ordered, V algo.Any]*/ (l, r *node /*[K, V]*/) *node /*[K, V]*/ {
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	if l.prio > r.prio {
+		l.right = merge(l.right, r)
+		l.update()
+		return l
+	}
+	r.left = merge(l, r.left)
+	r.update()
+	return r
+}
+
+// Min returns the smallest key of the map and its value, and true, or the
+// zero values and false if the map is empty.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Min() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, n.val, true
+}
+
+// Max returns the largest key of the map and its value, and true, or the
+// zero values and false if the map is empty.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Max() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.val, true
+}
+
+// Floor returns the largest key of the map that is smaller than or equal to
+// k and its value, and true, or the zero values and false if there is no
+// such key.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Floor(k K) (K, V, bool) {
+	var floor *node /*[K, V]*/
+	for n := m.root; n != nil; {
+		switch {
+		case k < n.key:
+			n = n.left
+		case k > n.key:
+			floor = n
+			n = n.right
+		default:
+			return n.key, n.val, true
+		}
+	}
+	if floor == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return floor.key, floor.val, true
+}
+
+// Ceiling returns the smallest key of the map that is larger than or equal
+// to k and its value, and true, or the zero values and false if there is no
+// such key.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (m *Map /*[K, V]*/) Ceiling(k K) (K, V, bool) {
+	var ceil *node /*[K, V]*/
+	for n := m.root; n != nil; {
+		switch {
+		case k < n.key:
+			ceil = n
+			n = n.left
+		case k > n.key:
+			n = n.right
+		default:
+			return n.key, n.val, true
+		}
+	}
+	if ceil == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return ceil.key, ceil.val, true
+}
+
+// Keys returns a slice of all keys of the map, in ascending order.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Keys() []K {
+	var keys []K
+	if n := m.Len(); n > 0 {
+		keys = make([]K, 0, n)
+		m.Ascend(func(k K, _ V) bool {
+			keys = append(keys, k)
+			return true
+		})
+	}
+	return keys
+}
+
+// Values returns a slice of all values of the map, in ascending order of
+// their keys.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Values() []V {
+	var vals []V
+	if n := m.Len(); n > 0 {
+		vals = make([]V, 0, n)
+		m.Ascend(func(_ K, v V) bool {
+			vals = append(vals, v)
+			return true
+		})
+	}
+	return vals
+}
+
+// Ascend calls fn for each key and value of the map, in ascending order of
+// keys, until all keys have been visited or fn returns false. The map must
+// not be modified during the iteration.
+//
+// It runs in O(n) time complexity where n is the number of keys in the map.
+func (m *Map /*[K, V]*/) Ascend(fn func(K, V) bool) {
+	m.root.ascend(fn)
+}
+
+// AscendRange calls fn for each key and value of the map in the half-open
+// range [lo, hi), in ascending order of keys, until all keys in the range
+// have been visited or fn returns false. The map must not be modified during
+// the iteration.
+//
+// It runs in O(log n + m) expected time complexity where m is the number of
+// keys visited.
+func (m *Map /*[K, V]*/) AscendRange(lo, hi K, fn func(K, V) bool) {
+	if lo < hi {
+		m.root.ascendRange(lo, hi, fn)
+	}
+}
+
+func (n *node /*[K, V]*/) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *node /*[K, V]*/) update() {
+	n.size = n.left.len() + n.right.len() + 1
+}
+
+func (n *node /*[K, V]*/) ascend(fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.ascend(fn) && fn(n.key, n.val) && n.right.ascend(fn)
+}
+
+func (n *node /*[K, V]*/) ascendRange(lo, hi K, fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if lo < n.key && !n.left.ascendRange(lo, hi, fn) {
+		return false
+	}
+	if lo <= n.key && n.key < hi && !fn(n.key, n.val) {
+		return false
+	}
+	if n.key < hi {
+		return n.right.ascendRange(lo, hi, fn)
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package treaps
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// returns a map with the keys 0, 2, 4, ..., 2*(n-1), inserted in random
+// order.
+func benchMap(n int) *Map {
+	r := rand.New(rand.NewSource(1))
+	m := Make(r)
+	for _, k := range r.Perm(n) {
+		m.Put(2*k, k)
+	}
+	return m
+}
+
+func BenchmarkMap_PutDelete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				k := 2*(i%n) + 1
+				m.Put(k, i)
+				m.Delete(k)
+			}
+		})
+	}
+}
+
+func BenchmarkMap_Get(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, ok := m.Get(2 * (i % n)); !ok {
+					b.Fatal("Get returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMap_SplitMerge(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			m := benchMap(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				r := m.Split(2*(i%n) + 1)
+				m = Merge(m, r)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package treaps
+
+import "math/rand"
+
+// Sequence is a sequence of values backed by an implicit treap, that is a
+// treap where the key of each node is its position in the sequence, which is
+// not stored but implied by the size of the subtrees. Values can be
+// inserted, deleted and accessed at any position, and any subrange can be
+// reversed, in O(log n) expected time complexity. It is useful e.g. for text
+// buffers or playlists. Its zero-value is an empty sequence ready to use,
+// with the global source of the math/rand package.
+type Sequence /*[V algo.Any]*/ struct {
+	root *snode /*[V]*/
+	r    *rand.Rand
+}
+
+type snode /*[V algo.Any]*/ struct {
+	val         V
+	prio        uint32
+	size        int    // number of nodes in the subtree rooted at this node
+	rev         bool   // the subtree must be reversed, i.e. its children swapped recursively
+	left, right *snode /*[V]*/
+}
+
+// MakeSequence returns an empty sequence of some value type, that uses the
+// provided *rand.Rand to generate the priorities of the nodes. If r is nil,
+// the global source of the math/rand package is used.
+func MakeSequence /*[V algo.Any]*/ (r *rand.Rand) *Sequence /*[V]*/ {
+	return &Sequence /*[V]*/ {r: r}
+}
+
+// MakeSequenceFrom returns a sequence of some value type initialized with
+// vals, in the same order, that uses the provided *rand.Rand to generate the
+// priorities of the nodes. If r is nil, the global source of the math/rand
+// package is used.
+//
+// It runs in O(n) time complexity.
+func MakeSequenceFrom /*[V algo.Any]*/ (r *rand.Rand, vals []V) *Sequence /*[V]*/ {
+	// build the treap from left to right, keeping the stack of nodes on its
+	// right spine: a new node becomes the right child of the last node with a
+	// higher priority, and the nodes with a lower priority become its left
+	// subtree. Each node is popped once, at which point its subtree is
+	// complete.
+	var stack []*snode /*[V]*/
+	for _, v := range vals {
+		n := &snode /*[V]*/ {val: v, prio: randomPriority(r), size: 1}
+		var last *snode /*[V]*/
+		for len(stack) > 0 && stack[len(stack)-1].prio < n.prio {
+			last = stack[len(stack)-1]
+			last.update()
+			stack = stack[:len(stack)-1]
+		}
+		n.left = last
+		if len(stack) > 0 {
+			stack[len(stack)-1].right = n
+		}
+		stack = append(stack, n)
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].update()
+	}
+
+	s := &Sequence /*[V]*/ {r: r}
+	if len(stack) > 0 {
+		s.root = stack[0]
+	}
+	return s
+}
+
+// Len returns the number of values in the sequence s.
+func (s *Sequence /*[V]*/) Len() int {
+	return s.root.len()
+}
+
+func (s *Sequence /*[V]*/) checkIndex(i, limit int) {
+	if i < 0 || i > limit {
+		panic("treaps: index out of range")
+	}
+}
+
+// returns the node at index i, which must be in range.
+func (s *Sequence /*[V]*/) at(i int) *snode /*[V]*/ {
+	// the pending reversals are not applied, so that reading does not modify
+	// the tree, instead the children are swapped when the number of reversals
+	// on the path is odd.
+	n, rev := s.root, false
+	for {
+		rev = rev != n.rev
+		left, right := n.left, n.right
+		if rev {
+			left, right = right, left
+		}
+		switch l := left.len(); {
+		case i < l:
+			n = left
+		case i > l:
+			n = right
+			i -= l + 1
+		default:
+			return n
+		}
+	}
+}
+
+// At returns the value at index i in the sequence s. It panics if i is out
+// of range.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (s *Sequence /*[V]*/) At(i int) V {
+	s.checkIndex(i, s.Len()-1)
+	return s.at(i).val
+}
+
+// Set sets the value at index i in the sequence s to v. It panics if i is
+// out of range.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (s *Sequence /*[V]*/) Set(i int, v V) {
+	s.checkIndex(i, s.Len()-1)
+	s.at(i).val = v
+}
+
+// Insert inserts v at index i in the sequence s, shifting the values at i
+// and after by one position. Index i may be equal to the length of the
+// sequence to append v. It panics if i is out of range.
+//
+// It runs in O(log n) expected time complexity.
+func (s *Sequence /*[V]*/) Insert(i int, v V) {
+	s.checkIndex(i, s.Len())
+	l, r := splitAt(s.root, i)
+	n := &snode /*[V]*/ {val: v, prio: randomPriority(s.r), size: 1}
+	s.root = mergeSeq(mergeSeq(l, n), r)
+}
+
+// Delete removes the value at index i in the sequence s and returns it,
+// shifting the values after i by one position. It panics if i is out of
+// range.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (s *Sequence /*[V]*/) Delete(i int) V {
+	s.checkIndex(i, s.Len()-1)
+	l, r := splitAt(s.root, i)
+	n, r := splitAt(r, 1)
+	s.root = mergeSeq(l, r)
+	return n.val
+}
+
+// Reverse reverses the order of the values in the half-open range [i, j) of
+// the sequence s. It panics if i or j is out of range, or if i > j.
+//
+// It runs in O(log n) expected time complexity. It does not allocate.
+func (s *Sequence /*[V]*/) Reverse(i, j int) {
+	s.checkIndex(j, s.Len())
+	s.checkIndex(i, j)
+	l, r := splitAt(s.root, j)
+	l, mid := splitAt(l, i)
+	if mid != nil {
+		// the reversal is applied lazily, when the subtree is next modified
+		mid.rev = !mid.rev
+	}
+	s.root = mergeSeq(mergeSeq(l, mid), r)
+}
+
+// Split removes the values at index i and after from the sequence s and
+// returns them in a new sequence, which uses the same source of random
+// numbers as s. The sequence s keeps the first i values. It panics if i is
+// out of range.
+//
+// It runs in O(log n) expected time complexity. It does not allocate,
+// except for the returned sequence.
+func (s *Sequence /*[V]*/) Split(i int) *Sequence /*[V]*/ {
+	s.checkIndex(i, s.Len())
+	l, r := splitAt(s.root, i)
+	s.root = l
+	return &Sequence /*[V]*/ {root: r, r: s.r}
+}
+
+// Concat returns a sequence with the values of a followed by the values of
+// b, which are left empty. The returned sequence uses the same source of
+// random numbers as a.
+//
+// It runs in O(log n) expected time complexity. It does not allocate,
+// except for the returned sequence.
+func Concat /*[V algo.Any]*/ (a, b *Sequence /*[V]*/) *Sequence /*[V]*/ {
+	s := &Sequence /*[V]*/ {root: mergeSeq(a.root, b.root), r: a.r}
+	a.root, b.root = nil, nil
+	return s
+}
+
+// Values returns a slice of all values of the sequence, in order.
+//
+// It runs in O(n) time complexity where n is the number of values in the
+// sequence.
+func (s *Sequence /*[V]*/) Values() []V {
+	var vals []V
+	if n := s.Len(); n > 0 {
+		vals = make([]V, 0, n)
+		vals = s.root.appendValues(vals, false)
+	}
+	return vals
+}
+
+// splits the subtree rooted at n in two subtrees, one with its first i
+// values and one with the rest.
+func splitAt /*[V algo.Any]*/ (n *snode /*[V]*/, i int) (l, r *snode /*[V]*/) {
+	if n == nil {
+		return nil, nil
+	}
+	n.push()
+	if n.left.len() >= i {
+		l, n.left = splitAt(n.left, i)
+		n.update()
+		return l, n
+	}
+	n.right, r = splitAt(n.right, i-n.left.len()-1)
+	n.update()
+	return n, r
+}
+
+// merges the subtrees rooted at l and r, the values of l coming before the
+// values of r, and returns the root of the merged subtree.
+func mergeSeq /*[V algo.Any]*/ (l, r *snode /*[V]*/) *snode /*[V]*/ {
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	if l.prio > r.prio {
+		l.push()
+		l.right = mergeSeq(l.right, r)
+		l.update()
+		return l
+	}
+	r.push()
+	r.left = mergeSeq(l, r.left)
+	r.update()
+	return r
+}
+
+func (n *snode /*[V]*/) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *snode /*[V]*/) update() {
+	n.size = n.left.len() + n.right.len() + 1
+}
+
+// applies the pending reversal of n to its children, before they are
+// modified.
+func (n *snode /*[V]*/) push() {
+	if !n.rev {
+		return
+	}
+	n.left, n.right = n.right, n.left
+	if n.left != nil {
+		n.left.rev = !n.left.rev
+	}
+	if n.right != nil {
+		n.right.rev = !n.right.rev
+	}
+	n.rev = false
+}
+
+// appends the values of the subtree rooted at n to vals, in order, or in
+// reverse order if rev is true (taking into account the pending reversals).
+func (n *snode /*[V]*/) appendValues(vals []V, rev bool) []V {
+	if n == nil {
+		return vals
+	}
+	rev = rev != n.rev
+	first, last := n.left, n.right
+	if rev {
+		first, last = last, first
+	}
+	vals = first.appendValues(vals, rev)
+	vals = append(vals, n.val)
+	return last.appendValues(vals, rev)
+}
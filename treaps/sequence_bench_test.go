@@ -0,0 +1,72 @@
+package treaps
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// returns a sequence with the values 0, 1, ..., n-1.
+func benchSequence(n int) *Sequence {
+	vals := make([]V, n)
+	for i := range vals {
+		vals[i] = i
+	}
+	return MakeSequenceFrom(rand.New(rand.NewSource(1)), vals)
+}
+
+func BenchmarkMakeSequenceFrom(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vals := make([]V, n)
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = MakeSequenceFrom(r, vals)
+			}
+		})
+	}
+}
+
+func BenchmarkSequence_At(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := benchSequence(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = s.At(i % n)
+			}
+		})
+	}
+}
+
+func BenchmarkSequence_InsertDelete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := benchSequence(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				j := i % n
+				s.Insert(j, i)
+				_ = s.Delete(j)
+			}
+		})
+	}
+}
+
+func BenchmarkSequence_Reverse(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			s := benchSequence(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// reverse the second half of the sequence
+				s.Reverse(n/2, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package treaps
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestSequence(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var s Sequence
+		if s.Len() != 0 || s.Values() != nil {
+			t.Fatal("want empty sequence")
+		}
+		s.Reverse(0, 0)
+		if rs := s.Split(0); rs.Len() != 0 {
+			t.Fatal("want empty split")
+		}
+		s.Insert(0, 1)
+		if s.Len() != 1 || s.At(0) != 1 {
+			t.Fatal("want single value")
+		}
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		s := MakeSequenceFrom(rand.New(rand.NewSource(1)), []V{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+		checkSequence(t, s, []V{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+		s.Reverse(2, 7)
+		checkSequence(t, s, []V{0, 1, 6, 5, 4, 3, 2, 7, 8, 9})
+		s.Reverse(0, 4)
+		checkSequence(t, s, []V{5, 6, 1, 0, 4, 3, 2, 7, 8, 9})
+		s.Insert(3, 42)
+		checkSequence(t, s, []V{5, 6, 1, 42, 0, 4, 3, 2, 7, 8, 9})
+		if v := s.Delete(0); v != 5 {
+			t.Fatalf("want deleted 5, got %d", v)
+		}
+		checkSequence(t, s, []V{6, 1, 42, 0, 4, 3, 2, 7, 8, 9})
+		s.Set(9, -9)
+		s.Insert(10, 10)
+		checkSequence(t, s, []V{6, 1, 42, 0, 4, 3, 2, 7, 8, -9, 10})
+
+		rs := s.Split(4)
+		checkSequence(t, s, []V{6, 1, 42, 0})
+		checkSequence(t, rs, []V{4, 3, 2, 7, 8, -9, 10})
+		rs.Reverse(0, rs.Len())
+		s = Concat(rs, s)
+		checkSequence(t, s, []V{10, -9, 8, 7, 2, 3, 4, 6, 1, 42, 0})
+		if rs.Len() != 0 {
+			t.Fatal("want concatenated sequence emptied")
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		var ref []V
+		for i := 0; i < 100; i++ {
+			ref = append(ref, i)
+		}
+		s := MakeSequenceFrom(r, ref)
+		for i := 0; i < 5000; i++ {
+			switch op := r.Intn(5); {
+			case op == 0 && len(ref) > 0:
+				j := r.Intn(len(ref))
+				if got := s.Delete(j); got != ref[j] {
+					t.Fatalf("%d: want deleted %d, got %d", j, ref[j], got)
+				}
+				ref = append(ref[:j], ref[j+1:]...)
+			case op == 1:
+				j, v := r.Intn(len(ref)+1), r.Int()
+				s.Insert(j, v)
+				ref = append(ref, 0)
+				copy(ref[j+1:], ref[j:])
+				ref[j] = v
+			case op == 2 && len(ref) > 0:
+				j, v := r.Intn(len(ref)), r.Int()
+				s.Set(j, v)
+				ref[j] = v
+			default:
+				hi := r.Intn(len(ref) + 1)
+				lo := r.Intn(hi + 1)
+				s.Reverse(lo, hi)
+				for a, b := lo, hi-1; a < b; a, b = a+1, b-1 {
+					ref[a], ref[b] = ref[b], ref[a]
+				}
+			}
+			if i%100 == 0 {
+				checkSequence(t, s, ref)
+			}
+		}
+		checkSequence(t, s, ref)
+	})
+}
+
+func TestSequencePanics(t *testing.T) {
+	s := MakeSequenceFrom(nil, []V{1, 2, 3})
+	cases := []struct {
+		desc string
+		fn   func()
+	}{
+		{"At negative", func() { s.At(-1) }},
+		{"At too big", func() { s.At(3) }},
+		{"Set too big", func() { s.Set(3, 0) }},
+		{"Insert too big", func() { s.Insert(4, 0) }},
+		{"Delete too big", func() { s.Delete(3) }},
+		{"Reverse too big", func() { s.Reverse(0, 4) }},
+		{"Reverse inverted", func() { s.Reverse(2, 1) }},
+		{"Split too big", func() { s.Split(4) }},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("want panic")
+				}
+			}()
+			c.fn()
+		})
+	}
+}
+
+// checkSequence checks that s contains the values in want, and that its tree
+// is a valid treap.
+func checkSequence(t *testing.T, s *Sequence, want []V) {
+	t.Helper()
+
+	if s.Len() != len(want) {
+		t.Fatalf("want len %d, got %d", len(want), s.Len())
+	}
+	if diff := cmp.Diff(want, s.Values(), cmpopts.EquateEmpty()); diff != "" {
+		t.Fatal(diff)
+	}
+	for i, v := range want {
+		if got := s.At(i); got != v {
+			t.Fatalf("%d: want %d, got %d", i, v, got)
+		}
+	}
+	checkSNode(t, s.root)
+}
+
+func checkSNode(t *testing.T, n *snode) {
+	t.Helper()
+
+	if n == nil {
+		return
+	}
+	if (n.left != nil && n.left.prio > n.prio) || (n.right != nil && n.right.prio > n.prio) {
+		t.Fatal("priority not a heap")
+	}
+	if n.size != n.left.len()+n.right.len()+1 {
+		t.Fatalf("want size %d, got %d", n.left.len()+n.right.len()+1, n.size)
+	}
+	checkSNode(t, n.left)
+	checkSNode(t, n.right)
+}
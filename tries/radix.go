@@ -0,0 +1,256 @@
+package tries
+
+import "sort"
+
+// Radix is a map of keys to values backed by a radix tree (or compressed
+// trie), a trie where the nodes that have a single child and no key are
+// merged with their child, so that each edge is labeled with a sequence of
+// bytes instead of a single byte. Its keys are sequences of bytes, i.e.
+// strings or byte slices, and they are iterated in lexicographical order of
+// bytes. Its zero-value is ready to use.
+//
+// Operations on a key run in O(k) time complexity where k is the length of
+// the key, regardless of the number of keys in the tree, and it uses at most
+// 2n nodes for n keys, regardless of their length.
+type Radix /*[K algo.Bytes, V algo.Any]*/ struct {
+	root radixNode /*[K, V]*/
+	len  int
+}
+
+type radixNode /*[K algo.Bytes, V algo.Any]*/ struct {
+	prefix   K            // label of the edge from the parent
+	children []*radixNode /*[K, V]*/ // sorted by the first byte of their prefix
+	key      K            // set if ok
+	val      V            // set if ok
+	ok       bool         // a key ends at this node
+}
+
+// MakeRadix returns a radix tree of some key and value types.
+func MakeRadix /*[K algo.Bytes, V algo.Any]*/ () *Radix /*[K, V]*/ {
+	return &Radix /*[K, V]*/ {}
+}
+
+// Len returns the number of keys in the radix tree r.
+func (r *Radix /*[K, V]*/) Len() int {
+	return r.len
+}
+
+// Get returns the value associated with k and true, or the zero value of V
+// and false if k is not in the radix tree.
+//
+// It runs in O(k) time complexity where k is the length of the key. It does
+// not allocate.
+func (r *Radix /*[K, V]*/) Get(k K) (V, bool) {
+	n, search := &r.root, k
+	for len(search) > 0 {
+		_, c := n.child(search[0])
+		if c == nil || commonPrefix(search, c.prefix) < len(c.prefix) {
+			var zero V
+			return zero, false
+		}
+		n, search = c, search[len(c.prefix):]
+	}
+	return n.val, n.ok
+}
+
+// Contains reports whether k is in the radix tree r.
+//
+// It runs in O(k) time complexity where k is the length of the key. It does
+// not allocate.
+func (r *Radix /*[K, V]*/) Contains(k K) bool {
+	_, ok := r.Get(k)
+	return ok
+}
+
+// Insert associates the value v with the key k in the radix tree r,
+// replacing the previous value if k is already in r.
+//
+// It runs in O(k) time complexity where k is the length of the key.
+func (r *Radix /*[K, V]*/) Insert(k K, v V) {
+	n, search := &r.root, k
+	for len(search) > 0 {
+		i, c := n.child(search[0])
+		if c == nil {
+			// add a leaf, its prefix is the end of its key
+			key := cloneKey(k)
+			leaf := &radixNode /*[K, V]*/ {prefix: key[len(k)-len(search):], key: key, val: v, ok: true}
+			n.children = append(n.children, nil)
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = leaf
+			r.len++
+			return
+		}
+
+		l := commonPrefix(search, c.prefix)
+		if l < len(c.prefix) {
+			// split the edge to the child where the prefix diverges
+			mid := &radixNode /*[K, V]*/ {prefix: c.prefix[:l], children: []*radixNode /*[K, V]*/ {c}}
+			c.prefix = c.prefix[l:]
+			n.children[i] = mid
+			c = mid
+		}
+		n, search = c, search[l:]
+	}
+
+	if !n.ok {
+		n.key = cloneKey(k)
+		n.ok = true
+		r.len++
+	}
+	n.val = v
+}
+
+// Delete removes the key k and its associated value from the radix tree r
+// and returns true, or false if k is not in r. The nodes that no longer lead
+// to a key are removed, and those that have a single child and no key are
+// merged with their child.
+//
+// It runs in O(k) time complexity where k is the length of the key.
+func (r *Radix /*[K, V]*/) Delete(k K) bool {
+	if !r.root.remove(k) {
+		return false
+	}
+	r.len--
+	return true
+}
+
+// removes the key that ends with search from the subtree rooted at n, and
+// returns true if it was found.
+func (n *radixNode /*[K, V]*/) remove(search K) bool {
+	if len(search) == 0 {
+		if !n.ok {
+			return false
+		}
+		var zk K
+		var zv V
+		n.key, n.val, n.ok = zk, zv, false
+		return true
+	}
+
+	i, c := n.child(search[0])
+	if c == nil || commonPrefix(search, c.prefix) < len(c.prefix) || !c.remove(search[len(c.prefix):]) {
+		return false
+	}
+
+	// compact the child if it has no key anymore
+	if !c.ok {
+		switch len(c.children) {
+		case 0:
+			copy(n.children[i:], n.children[i+1:])
+			n.children[len(n.children)-1] = nil
+			n.children = n.children[:len(n.children)-1]
+		case 1:
+			gc := c.children[0]
+			prefix := append(append([]byte(nil), c.prefix...), gc.prefix...)
+			gc.prefix = K(prefix)
+			n.children[i] = gc
+		}
+	}
+	return true
+}
+
+// LongestPrefix returns the longest key of the radix tree that is a prefix
+// of k and its value, and true, or the zero values and false if there is no
+// such key.
+//
+// It runs in O(k) time complexity where k is the length of the key. It does
+// not allocate.
+func (r *Radix /*[K, V]*/) LongestPrefix(k K) (K, V, bool) {
+	var best *radixNode /*[K, V]*/
+	n, search := &r.root, k
+	for {
+		if n.ok {
+			best = n
+		}
+		if len(search) == 0 {
+			break
+		}
+		_, c := n.child(search[0])
+		if c == nil || commonPrefix(search, c.prefix) < len(c.prefix) {
+			break
+		}
+		n, search = c, search[len(c.prefix):]
+	}
+	if best == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return best.key, best.val, true
+}
+
+// WalkPrefix calls fn for each key of the radix tree that starts with
+// prefix and its value, in lexicographical order of keys, until all those
+// keys have been visited or fn returns false. The radix tree must not be
+// modified during the iteration.
+//
+// It runs in O(p + m) time complexity where p is the length of the prefix
+// and m the number of keys visited.
+func (r *Radix /*[K, V]*/) WalkPrefix(prefix K, fn func(K, V) bool) {
+	n, search := &r.root, prefix
+	for len(search) > 0 {
+		_, c := n.child(search[0])
+		if c == nil {
+			return
+		}
+		l := commonPrefix(search, c.prefix)
+		if l == len(search) {
+			// the prefix ends within the label of the child, all keys under the
+			// child start with the prefix.
+			c.walk(fn)
+			return
+		}
+		if l < len(c.prefix) {
+			return
+		}
+		n, search = c, search[l:]
+	}
+	n.walk(fn)
+}
+
+// Walk calls fn for each key of the radix tree and its value, in
+// lexicographical order of keys, until all keys have been visited or fn
+// returns false. The radix tree must not be modified during the iteration.
+//
+// It runs in O(n) time complexity where n is the number of keys.
+func (r *Radix /*[K, V]*/) Walk(fn func(K, V) bool) {
+	r.root.walk(fn)
+}
+
+// Keys returns a slice of all keys of the radix tree, in lexicographical
+// order.
+//
+// It runs in O(n) time complexity where n is the number of keys.
+func (r *Radix /*[K, V]*/) Keys() []K {
+	var keys []K
+	if r.len > 0 {
+		keys = make([]K, 0, r.len)
+		r.Walk(func(k K, _ V) bool {
+			keys = append(keys, k)
+			return true
+		})
+	}
+	return keys
+}
+
+// returns the index of the child of n whose prefix starts with byte c and
+// the child, or the index where it would be inserted and nil.
+func (n *radixNode /*[K, V]*/) child(c byte) (int, *radixNode /*[K, V]*/) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].prefix[0] >= c })
+	if i < len(n.children) && n.children[i].prefix[0] == c {
+		return i, n.children[i]
+	}
+	return i, nil
+}
+
+func (n *radixNode /*[K, V]*/) walk(fn func(K, V) bool) bool {
+	if n.ok && !fn(n.key, n.val) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(fn) {
+			return false
+		}
+	}
+	return true
+}
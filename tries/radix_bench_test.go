@@ -0,0 +1,80 @@
+package tries
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkRadix_InsertDelete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			keys := benchKeys(2 * n)
+			r := MakeRadix()
+			for _, k := range keys[:n] {
+				r.Insert(k, 0)
+			}
+			others := keys[n:]
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				k := others[i%n]
+				r.Insert(k, i)
+				r.Delete(k)
+			}
+		})
+	}
+}
+
+func BenchmarkRadix_Get(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			keys := benchKeys(n)
+			r := MakeRadix()
+			for i, k := range keys {
+				r.Insert(k, i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, ok := r.Get(keys[i%n]); !ok {
+					b.Fatal("Get returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRadix_LongestPrefix(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			keys := benchKeys(n)
+			r := MakeRadix()
+			for i, k := range keys {
+				r.Insert(k[:8], i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, _, ok := r.LongestPrefix(keys[i%n]); !ok {
+					b.Fatal("LongestPrefix returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRadix_Walk(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			r := MakeRadix()
+			for i, k := range benchKeys(n) {
+				r.Insert(k, i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				r.Walk(func(K, V) bool { return true })
+			}
+		})
+	}
+}
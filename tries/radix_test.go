@@ -0,0 +1,132 @@
+package tries
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRadix(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var r Radix
+		if r.Len() != 0 || r.Keys() != nil {
+			t.Fatal("want empty radix tree")
+		}
+		if _, ok := r.Get("a"); ok {
+			t.Fatal("want no value")
+		}
+		if _, _, ok := r.LongestPrefix("a"); ok {
+			t.Fatal("want no longest prefix")
+		}
+		if r.Delete("a") {
+			t.Fatal("want no delete")
+		}
+		r.Insert("a", 1)
+		if v, ok := r.Get("a"); !ok || v != 1 || r.Len() != 1 {
+			t.Fatal("want single key")
+		}
+	})
+
+	t.Run("SplitMerge", func(t *testing.T) {
+		r := MakeRadix()
+		r.Insert("romane", 1)
+		r.Insert("romanus", 2)
+		r.Insert("romulus", 3)
+		checkRadix(t, r, map[K]V{"romane": 1, "romanus": 2, "romulus": 3})
+		if len(r.root.children) != 1 || r.root.children[0].prefix != "rom" {
+			t.Fatal("want single edge from the root labeled rom")
+		}
+
+		r.Delete("romulus")
+		checkRadix(t, r, map[K]V{"romane": 1, "romanus": 2})
+		if len(r.root.children) != 1 || r.root.children[0].prefix != "roman" {
+			t.Fatal("want single edge from the root labeled roman")
+		}
+
+		r.Delete("romane")
+		checkRadix(t, r, map[K]V{"romanus": 2})
+		if len(r.root.children) != 1 || r.root.children[0].prefix != "romanus" {
+			t.Fatal("want single edge from the root labeled romanus")
+		}
+	})
+
+	t.Run("InsertDeleteRandom", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		rnd := rand.New(rand.NewSource(seed))
+
+		r := MakeRadix()
+		ref := make(map[K]V)
+		for i := 0; i < 10000; i++ {
+			k := randomKey(rnd)
+			switch rnd.Intn(4) {
+			case 0:
+				_, want := ref[k]
+				if got := r.Delete(k); got != want {
+					t.Fatalf("%q: want deleted %t, got %t", k, want, got)
+				}
+				delete(ref, k)
+			case 1:
+				_, want := ref[k]
+				if got := r.Contains(k); got != want {
+					t.Fatalf("%q: want contains %t, got %t", k, want, got)
+				}
+			default:
+				v := rnd.Int()
+				r.Insert(k, v)
+				ref[k] = v
+			}
+			if i%100 == 0 {
+				checkRadix(t, r, ref)
+			}
+		}
+		checkRadix(t, r, ref)
+
+		// deleting all keys removes all nodes
+		for k := range ref {
+			r.Delete(k)
+		}
+		if r.Len() != 0 || len(r.root.children) != 0 {
+			t.Fatal("want empty radix tree after deleting all keys")
+		}
+	})
+}
+
+func TestRadixPrefixes(t *testing.T) {
+	r := MakeRadix()
+	for i, k := range prefixKeys {
+		r.Insert(k, i)
+	}
+	testPrefixes(t, r.LongestPrefix, r.WalkPrefix)
+}
+
+// checkRadix checks that r contains the same keys and values as ref, and
+// that it is compressed: no node other than the root has an empty prefix,
+// and no node without a key has less than two children.
+func checkRadix(t *testing.T, r *Radix, ref map[K]V) {
+	t.Helper()
+
+	checkRef(t, r.Len(), r.Keys(), r.Get, ref)
+
+	var checkNode func(n *radixNode, path K)
+	checkNode = func(n *radixNode, path K) {
+		if n != &r.root {
+			if len(n.prefix) == 0 {
+				t.Fatalf("%q: want non-empty prefix", path)
+			}
+			if !n.ok && len(n.children) < 2 {
+				t.Fatalf("%q: want node without key to have at least 2 children, got %d", path, len(n.children))
+			}
+		}
+		if n.ok && n.key != path {
+			t.Fatalf("want key %q, got %q", path, n.key)
+		}
+		for i, c := range n.children {
+			if i > 0 && n.children[i-1].prefix[0] >= c.prefix[0] {
+				t.Fatalf("%q: want children sorted by first byte", path)
+			}
+			checkNode(c, path+c.prefix)
+		}
+	}
+	checkNode(&r.root, "")
+}
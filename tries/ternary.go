@@ -0,0 +1,293 @@
+package tries
+
+// Ternary is a map of keys to values backed by a ternary search tree, a
+// trie where the children of each node are stored in a binary search tree
+// instead of an array or a map. Each node holds a byte and three children:
+// the nodes for smaller and larger bytes at the same position, and the node
+// for the next byte of the keys. It uses less memory than a Trie, as each
+// node only stores three pointers, at the cost of slower lookups. Its keys
+// are sequences of bytes, i.e. strings or byte slices, and they are iterated
+// in lexicographical order of bytes. Its zero-value is ready to use.
+type Ternary /*[K algo.Bytes, V algo.Any]*/ struct {
+	root *ternaryNode /*[K, V]*/
+	len  int
+
+	// the empty key cannot be stored in a node, as each node holds a byte
+	empty    V
+	hasEmpty bool
+}
+
+type ternaryNode /*[K algo.Bytes, V algo.Any]*/ struct {
+	c          byte
+	lo, eq, hi *ternaryNode /*[K, V]*/
+	key        K            // set if ok
+	val        V            // set if ok
+	ok         bool         // a key ends at this node
+}
+
+// MakeTernary returns a ternary search tree of some key and value types.
+func MakeTernary /*[K algo.Bytes, V algo.Any]*/ () *Ternary /*[K, V]*/ {
+	return &Ternary /*[K, V]*/ {}
+}
+
+// Len returns the number of keys in the ternary search tree t.
+func (t *Ternary /*[K, V]*/) Len() int {
+	return t.len
+}
+
+// returns the node of the last byte of k, which must not be empty, or nil.
+func (t *Ternary /*[K, V]*/) find(k K) *ternaryNode /*[K, V]*/ {
+	n, i := t.root, 0
+	for n != nil {
+		switch c := k[i]; {
+		case c < n.c:
+			n = n.lo
+		case c > n.c:
+			n = n.hi
+		default:
+			if i == len(k)-1 {
+				return n
+			}
+			n = n.eq
+			i++
+		}
+	}
+	return nil
+}
+
+// Get returns the value associated with k and true, or the zero value of V
+// and false if k is not in the ternary search tree.
+//
+// It runs in O(k + log n) expected time complexity where k is the length of
+// the key, if the keys are inserted in random order. It does not allocate.
+func (t *Ternary /*[K, V]*/) Get(k K) (V, bool) {
+	if len(k) == 0 {
+		return t.empty, t.hasEmpty
+	}
+	if n := t.find(k); n != nil && n.ok {
+		return n.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether k is in the ternary search tree t.
+//
+// It runs in O(k + log n) expected time complexity where k is the length of
+// the key, if the keys are inserted in random order. It does not allocate.
+func (t *Ternary /*[K, V]*/) Contains(k K) bool {
+	_, ok := t.Get(k)
+	return ok
+}
+
+// Insert associates the value v with the key k in the ternary search tree
+// t, replacing the previous value if k is already in t.
+//
+// It runs in O(k + log n) expected time complexity where k is the length of
+// the key, if the keys are inserted in random order.
+func (t *Ternary /*[K, V]*/) Insert(k K, v V) {
+	if len(k) == 0 {
+		if !t.hasEmpty {
+			t.len++
+		}
+		t.empty, t.hasEmpty = v, true
+		return
+	}
+
+	p, i := &t.root, 0
+	for {
+		n := *p
+		if n == nil {
+			n = &ternaryNode /*[K, V]*/ {c: k[i]}
+			*p = n
+		}
+		switch c := k[i]; {
+		case c < n.c:
+			p = &n.lo
+		case c > n.c:
+			p = &n.hi
+		default:
+			if i == len(k)-1 {
+				if !n.ok {
+					n.key = cloneKey(k)
+					n.ok = true
+					t.len++
+				}
+				n.val = v
+				return
+			}
+			p = &n.eq
+			i++
+		}
+	}
+}
+
+// Delete removes the key k and its associated value from the ternary search
+// tree t and returns true, or false if k is not in t. The nodes that no
+// longer lead to a key are removed.
+//
+// It runs in O(k + log n) expected time complexity where k is the length of
+// the key, if the keys are inserted in random order. It does not allocate.
+func (t *Ternary /*[K, V]*/) Delete(k K) bool {
+	if len(k) == 0 {
+		if !t.hasEmpty {
+			return false
+		}
+		var zero V
+		t.empty, t.hasEmpty = zero, false
+		t.len--
+		return true
+	}
+
+	var ok bool
+	t.root, ok = t.root.remove(k, 0)
+	if ok {
+		t.len--
+	}
+	return ok
+}
+
+// removes k from the subtree rooted at n, where the byte i of k is compared,
+// and returns the new root of the subtree and true if k was found.
+func (n *ternaryNode /*[K, V]*/) remove(k K, i int) (*ternaryNode /*[K, V]*/, bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var ok bool
+	switch c := k[i]; {
+	case c < n.c:
+		n.lo, ok = n.lo.remove(k, i)
+	case c > n.c:
+		n.hi, ok = n.hi.remove(k, i)
+	case i == len(k)-1:
+		if ok = n.ok; ok {
+			var zk K
+			var zv V
+			n.key, n.val, n.ok = zk, zv, false
+		}
+	default:
+		n.eq, ok = n.eq.remove(k, i+1)
+	}
+	if !ok || n.ok || n.eq != nil {
+		return n, ok
+	}
+
+	// the node no longer leads to a key, remove it from the binary search
+	// tree of its byte position.
+	switch {
+	case n.lo == nil:
+		return n.hi, true
+	case n.hi == nil:
+		return n.lo, true
+	}
+	// replace it with the largest node of its lo subtree
+	p := &n.lo
+	for (*p).hi != nil {
+		p = &(*p).hi
+	}
+	m := *p
+	*p = m.lo
+	m.lo, m.hi = n.lo, n.hi
+	return m, true
+}
+
+// LongestPrefix returns the longest key of the ternary search tree that is
+// a prefix of k and its value, and true, or the zero values and false if
+// there is no such key.
+//
+// It runs in O(k + log n) expected time complexity where k is the length of
+// the key, if the keys are inserted in random order. It does not allocate.
+func (t *Ternary /*[K, V]*/) LongestPrefix(k K) (K, V, bool) {
+	var best *ternaryNode /*[K, V]*/
+	n, i := t.root, 0
+	for n != nil && i < len(k) {
+		switch c := k[i]; {
+		case c < n.c:
+			n = n.lo
+		case c > n.c:
+			n = n.hi
+		default:
+			if n.ok {
+				best = n
+			}
+			n = n.eq
+			i++
+		}
+	}
+
+	if best == nil {
+		var zk K
+		if t.hasEmpty {
+			return zk, t.empty, true
+		}
+		var zv V
+		return zk, zv, false
+	}
+	return best.key, best.val, true
+}
+
+// WalkPrefix calls fn for each key of the ternary search tree that starts
+// with prefix and its value, in lexicographical order of keys, until all
+// those keys have been visited or fn returns false. The ternary search tree
+// must not be modified during the iteration.
+//
+// It runs in O(p + log n + m) expected time complexity where p is the length
+// of the prefix and m the number of nodes under the prefix.
+func (t *Ternary /*[K, V]*/) WalkPrefix(prefix K, fn func(K, V) bool) {
+	if len(prefix) == 0 {
+		t.Walk(fn)
+		return
+	}
+	if n := t.find(prefix); n != nil {
+		if n.ok && !fn(n.key, n.val) {
+			return
+		}
+		n.eq.walk(fn)
+	}
+}
+
+// Walk calls fn for each key of the ternary search tree and its value, in
+// lexicographical order of keys, until all keys have been visited or fn
+// returns false. The ternary search tree must not be modified during the
+// iteration.
+//
+// It runs in O(m) time complexity where m is the number of nodes.
+func (t *Ternary /*[K, V]*/) Walk(fn func(K, V) bool) {
+	if t.hasEmpty {
+		var zk K
+		if !fn(zk, t.empty) {
+			return
+		}
+	}
+	t.root.walk(fn)
+}
+
+// Keys returns a slice of all keys of the ternary search tree, in
+// lexicographical order.
+//
+// It runs in O(m) time complexity where m is the number of nodes.
+func (t *Ternary /*[K, V]*/) Keys() []K {
+	var keys []K
+	if t.len > 0 {
+		keys = make([]K, 0, t.len)
+		t.Walk(func(k K, _ V) bool {
+			keys = append(keys, k)
+			return true
+		})
+	}
+	return keys
+}
+
+// visits the keys of the subtree rooted at n in order: the keys with a
+// smaller byte, the key that ends at n, the keys that continue after n, and
+// the keys with a larger byte.
+func (n *ternaryNode /*[K, V]*/) walk(fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.lo.walk(fn) &&
+		(!n.ok || fn(n.key, n.val)) &&
+		n.eq.walk(fn) &&
+		n.hi.walk(fn)
+}
@@ -0,0 +1,80 @@
+package tries
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkTernary_InsertDelete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			keys := benchKeys(2 * n)
+			tt := MakeTernary()
+			for _, k := range keys[:n] {
+				tt.Insert(k, 0)
+			}
+			others := keys[n:]
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				k := others[i%n]
+				tt.Insert(k, i)
+				tt.Delete(k)
+			}
+		})
+	}
+}
+
+func BenchmarkTernary_Get(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			keys := benchKeys(n)
+			tt := MakeTernary()
+			for i, k := range keys {
+				tt.Insert(k, i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, ok := tt.Get(keys[i%n]); !ok {
+					b.Fatal("Get returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTernary_LongestPrefix(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			keys := benchKeys(n)
+			tt := MakeTernary()
+			for i, k := range keys {
+				tt.Insert(k[:8], i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, _, ok := tt.LongestPrefix(keys[i%n]); !ok {
+					b.Fatal("LongestPrefix returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTernary_Walk(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			tt := MakeTernary()
+			for i, k := range benchKeys(n) {
+				tt.Insert(k, i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				tt.Walk(func(K, V) bool { return true })
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package tries
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestTernary(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var tt Ternary
+		if tt.Len() != 0 || tt.Keys() != nil {
+			t.Fatal("want empty ternary search tree")
+		}
+		if _, ok := tt.Get("a"); ok {
+			t.Fatal("want no value")
+		}
+		if _, ok := tt.Get(""); ok {
+			t.Fatal("want no value for the empty key")
+		}
+		if _, _, ok := tt.LongestPrefix("a"); ok {
+			t.Fatal("want no longest prefix")
+		}
+		if tt.Delete("a") || tt.Delete("") {
+			t.Fatal("want no delete")
+		}
+		tt.Insert("a", 1)
+		if v, ok := tt.Get("a"); !ok || v != 1 || tt.Len() != 1 {
+			t.Fatal("want single key")
+		}
+	})
+
+	t.Run("EmptyKey", func(t *testing.T) {
+		tt := MakeTernary()
+		tt.Insert("", 1)
+		tt.Insert("", 2)
+		checkTernary(t, tt, map[K]V{"": 2})
+		if k, v, ok := tt.LongestPrefix("abc"); !ok || k != "" || v != 2 {
+			t.Fatalf("want empty key as longest prefix, got %q (%t)", k, ok)
+		}
+		if !tt.Delete("") {
+			t.Fatal("want empty key deleted")
+		}
+		checkTernary(t, tt, map[K]V{})
+	})
+
+	t.Run("InsertDeleteRandom", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		tt := MakeTernary()
+		ref := make(map[K]V)
+		for i := 0; i < 10000; i++ {
+			k := randomKey(r)
+			switch r.Intn(4) {
+			case 0:
+				_, want := ref[k]
+				if got := tt.Delete(k); got != want {
+					t.Fatalf("%q: want deleted %t, got %t", k, want, got)
+				}
+				delete(ref, k)
+			case 1:
+				_, want := ref[k]
+				if got := tt.Contains(k); got != want {
+					t.Fatalf("%q: want contains %t, got %t", k, want, got)
+				}
+			default:
+				v := r.Int()
+				tt.Insert(k, v)
+				ref[k] = v
+			}
+			if i%100 == 0 {
+				checkTernary(t, tt, ref)
+			}
+		}
+		checkTernary(t, tt, ref)
+
+		// deleting all keys removes all nodes
+		for k := range ref {
+			tt.Delete(k)
+		}
+		if tt.Len() != 0 || tt.root != nil {
+			t.Fatal("want empty ternary search tree after deleting all keys")
+		}
+	})
+}
+
+func TestTernaryPrefixes(t *testing.T) {
+	tt := MakeTernary()
+	for i, k := range prefixKeys {
+		tt.Insert(k, i)
+	}
+	testPrefixes(t, tt.LongestPrefix, tt.WalkPrefix)
+}
+
+// checkTernary checks that tt contains the same keys and values as ref, that
+// the bytes of each binary search tree are ordered, and that it has no node
+// that does not lead to a key.
+func checkTernary(t *testing.T, tt *Ternary, ref map[K]V) {
+	t.Helper()
+
+	checkRef(t, tt.Len(), tt.Keys(), tt.Get, ref)
+
+	// checks the node n, whose byte must be in (lo, hi), and returns true if
+	// it leads to a key.
+	var checkNode func(n *ternaryNode, path K, lo, hi int) bool
+	checkNode = func(n *ternaryNode, path K, lo, hi int) bool {
+		if n == nil {
+			return false
+		}
+		if int(n.c) <= lo || int(n.c) >= hi {
+			t.Fatalf("%q: byte %q out of order", path, n.c)
+		}
+		k := path + K(n.c)
+		if n.ok && n.key != k {
+			t.Fatalf("want key %q, got %q", k, n.key)
+		}
+		eq := checkNode(n.eq, k, -1, 256)
+		if !n.ok && !eq {
+			t.Fatalf("%q: want node to lead to a key", k)
+		}
+		checkNode(n.lo, path, lo, int(n.c))
+		checkNode(n.hi, path, int(n.c), hi)
+		return true
+	}
+	checkNode(tt.root, "", -1, 256)
+}
@@ -0,0 +1,221 @@
+package tries
+
+import "sort"
+
+// Trie is a map of keys to values backed by a trie (or prefix tree), where
+// each node represents a byte of the keys, so that the keys that share a
+// prefix share the nodes of that prefix. Its keys are sequences of bytes,
+// i.e. strings or byte slices, and they are iterated in lexicographical
+// order of bytes. Its zero-value is ready to use.
+//
+// Operations on a key run in O(k) time complexity where k is the length of
+// the key, regardless of the number of keys in the trie. See Radix for a
+// compressed trie that uses less memory with long keys.
+type Trie /*[K algo.Bytes, V algo.Any]*/ struct {
+	root trieNode /*[K, V]*/
+	len  int
+}
+
+type trieNode /*[K algo.Bytes, V algo.Any]*/ struct {
+	labels   []byte      // bytes of the children, sorted
+	children []*trieNode /*[K, V]*/ // child of each byte of labels
+	key      K           // set if ok
+	val      V           // set if ok
+	ok       bool        // a key ends at this node
+}
+
+// MakeTrie returns a trie of some key and value types.
+func MakeTrie /*[K algo.Bytes, V algo.Any]*/ () *Trie /*[K, V]*/ {
+	return &Trie /*[K, V]*/ {}
+}
+
+// Len returns the number of keys in the trie t.
+func (t *Trie /*[K, V]*/) Len() int {
+	return t.len
+}
+
+// returns the node of the key k, or nil.
+func (t *Trie /*[K, V]*/) find(k K) *trieNode /*[K, V]*/ {
+	n := &t.root
+	for i := 0; i < len(k) && n != nil; i++ {
+		_, n = n.child(k[i])
+	}
+	return n
+}
+
+// Get returns the value associated with k and true, or the zero value of V
+// and false if k is not in the trie.
+//
+// It runs in O(k log a) time complexity where k is the length of the key and
+// a the size of the alphabet. It does not allocate.
+func (t *Trie /*[K, V]*/) Get(k K) (V, bool) {
+	if n := t.find(k); n != nil && n.ok {
+		return n.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether k is in the trie t.
+//
+// It runs in O(k log a) time complexity where k is the length of the key and
+// a the size of the alphabet. It does not allocate.
+func (t *Trie /*[K, V]*/) Contains(k K) bool {
+	_, ok := t.Get(k)
+	return ok
+}
+
+// Insert associates the value v with the key k in the trie t, replacing the
+// previous value if k is already in t.
+//
+// It runs in O(k log a) time complexity where k is the length of the key and
+// a the size of the alphabet.
+func (t *Trie /*[K, V]*/) Insert(k K, v V) {
+	n := &t.root
+	for i := 0; i < len(k); i++ {
+		j, c := n.child(k[i])
+		if c == nil {
+			c = &trieNode /*[K, V]*/ {}
+			n.labels = append(n.labels, 0)
+			copy(n.labels[j+1:], n.labels[j:])
+			n.labels[j] = k[i]
+			n.children = append(n.children, nil)
+			copy(n.children[j+1:], n.children[j:])
+			n.children[j] = c
+		}
+		n = c
+	}
+	if !n.ok {
+		n.key = cloneKey(k)
+		n.ok = true
+		t.len++
+	}
+	n.val = v
+}
+
+// Delete removes the key k and its associated value from the trie t and
+// returns true, or false if k is not in t. The nodes that no longer lead to
+// a key are removed.
+//
+// It runs in O(k log a) time complexity where k is the length of the key and
+// a the size of the alphabet. It does not allocate.
+func (t *Trie /*[K, V]*/) Delete(k K) bool {
+	if !t.root.remove(k, 0) {
+		return false
+	}
+	t.len--
+	return true
+}
+
+// removes k from the subtree rooted at n, which is reached by the first i
+// bytes of k, and returns true if it was found.
+func (n *trieNode /*[K, V]*/) remove(k K, i int) bool {
+	if i == len(k) {
+		if !n.ok {
+			return false
+		}
+		var zk K
+		var zv V
+		n.key, n.val, n.ok = zk, zv, false
+		return true
+	}
+
+	j, c := n.child(k[i])
+	if c == nil || !c.remove(k, i+1) {
+		return false
+	}
+	if !c.ok && len(c.children) == 0 {
+		copy(n.labels[j:], n.labels[j+1:])
+		n.labels = n.labels[:len(n.labels)-1]
+		copy(n.children[j:], n.children[j+1:])
+		n.children[len(n.children)-1] = nil
+		n.children = n.children[:len(n.children)-1]
+	}
+	return true
+}
+
+// LongestPrefix returns the longest key of the trie that is a prefix of k
+// and its value, and true, or the zero values and false if there is no such
+// key.
+//
+// It runs in O(k log a) time complexity where k is the length of the key and
+// a the size of the alphabet. It does not allocate.
+func (t *Trie /*[K, V]*/) LongestPrefix(k K) (K, V, bool) {
+	var best *trieNode /*[K, V]*/
+	n := &t.root
+	for i := 0; n != nil; i++ {
+		if n.ok {
+			best = n
+		}
+		if i == len(k) {
+			break
+		}
+		_, n = n.child(k[i])
+	}
+	if best == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return best.key, best.val, true
+}
+
+// WalkPrefix calls fn for each key of the trie that starts with prefix and
+// its value, in lexicographical order of keys, until all those keys have
+// been visited or fn returns false. The trie must not be modified during the
+// iteration.
+//
+// It runs in O(p log a + m) time complexity where p is the length of the
+// prefix, a the size of the alphabet and m the number of nodes under the
+// prefix.
+func (t *Trie /*[K, V]*/) WalkPrefix(prefix K, fn func(K, V) bool) {
+	if n := t.find(prefix); n != nil {
+		n.walk(fn)
+	}
+}
+
+// Walk calls fn for each key of the trie and its value, in lexicographical
+// order of keys, until all keys have been visited or fn returns false. The
+// trie must not be modified during the iteration.
+//
+// It runs in O(m) time complexity where m is the number of nodes.
+func (t *Trie /*[K, V]*/) Walk(fn func(K, V) bool) {
+	t.root.walk(fn)
+}
+
+// Keys returns a slice of all keys of the trie, in lexicographical order.
+//
+// It runs in O(m) time complexity where m is the number of nodes.
+func (t *Trie /*[K, V]*/) Keys() []K {
+	var keys []K
+	if t.len > 0 {
+		keys = make([]K, 0, t.len)
+		t.Walk(func(k K, _ V) bool {
+			keys = append(keys, k)
+			return true
+		})
+	}
+	return keys
+}
+
+// returns the index of the child of n for byte c and the child, or the index
+// where it would be inserted and nil.
+func (n *trieNode /*[K, V]*/) child(c byte) (int, *trieNode /*[K, V]*/) {
+	i := sort.Search(len(n.labels), func(i int) bool { return n.labels[i] >= c })
+	if i < len(n.labels) && n.labels[i] == c {
+		return i, n.children[i]
+	}
+	return i, nil
+}
+
+func (n *trieNode /*[K, V]*/) walk(fn func(K, V) bool) bool {
+	if n.ok && !fn(n.key, n.val) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(fn) {
+			return false
+		}
+	}
+	return true
+}
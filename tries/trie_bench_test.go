@@ -0,0 +1,117 @@
+package tries
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// returns n distinct random keys of 16 hexadecimal bytes.
+func benchKeys(n int) []K {
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[K]bool, n)
+	keys := make([]K, 0, n)
+	for len(keys) < n {
+		k := K(fmt.Sprintf("%016x", r.Uint64()))
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func BenchmarkTrie_InsertDelete(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			keys := benchKeys(2 * n)
+			t := MakeTrie()
+			for _, k := range keys[:n] {
+				t.Insert(k, 0)
+			}
+			others := keys[n:]
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				k := others[i%n]
+				t.Insert(k, i)
+				t.Delete(k)
+			}
+		})
+	}
+}
+
+func BenchmarkTrie_Get(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			keys := benchKeys(n)
+			t := MakeTrie()
+			for i, k := range keys {
+				t.Insert(k, i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, ok := t.Get(keys[i%n]); !ok {
+					b.Fatal("Get returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTrie_LongestPrefix(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			keys := benchKeys(n)
+			t := MakeTrie()
+			for i, k := range keys {
+				t.Insert(k[:8], i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, _, ok := t.LongestPrefix(keys[i%n]); !ok {
+					b.Fatal("LongestPrefix returned false")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTrie_Walk(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			t := MakeTrie()
+			for i, k := range benchKeys(n) {
+				t.Insert(k, i)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				t.Walk(func(K, V) bool { return true })
+			}
+		})
+	}
+}
+
+// for comparison with a built-in map, which does not support prefix queries
+// nor sorted iteration.
+func BenchmarkMap_Get(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			keys := benchKeys(n)
+			m := make(map[K]V, n)
+			for i, k := range keys {
+				m[k] = i
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, ok := m[keys[i%n]]; !ok {
+					b.Fatal("Get returned false")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package tries
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestTrie(t *testing.T) {
+	t.Run("ZeroValue", func(t *testing.T) {
+		var tr Trie
+		if tr.Len() != 0 || tr.Keys() != nil {
+			t.Fatal("want empty trie")
+		}
+		if _, ok := tr.Get("a"); ok {
+			t.Fatal("want no value")
+		}
+		if _, _, ok := tr.LongestPrefix("a"); ok {
+			t.Fatal("want no longest prefix")
+		}
+		if tr.Delete("a") {
+			t.Fatal("want no delete")
+		}
+		tr.Insert("a", 1)
+		if v, ok := tr.Get("a"); !ok || v != 1 || tr.Len() != 1 {
+			t.Fatal("want single key")
+		}
+	})
+
+	t.Run("InsertDeleteRandom", func(t *testing.T) {
+		seed := time.Now().UnixNano()
+		t.Logf("random seed: %d", seed)
+		r := rand.New(rand.NewSource(seed))
+
+		tr := MakeTrie()
+		ref := make(map[K]V)
+		for i := 0; i < 10000; i++ {
+			k := randomKey(r)
+			switch r.Intn(4) {
+			case 0:
+				_, want := ref[k]
+				if got := tr.Delete(k); got != want {
+					t.Fatalf("%q: want deleted %t, got %t", k, want, got)
+				}
+				delete(ref, k)
+			case 1:
+				_, want := ref[k]
+				if got := tr.Contains(k); got != want {
+					t.Fatalf("%q: want contains %t, got %t", k, want, got)
+				}
+			default:
+				v := r.Int()
+				tr.Insert(k, v)
+				ref[k] = v
+			}
+			if i%100 == 0 {
+				checkTrie(t, tr, ref)
+			}
+		}
+		checkTrie(t, tr, ref)
+
+		// deleting all keys removes all nodes
+		for k := range ref {
+			tr.Delete(k)
+		}
+		if tr.Len() != 0 || len(tr.root.children) != 0 {
+			t.Fatal("want empty trie after deleting all keys")
+		}
+	})
+}
+
+func TestTrieByteKeys(t *testing.T) {
+	// the key is copied, changing the caller's slice does not change the trie
+	k := []byte("abc")
+	tr := MakeTrie()
+	tr.Insert(K(k), 1)
+	k[0] = 'x'
+	if !tr.Contains("abc") || tr.Contains("xbc") {
+		t.Fatal("want key copied on insert")
+	}
+}
+
+func TestTriePrefixes(t *testing.T) {
+	tr := MakeTrie()
+	for i, k := range prefixKeys {
+		tr.Insert(k, i)
+	}
+	testPrefixes(t, tr.LongestPrefix, tr.WalkPrefix)
+}
+
+// checkTrie checks that tr contains the same keys and values as ref, and
+// that it has no node that does not lead to a key.
+func checkTrie(t *testing.T, tr *Trie, ref map[K]V) {
+	t.Helper()
+
+	checkRef(t, tr.Len(), tr.Keys(), tr.Get, ref)
+
+	var checkNode func(n *trieNode, depth int)
+	checkNode = func(n *trieNode, depth int) {
+		if !sort.SliceIsSorted(n.labels, func(i, j int) bool { return n.labels[i] < n.labels[j] }) {
+			t.Fatalf("%q: want sorted labels, got %q", n.key, n.labels)
+		}
+		if len(n.labels) != len(n.children) {
+			t.Fatalf("%q: want %d children, got %d", n.key, len(n.labels), len(n.children))
+		}
+		if depth > 0 && !n.ok && len(n.children) == 0 {
+			t.Fatal("want no empty leaf")
+		}
+		for _, c := range n.children {
+			checkNode(c, depth+1)
+		}
+	}
+	checkNode(&tr.root, 0)
+}
+
+// returns a random key of up to 6 bytes over a small alphabet, so that keys
+// share many prefixes. It may return the empty key.
+func randomKey(r *rand.Rand) K {
+	var sb strings.Builder
+	n := r.Intn(7)
+	for i := 0; i < n; i++ {
+		sb.WriteByte("abc"[r.Intn(3)])
+	}
+	return K(sb.String())
+}
+
+// checkRef checks that a tree with the len, keys and get function contains
+// the same keys and values as ref.
+func checkRef(t *testing.T, n int, keys []K, get func(K) (V, bool), ref map[K]V) {
+	t.Helper()
+
+	want := make([]K, 0, len(ref))
+	for k := range ref {
+		want = append(want, k)
+	}
+	sort.Strings(want)
+
+	if n != len(ref) {
+		t.Fatalf("want len %d, got %d", len(ref), n)
+	}
+	if diff := cmp.Diff(want, keys, cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("keys: %s", diff)
+	}
+	for _, k := range want {
+		if v, ok := get(k); !ok || v != ref[k] {
+			t.Fatalf("%q: want value %d, got %d", k, ref[k], v)
+		}
+	}
+}
+
+// the keys inserted for testPrefixes, the value of each key is its index.
+var prefixKeys = []K{"", "a", "abc", "abcde", "abd", "b", "ba", "romane", "romanus", "romulus"}
+
+// testPrefixes tests the LongestPrefix and WalkPrefix functions of a tree
+// that contains prefixKeys.
+func testPrefixes(t *testing.T, longest func(K) (K, V, bool), walk func(K, func(K, V) bool)) {
+	t.Helper()
+
+	cases := []struct {
+		k    K
+		want K
+	}{
+		{"", ""},
+		{"x", ""},
+		{"a", "a"},
+		{"ab", "a"},
+		{"abc", "abc"},
+		{"abcd", "abc"},
+		{"abcdef", "abcde"},
+		{"abdx", "abd"},
+		{"bab", "ba"},
+		{"roman", ""},
+		{"romanes", "romane"},
+	}
+	for _, c := range cases {
+		k, v, ok := longest(c.k)
+		if !ok || k != c.want || prefixKeys[v] != c.want {
+			t.Fatalf("%q: want longest prefix %q, got %q (%t)", c.k, c.want, k, ok)
+		}
+	}
+
+	walks := []struct {
+		prefix K
+		want   []K
+	}{
+		{"", prefixKeys},
+		{"a", []K{"a", "abc", "abcde", "abd"}},
+		{"ab", []K{"abc", "abcde", "abd"}},
+		{"abc", []K{"abc", "abcde"}},
+		{"abcdef", nil},
+		{"b", []K{"b", "ba"}},
+		{"c", nil},
+		{"r", []K{"romane", "romanus", "romulus"}},
+		{"rom", []K{"romane", "romanus", "romulus"}},
+		{"roma", []K{"romane", "romanus"}},
+		{"romb", nil},
+		{"romulus", []K{"romulus"}},
+	}
+	for _, c := range walks {
+		var got []K
+		walk(c.prefix, func(k K, v V) bool {
+			if prefixKeys[v] != k {
+				t.Fatalf("%q: want value of %q, got %d", c.prefix, k, v)
+			}
+			got = append(got, k)
+			return true
+		})
+		if diff := cmp.Diff(c.want, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Fatalf("%q: %s", c.prefix, diff)
+		}
+	}
+
+	// stop early
+	var got []K
+	walk("a", func(k K, v V) bool {
+		got = append(got, k)
+		return k != "abc"
+	})
+	if diff := cmp.Diff([]K{"a", "abc"}, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,26 @@
+package tries
+
+type (
+	K = string // NOTE: generic type placeholder
+	V = int    // NOTE: generic type placeholder
+)
+
+// returns a copy of k, so that a key stored in a tree cannot be modified
+// by the caller (if it is a []byte).
+func cloneKey /*[K algo.Bytes]*/ (k K) K {
+	return K(append([]byte(nil), k...))
+}
+
+// returns the length of the longest common prefix of a and b.
+func commonPrefix /*[K algo.Bytes]*/ (a, b K) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}